@@ -0,0 +1,83 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/it-timo/goboot/pkg/config"
+	"github.com/it-timo/goboot/pkg/goboot"
+)
+
+// cmdAdd is the name of the add subcommand.
+const cmdAdd = "add"
+
+// errMissingService is returned by runAdd if no service ID is given.
+var errMissingService = errors.New("missing service ID (usage: goboot add [flags] <service>)")
+
+// runAdd adds a single service to an already generated project.
+//
+// The service config is taken from the declaration of the service in goboot.yml
+// (inline config, confPath, or built-in defaults), whether the service is enabled there or not.
+// Plugins declared there are added like built-in services.
+//
+// It returns errUpgradeConflicts if any affected file could not be merged cleanly.
+func runAdd(args []string) error {
+	fs := flag.NewFlagSet("goboot add", flag.ContinueOnError)
+	projectDir := ""
+	conflictStyle := ""
+	allowPlugins := false
+
+	fs.StringVar(&projectDir, "dir", ".", "Path to the generated project (containing .goboot.lock)")
+	cfgFlags := addConfigFlags(fs, "Path to the goboot config file declaring the service")
+	fs.StringVar(&conflictStyle, "conflict-style", "",
+		"How to write merge conflicts: markers (default) or rej (writes <file>.rej)")
+	fs.BoolVar(&allowPlugins, "allow-plugins", false, allowPluginsUsage)
+
+	serviceID, err := parseWithArgument(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if serviceID == "" {
+		return errMissingService
+	}
+
+	service, err := declaredService(cfgFlags, serviceID)
+	if err != nil {
+		return err
+	}
+
+	report, err := goboot.Add(projectDir, service, conflictStyle, allowPlugins)
+	if err != nil {
+		return fmt.Errorf("failed to add %q: %w", serviceID, withPluginsHint(err))
+	}
+
+	err = report.Print(outputWriter)
+	if err != nil {
+		fmt.Println("Failed to write add report to output:", err)
+	}
+
+	conflicts := report.Count(goboot.UpgradeConflict)
+	if conflicts > 0 {
+		return fmt.Errorf("%w: resolve %d files and run goboot verify", errUpgradeConflicts, conflicts)
+	}
+
+	return nil
+}
+
+// declaredService returns the declaration of serviceID in the goboot config of the flags.
+func declaredService(cfgFlags *configFlags, serviceID string) (config.ServiceConfigMeta, error) {
+	cfg, err := cfgFlags.initConfig()
+	if err != nil {
+		return config.ServiceConfigMeta{}, err
+	}
+
+	for _, svc := range cfg.Services {
+		if svc.ID == serviceID {
+			return svc, nil
+		}
+	}
+
+	return config.ServiceConfigMeta{}, fmt.Errorf("service %q is not declared in %s", serviceID, cfgFlags)
+}
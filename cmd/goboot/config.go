@@ -0,0 +1,76 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/it-timo/goboot/pkg/config"
+	"github.com/it-timo/goboot/pkg/goboot"
+)
+
+// cmdConfig is the name of the config subcommand, which groups the commands maintaining config files.
+const cmdConfig = "config"
+
+// cmdConfigMigrate is the name of the config command upgrading config files to the current config version.
+const cmdConfigMigrate = "migrate"
+
+// errMissingConfigCommand is returned by runConfig without a known config command.
+var errMissingConfigCommand = errors.New("missing config command (usage: goboot config migrate [flags])")
+
+// runConfig dispatches to the config command named by the first argument.
+func runConfig(args []string) error {
+	if len(args) == 0 {
+		return errMissingConfigCommand
+	}
+
+	switch args[0] {
+	case cmdConfigMigrate:
+		return runConfigMigrate(args[1:])
+	default:
+		return fmt.Errorf("unknown config command %q: %w", args[0], errMissingConfigCommand)
+	}
+}
+
+// runConfigMigrate upgrades goboot config files and the service config files they reference
+// to the current config version (see config.ConfigVersion) in place, keeping their comments.
+func runConfigMigrate(args []string) error {
+	fs := flag.NewFlagSet("goboot config migrate", flag.ContinueOnError)
+
+	var paths stringList
+
+	fs.Var(&paths, "config", "Path to the goboot config file to migrate (default "+defaultConfigPath+"); repeatable")
+
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("failed to parse flags: %w", err)
+	}
+
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments: %v", fs.Args())
+	}
+
+	if len(paths) == 0 {
+		paths = stringList{defaultConfigPath}
+	}
+
+	for _, path := range paths {
+		files, err := goboot.ServiceConfigFactories().MigrateConfigFiles(path)
+		if err != nil {
+			return fmt.Errorf("failed to migrate configs: %w", err)
+		}
+
+		for _, file := range files {
+			msg := fmt.Sprintf("%s is up to date (version %d)\n", file.Path, config.ConfigVersion)
+			if file.Migrated() {
+				msg = fmt.Sprintf("migrated %s from version %d to %d\n", file.Path, file.From, config.ConfigVersion)
+			}
+
+			_, err = fmt.Fprint(outputWriter, msg)
+			if err != nil {
+				return fmt.Errorf("failed to write output: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
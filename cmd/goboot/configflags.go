@@ -0,0 +1,76 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/it-timo/goboot/pkg/config"
+	"github.com/it-timo/goboot/pkg/goboot"
+)
+
+// defaultConfigPath is the goboot config read if no -config flag is given.
+const defaultConfigPath = "./configs/goboot.yml"
+
+// stringList is a flag.Value collecting the values of a repeatable flag.
+type stringList []string
+
+// String returns the collected values, separated by commas.
+func (l *stringList) String() string {
+	return strings.Join(*l, ", ")
+}
+
+// Set appends a value.
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+
+	return nil
+}
+
+// configFlags holds the repeatable -config and -set flags of the commands reading goboot.yml.
+type configFlags struct {
+	paths     stringList
+	overrides stringList
+}
+
+// addConfigFlags registers the -config and -set flags on fs, describing the config file with usage.
+func addConfigFlags(fs *flag.FlagSet, usage string) *configFlags {
+	cf := &configFlags{}
+
+	fs.Var(&cf.paths, "config", usage+" (default "+defaultConfigPath+"); repeat to deep-merge overlays in order")
+	fs.Var(&cf.overrides, "set",
+		"Override a config value after merging, as key.path=value (e.g., services.base_lint.enabled=false); repeatable")
+
+	return cf
+}
+
+// String returns the config files, for messages.
+func (cf *configFlags) String() string {
+	if len(cf.paths) == 0 {
+		return defaultConfigPath
+	}
+
+	return cf.paths.String()
+}
+
+// initConfig reads, merges, and validates the config files and overrides of the flags.
+func (cf *configFlags) initConfig() (*config.GoBoot, error) {
+	paths := cf.paths
+	if len(paths) == 0 {
+		paths = stringList{defaultConfigPath}
+	}
+
+	cfg := config.NewGoBoot(goboot.ServiceConfigFactories(), paths[0], paths[1:]...)
+
+	err := cfg.SetOverrides(cf.overrides)
+	if err != nil {
+		return nil, fmt.Errorf("invalid -set flag: %w", err)
+	}
+
+	err = cfg.Init()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize configuration: %w", err)
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,558 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/it-timo/goboot/pkg/config"
+	"github.com/it-timo/goboot/pkg/goboot"
+	"github.com/it-timo/goboot/pkg/goboottypes"
+)
+
+// cmdInit is the name of the init subcommand.
+const cmdInit = "init"
+
+// initHeader is written on top of every config file written by runInit.
+const initHeader = "#  Written by \"goboot init\". Edit as needed; see the goboot README for all fields.\n\n"
+
+// noGitProvider is the answer for projects hosted on none of goboottypes.GitProviders.
+const noGitProvider = "none"
+
+// initFile is a config file written by runInit.
+type initFile struct {
+	path string // Path of the file.
+	data []byte // YAML content, without initHeader.
+}
+
+// runInit asks for the values of a new project on stdin and writes goboot.yml plus one config file per service.
+//
+// Every question offers a default (taken from the built-in service defaults) and, where known,
+// the allowed values; an empty answer takes the default. Once stdin is exhausted, the remaining
+// questions take their defaults, so the prompt flow can be scripted by piping the answers in.
+//
+// The configs are validated like in a regular run before any file is written.
+func runInit(args []string) error {
+	fs := flag.NewFlagSet("goboot init", flag.ContinueOnError)
+	dir := ""
+	force := false
+
+	fs.StringVar(&dir, "dir", "./configs", "Directory to write goboot.yml and the service configs to")
+	fs.BoolVar(&force, "force", false, "Overwrite existing config files")
+
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("failed to parse flags: %w", err)
+	}
+
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments: %v", fs.Args())
+	}
+
+	files, err := initConfig(newPrompter(inputReader, outputWriter), dir)
+	if err != nil {
+		return err
+	}
+
+	return writeInitFiles(files, dir, force)
+}
+
+// initConfig asks for the goboot config and the configs of the selected services.
+//
+// It returns the files to write, with goboot.yml last, after validating every config.
+func initConfig(p *prompter, dir string) ([]initFile, error) {
+	cfg := config.NewGoBoot(goboot.ServiceConfigFactories(), "")
+
+	ids, err := askGoBoot(p, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]initFile, 0, len(ids)+1)
+
+	for _, id := range ids {
+		data, err := askServiceConfig(p, cfg, id)
+		if err != nil {
+			return nil, err
+		}
+
+		// Validate the config the way it will be read back from the file.
+		err = cfg.LoadServiceConfig(id, data)
+		if err != nil {
+			return nil, err
+		}
+
+		files = append(files, initFile{path: filepath.Join(dir, id+".yml"), data: data})
+	}
+
+	for i := range cfg.Services {
+		cfg.Services[i].ConfPath = filepath.ToSlash(files[i].path)
+	}
+
+	err = cfg.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := encodeConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode goboot config: %w", err)
+	}
+
+	return append(files, initFile{path: filepath.Join(dir, "goboot.yml"), data: data}), nil
+}
+
+// askGoBoot asks for the fields of the goboot config and the services to run.
+//
+// It returns the IDs of the selected services.
+func askGoBoot(p *prompter, cfg *config.GoBoot) ([]string, error) {
+	var err error
+
+	cfg.ProjectName, err = p.ask(question{text: "Project name", check: config.ValidateProjectName})
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.RepoURL, err = p.ask(question{
+		text:  "Repository URL (e.g., https://github.com/user/project)",
+		check: config.ValidateRepoURL,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.TargetPath, err = p.ask(question{text: "Directory to create the project directory in", def: "."})
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.ConflictPolicy, err = p.ask(question{
+		text:    "Policy for existing files",
+		def:     goboottypes.DefaultConflictPolicy,
+		choices: goboottypes.ConflictPolicies(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	services, err := p.ask(question{
+		text:    "Services to run, comma-separated",
+		def:     strings.Join(serviceNames, ","),
+		choices: serviceNames,
+		list:    true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resolveServices(services)
+}
+
+// askServiceConfig asks for the fields of the given service, starting from its built-in defaults.
+//
+// It returns the config encoded as YAML.
+func askServiceConfig(p *prompter, cfg *config.GoBoot, id string) ([]byte, error) {
+	svcCfg, err := cfg.NewServiceConfig(id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = p.section(id)
+	if err != nil {
+		return nil, err
+	}
+
+	switch svc := svcCfg.(type) {
+	case *config.BaseProjectConfig:
+		err = askBaseProject(p, svc)
+	case *config.BaseLintConfig:
+		err = askBaseLint(p, svc)
+	case *config.BaseTestConfig:
+		svc.UseStyle, err = p.ask(question{
+			text: "Test style", def: svc.UseStyle, choices: goboottypes.TestStyles(),
+		})
+	case *config.BaseLocalConfig:
+		var files string
+
+		files, err = p.ask(question{
+			text: "Local tooling, comma-separated", def: strings.Join(svc.FileList, ","),
+			choices: goboottypes.ScriptNames(), list: true,
+		})
+		svc.FileList = splitList(files)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := encodeConfig(svcCfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode config for %q: %w", id, err)
+	}
+
+	return data, nil
+}
+
+// askBaseProject asks for the fields of the base_project config.
+func askBaseProject(p *prompter, cfg *config.BaseProjectConfig) error {
+	// The fixed windows of the built-in defaults go stale; new configs start from the current date instead.
+	cfg.ReleaseCurrentWindow, cfg.ReleaseUpcomingWindow, cfg.ReleaseLongTerm = config.DefaultReleaseWindows(time.Now())
+
+	fields := []struct {
+		field *string
+		q     question
+	}{
+		{&cfg.UsedGoVersion, question{
+			text: "Go version (or \"" + goboottypes.GoVersionAuto + "\" for the local toolchain)", def: cfg.UsedGoVersion,
+			check: config.ValidateGoVersion,
+		}},
+		{&cfg.UsedNodeVersion, question{
+			text: "Node.js version", def: cfg.UsedNodeVersion, check: config.ValidateNodeVersion,
+		}},
+		{&cfg.ReleaseCurrentWindow, question{
+			text: "Current release window", def: cfg.ReleaseCurrentWindow, check: config.ValidateReleaseWindow,
+		}},
+		{&cfg.ReleaseUpcomingWindow, question{
+			text: "Upcoming release window", def: cfg.ReleaseUpcomingWindow, check: config.ValidateReleaseWindow,
+		}},
+		{&cfg.ReleaseLongTerm, question{
+			text: "Long-term release target", def: cfg.ReleaseLongTerm, check: config.ValidateReleaseYear,
+		}},
+		{&cfg.Author, question{text: "Author", def: cfg.Author}},
+		{&cfg.GitProvider, question{
+			text: "Git provider for badges and links", def: gitProvider(cfg.ProjectURL),
+			choices: append(goboottypes.GitProviders(), noGitProvider),
+		}},
+	}
+
+	for _, f := range fields {
+		answer, err := p.ask(f.q)
+		if err != nil {
+			return err
+		}
+
+		*f.field = answer
+	}
+
+	if cfg.GitProvider == noGitProvider {
+		cfg.GitProvider = ""
+
+		return nil
+	}
+
+	var err error
+
+	cfg.GitUser, err = p.ask(question{text: "Git user or organization", def: gitUser(cfg.ProjectURL)})
+
+	return err
+}
+
+// askBaseLint asks for the linters of the base_lint config; every linter runs its default command.
+func askBaseLint(p *prompter, cfg *config.BaseLintConfig) error {
+	var enabled []string
+
+	for _, name := range config.LinterNames() {
+		linter, ok := cfg.Linters[name]
+		if ok && linter.Enabled {
+			enabled = append(enabled, name)
+		}
+	}
+
+	answer, err := p.ask(question{
+		text: "Linters, comma-separated", def: strings.Join(enabled, ","),
+		choices: config.LinterNames(), list: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	enabled = splitList(answer)
+	cfg.Linters = make(map[string]*config.Linter)
+
+	for _, name := range config.LinterNames() {
+		cfg.Linters[name] = &config.Linter{Enabled: slices.Contains(enabled, name)}
+	}
+
+	return nil
+}
+
+// gitProvider returns the git provider hosting repoURL, or noGitProvider if it is not a known provider.
+func gitProvider(repoURL string) string {
+	parsed, err := url.Parse(repoURL)
+	if err != nil {
+		return noGitProvider
+	}
+
+	for _, provider := range goboottypes.GitProviders() {
+		if parsed.Host == provider+".com" {
+			return provider
+		}
+	}
+
+	return noGitProvider
+}
+
+// gitUser returns the first path segment of repoURL (e.g., "user" for "https://github.com/user/project").
+func gitUser(repoURL string) string {
+	parsed, err := url.Parse(repoURL)
+	if err != nil {
+		return ""
+	}
+
+	user, _, _ := strings.Cut(strings.TrimPrefix(parsed.Path, "/"), "/")
+
+	return user
+}
+
+// encodeConfig encodes a config as YAML, leaving out fields with zero values (e.g., an empty sourcePath),
+// which decode to the same config.
+func encodeConfig(cfg any) ([]byte, error) {
+	var node yaml.Node
+
+	err := node.Encode(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode config: %w", err)
+	}
+
+	dropZeroValues(&node)
+
+	var buf bytes.Buffer
+
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+
+	err = enc.Encode(&node)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode config: %w", err)
+	}
+
+	err = enc.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode config: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// dropZeroValues removes all mapping entries with an empty string, zero, null, or empty sequence value.
+func dropZeroValues(node *yaml.Node) {
+	for _, child := range node.Content {
+		dropZeroValues(child)
+	}
+
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+
+	content := node.Content[:0]
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		value := node.Content[i+1]
+
+		isZero := value.Kind == yaml.ScalarNode && (value.Tag == "!!null" ||
+			value.Tag == "!!str" && value.Value == "" || value.Tag == "!!int" && value.Value == "0")
+		if isZero || value.Kind == yaml.SequenceNode && len(value.Content) == 0 {
+			continue
+		}
+
+		content = append(content, node.Content[i], value)
+	}
+
+	node.Content = content
+}
+
+// writeInitFiles writes the config files to dir.
+//
+// Unless force is set, it fails before writing anything if one of the files already exists.
+func writeInitFiles(files []initFile, dir string, force bool) error {
+	if !force {
+		for _, file := range files {
+			_, err := os.Stat(file.path)
+			if err == nil {
+				return fmt.Errorf("%s already exists (use -force to overwrite)", file.path)
+			}
+		}
+	}
+
+	err := os.MkdirAll(dir, goboottypes.DirPerm)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+
+	for _, file := range files {
+		err = os.WriteFile(file.path, append([]byte(initHeader), file.data...), goboottypes.FilePerm)
+		if err != nil {
+			return fmt.Errorf("failed to write %s: %w", file.path, err)
+		}
+
+		_, err = fmt.Fprintf(outputWriter, "wrote %s\n", file.path)
+		if err != nil {
+			return fmt.Errorf("failed to write output: %w", err)
+		}
+	}
+
+	_, err = fmt.Fprintf(outputWriter, "Generate the project with: goboot -config %s\n", files[len(files)-1].path)
+	if err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+
+	return nil
+}
+
+// question is a single prompt of the init wizard.
+type question struct {
+	text    string   // Question shown to the user.
+	def     string   // Answer taken for an empty input.
+	choices []string // Allowed answers; any answer is allowed if empty.
+	list    bool     // Whether the answer is a comma-separated list of choices.
+
+	check func(string) error // Additional check of the answer; optional.
+}
+
+// validate checks an answer against the question.
+func (q question) validate(answer string) error {
+	if answer == "" {
+		return errors.New("an answer is required")
+	}
+
+	if q.check != nil {
+		err := q.check(answer)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(q.choices) == 0 {
+		return nil
+	}
+
+	values := []string{answer}
+	if q.list {
+		values = splitList(answer)
+	}
+
+	for _, value := range values {
+		if !slices.Contains(q.choices, value) {
+			return fmt.Errorf("%q is not one of: %s", value, strings.Join(q.choices, ", "))
+		}
+	}
+
+	return nil
+}
+
+// prompter asks questions on an output and reads the answers line by line from an input.
+type prompter struct {
+	in  *bufio.Scanner
+	out io.Writer
+	eof bool // Whether the input is exhausted.
+}
+
+// newPrompter returns a prompter reading answers from in and writing questions to out.
+func newPrompter(in io.Reader, out io.Writer) *prompter {
+	return &prompter{in: bufio.NewScanner(in), out: out}
+}
+
+// section prints a heading for the questions of a service.
+func (p *prompter) section(title string) error {
+	_, err := fmt.Fprintf(p.out, "\n%s\n", title)
+	if err != nil {
+		return fmt.Errorf("failed to write prompt: %w", err)
+	}
+
+	return nil
+}
+
+// ask prints the question and reads the answer, repeating the question until the answer is valid.
+//
+// An empty answer, or the end of the input, takes the default. If the input ends without a valid answer
+// (e.g., after a typo in a piped answer), an error is returned instead of falling back to the default.
+func (p *prompter) ask(q question) (string, error) {
+	var invalid error
+
+	for {
+		answer, err := p.readAnswer(q)
+		if err != nil {
+			return "", err
+		}
+
+		if p.eof && invalid != nil {
+			return "", fmt.Errorf("no valid answer for %q: %w", q.text, invalid)
+		}
+
+		if answer == "" {
+			answer = q.def
+		}
+
+		invalid = q.validate(answer)
+		if invalid == nil {
+			return answer, nil
+		}
+
+		if p.eof {
+			return "", fmt.Errorf("no valid answer for %q: %w", q.text, invalid)
+		}
+
+		_, err = fmt.Fprintf(p.out, "  %s\n", invalid)
+		if err != nil {
+			return "", fmt.Errorf("failed to write prompt: %w", err)
+		}
+	}
+}
+
+// readAnswer prints the question with its choices and default and reads a single line of input.
+func (p *prompter) readAnswer(q question) (string, error) {
+	prompt := q.text
+	if len(q.choices) > 0 {
+		prompt += " (" + strings.Join(q.choices, ", ") + ")"
+	}
+
+	if q.def != "" {
+		prompt += " [" + q.def + "]"
+	}
+
+	_, err := fmt.Fprintf(p.out, "%s: ", prompt)
+	if err != nil {
+		return "", fmt.Errorf("failed to write prompt: %w", err)
+	}
+
+	if p.eof || !p.in.Scan() {
+		p.eof = true
+
+		_, err = fmt.Fprintln(p.out)
+		if err != nil {
+			return "", fmt.Errorf("failed to write prompt: %w", err)
+		}
+
+		if err = p.in.Err(); err != nil {
+			return "", fmt.Errorf("failed to read answer: %w", err)
+		}
+
+		return "", nil
+	}
+
+	return strings.TrimSpace(p.in.Text()), nil
+}
+
+// splitList splits a comma-separated answer into its trimmed, non-empty values.
+func splitList(answer string) []string {
+	var values []string
+
+	for value := range strings.SplitSeq(answer, ",") {
+		value = strings.TrimSpace(value)
+		if value != "" {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
@@ -3,6 +3,16 @@ Package main initializes and executes the goboot CLI.
 
 It loads the main YAML configuration, registers all enabled services, and executes each one in order.
 
+Subcommands:
+  - verify: detect drift between a generated project and its .goboot.lock
+  - upgrade: three-way merge the current templates and configs into a generated project
+  - add: add a single service to a generated project
+  - new: generate a project from flags and built-in defaults, without config files
+  - init: write goboot.yml and the service configs from answers read from stdin
+  - schema: emit the JSON Schemas of the config files
+  - validate: validate the config files and all enabled service configs without generating anything
+  - config migrate: upgrade the config files to the current config version in place, keeping their comments
+
 Errors during any stage cause early termination.
 */
 package main
@@ -15,57 +25,126 @@ import (
 
 	"github.com/it-timo/goboot/pkg/config"
 	"github.com/it-timo/goboot/pkg/goboot"
+	"github.com/it-timo/goboot/pkg/goboottypes"
 )
 
 var (
 	exitFunc               = os.Exit
 	outputWriter io.Writer = os.Stdout
+	inputReader  io.Reader = os.Stdin
 )
 
-// run executes the whole goboot CLI with config load, app init, service registration and execution.
+// subcommands maps subcommand names to their entry points.
+var subcommands = map[string]func(args []string) error{
+	cmdVerify:   runVerify,
+	cmdUpgrade:  runUpgrade,
+	cmdAdd:      runAdd,
+	cmdNew:      runNew,
+	cmdInit:     runInit,
+	cmdSchema:   runSchema,
+	cmdValidate: runValidate,
+	cmdConfig:   runConfig,
+}
+
+// run dispatches to the subcommand named by the first argument.
+//
+// Without a known subcommand, the project is generated (see runGenerate).
 func run(args []string) error {
+	if len(args) > 0 {
+		subcommand, ok := subcommands[args[0]]
+		if ok {
+			return subcommand(args[1:])
+		}
+	}
+
+	return runGenerate(args)
+}
+
+// parseWithArgument parses the flags of a subcommand taking a single positional argument,
+// which may appear before, between, or after the flags (e.g., "goboot add base_lint -dir ./project").
+//
+// It returns the positional argument, or an empty string if none is given.
+func parseWithArgument(fs *flag.FlagSet, args []string) (string, error) {
+	if err := fs.Parse(args); err != nil {
+		return "", fmt.Errorf("failed to parse flags: %w", err)
+	}
+
+	arg := fs.Arg(0)
+	if arg == "" {
+		return "", nil
+	}
+
+	if err := fs.Parse(fs.Args()[1:]); err != nil {
+		return "", fmt.Errorf("failed to parse flags: %w", err)
+	}
+
+	if fs.NArg() > 0 {
+		return "", fmt.Errorf("unexpected arguments: %v", fs.Args())
+	}
+
+	return arg, nil
+}
+
+// runGenerate executes the whole goboot generation with config load, app init, service registration and execution.
+func runGenerate(args []string) error {
 	// Step 0: Parse flags explicitly using a local FlagSet to avoid global state.
 	fs := flag.NewFlagSet("goboot", flag.ContinueOnError)
-	configPath := ""
+	dryRun := false
+	conflictPolicy := ""
 
-	fs.StringVar(&configPath, "config", "./configs/goboot.yml", "Path to the goboot config file")
+	cfgFlags := addConfigFlags(fs, "Path to the goboot config file")
+	fs.BoolVar(&dryRun, "dry-run", false, "Show the files, scripts, and commands of a run without touching the disk")
+	fs.StringVar(&conflictPolicy, "conflict-policy", "",
+		"Policy for existing files: fail, skip-existing, overwrite, or backup (overrides goboot.yml)")
 
 	if err := fs.Parse(args); err != nil {
 		return fmt.Errorf("failed to parse flags: %w", err)
 	}
 
-	// Step 1: Load and validate goboot configuration from YAML.
-	cfg := config.NewGoBoot(configPath)
-
-	err := cfg.Init()
+	// Step 1: Load, merge, and validate goboot configuration from YAML.
+	cfg, err := cfgFlags.initConfig()
 	if err != nil {
-		return fmt.Errorf("failed to initialize configuration: %w", err)
+		return err
 	}
 
-	_, err = fmt.Fprintf(outputWriter, "Loaded configuration: %+#v\n", cfg)
-	if err != nil {
-		fmt.Println("Failed to write error to output:", err)
+	if conflictPolicy != "" {
+		err = cfg.SetConflictPolicy(conflictPolicy)
+		if err != nil {
+			return fmt.Errorf("invalid -conflict-policy flag: %w", err)
+		}
 	}
 
-	// Step 2: Create a new goboot application instance.
-	app := goboot.NewGoBoot(cfg)
+	return generate(cfg, dryRun)
+}
 
-	// Step 3: Register all declared and enabled services.
-	err = app.RegisterServices()
+// generate runs all services of the loaded and validated configuration and reports the result.
+func generate(cfg *config.GoBoot, dryRun bool) error {
+	_, err := fmt.Fprintf(outputWriter, "Loaded configuration: %s\n", cfg)
 	if err != nil {
-		return fmt.Errorf("service registration failed: %w", err)
+		fmt.Println("Failed to write error to output:", err)
 	}
 
-	// Step 4: Execute all registered services.
-	err = app.RunServices()
+	// Step 2: Register and execute all declared and enabled services, and run go mod tidy if the go.mod file exists.
+	report, err := goboot.Generate(cfg, goboot.Options{
+		Logger:    goboottypes.NewStdoutLogger(),
+		DryRun:    dryRun,
+		GoModTidy: true,
+	})
 	if err != nil {
-		return fmt.Errorf("service execution failed: %w", err)
+		return err
 	}
 
-	// Step 5: Run go mod tidy if the go.mod file exists.
-	err = app.RunGoModTidy(true)
-	if err != nil {
-		return fmt.Errorf("failed to run go mod tidy: %w", err)
+	// Step 3: Show what the dry run would have done, or the conflicts the run resolved.
+	if dryRun {
+		err = report.Print(outputWriter)
+		if err != nil {
+			return fmt.Errorf("failed to print dry run report: %w", err)
+		}
+	} else {
+		err = report.PrintConflicts(outputWriter)
+		if err != nil {
+			fmt.Println("Failed to write conflicts to output:", err)
+		}
 	}
 
 	_, err = fmt.Fprintln(outputWriter, "goboot execution completed successfully.")
@@ -1,14 +1,25 @@
 package main
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"io/fs"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"regexp"
 	"runtime"
 	"strings"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+
+	"github.com/it-timo/goboot/pkg/config"
+	"github.com/it-timo/goboot/pkg/goboot"
+	"github.com/it-timo/goboot/pkg/goboottypes"
+	"github.com/it-timo/goboot/pkg/gobootutils"
 )
 
 // repoRoot returns the repository root based on this test file location.
@@ -36,6 +47,107 @@ func readFile(path string) string {
 	return string(data)
 }
 
+// readTree returns all regular files below root, keyed by their slash-separated relative path.
+func readTree(root string) map[string]string {
+	files := make(map[string]string)
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+
+		files[filepath.ToSlash(rel)] = readFile(path)
+
+		return nil
+	})
+	Expect(err).NotTo(HaveOccurred())
+
+	return files
+}
+
+// writeAllServiceConfigs writes a goboot config with all built-in services enabled and returns its path.
+func writeAllServiceConfigs(tempDir, projectName, repoURL, targetDir string) string {
+	root := repoRoot(GinkgoT())
+
+	baseProjectCfg := filepath.Join(tempDir, "base_project.yml")
+	writeConfig(baseProjectCfg, fmt.Sprintf(`
+sourcePath: %s
+usedGoVersion: "1.22.5"
+usedNodeVersion: "20.0.0"
+releaseCurrentWindow: "Q1 2026"
+releaseUpcomingWindow: "Q3 2026"
+releaseLongTerm: "2029"
+author: "E2E Author"
+gitProvider: "github"
+gitUser: "example"
+`, filepath.Join(root, "templates", "project_base")))
+
+	baseLintCfg := filepath.Join(tempDir, "base_lint.yml")
+	writeConfig(baseLintCfg, fmt.Sprintf(`
+sourcePath: %s
+linters:
+  golang:
+    enabled: true
+  yaml:
+    enabled: true
+  make:
+    enabled: true
+  markdown:
+    enabled: true
+  shellcheck:
+    enabled: true
+  shfmt:
+    enabled: true
+`, filepath.Join(root, "templates", "lint_base")))
+
+	baseTestCfg := filepath.Join(tempDir, "base_test.yml")
+	writeConfig(baseTestCfg, fmt.Sprintf(`
+sourcePath: %s
+useStyle: "ginkgo"
+`, filepath.Join(root, "templates", "test_base")))
+
+	baseLocalCfg := filepath.Join(tempDir, "base_local.yml")
+	writeConfig(baseLocalCfg, fmt.Sprintf(`
+sourcePath: %s
+fileList:
+  - make
+  - task
+  - script
+  - commit
+`, filepath.Join(root, "templates", "local_base")))
+
+	gobootCfg := filepath.Join(tempDir, "goboot.yml")
+	writeConfig(gobootCfg, fmt.Sprintf(`
+projectName: %s
+repoUrl: %s
+targetPath: %s
+services:
+  - id: base_project
+    confPath: %s
+    enabled: true
+  - id: base_lint
+    confPath: %s
+    enabled: true
+  - id: base_test
+    confPath: %s
+    enabled: true
+  - id: base_local
+    confPath: %s
+    enabled: true
+`, projectName, repoURL, targetDir, baseProjectCfg, baseLintCfg, baseTestCfg, baseLocalCfg))
+
+	return gobootCfg
+}
+
 var _ = Describe("End-to-end goboot runs", func() {
 	It("scaffolds a full project with all services enabled (ginkgo style)", func() {
 		defer withFakeGo()()
@@ -160,6 +272,977 @@ services:
 		Expect(ginkgoSuite).To(ContainSubstring("E2EGinkgo Suite"))
 	})
 
+	It("generates byte-identical output for the same input", func() {
+		defer withFakeGo()()
+		tempDir := GinkgoT().TempDir()
+		projectName := "E2EStable"
+		repoURL := "github.com/example/e2e-stable"
+
+		firstTarget := filepath.Join(tempDir, "first")
+		secondTarget := filepath.Join(tempDir, "second")
+
+		firstCfg := writeAllServiceConfigs(tempDir, projectName, repoURL, firstTarget)
+		Expect(run([]string{"--config", firstCfg})).To(Succeed())
+
+		secondCfgDir := filepath.Join(tempDir, "second-configs")
+		Expect(os.MkdirAll(secondCfgDir, 0o755)).To(Succeed())
+		secondCfg := writeAllServiceConfigs(secondCfgDir, projectName, repoURL, secondTarget)
+		Expect(run([]string{"--config", secondCfg})).To(Succeed())
+
+		first := readTree(filepath.Join(firstTarget, projectName))
+		second := readTree(filepath.Join(secondTarget, projectName))
+
+		Expect(first).NotTo(BeEmpty())
+		Expect(first).To(HaveKey("Makefile"))
+		Expect(second).To(HaveLen(len(first)))
+
+		for name, content := range first {
+			Expect(second).To(HaveKeyWithValue(name, content), "file %q differs between runs", name)
+		}
+	})
+
+	It("scaffolds from the embedded templates outside the repository", func() {
+		defer withFakeGo()()
+		tempDir := GinkgoT().TempDir()
+		projectName := "E2EBuiltin"
+		repoURL := "github.com/example/e2e-builtin"
+
+		diskTarget := filepath.Join(tempDir, "disk")
+		diskCfg := writeAllServiceConfigs(tempDir, projectName, repoURL, diskTarget)
+		Expect(run([]string{"--config", diskCfg})).To(Succeed())
+
+		// Drop every sourcePath, so all services fall back to their embedded template set.
+		builtinDir := filepath.Join(tempDir, "builtin-configs")
+		Expect(os.MkdirAll(builtinDir, 0o755)).To(Succeed())
+
+		builtinTarget := filepath.Join(tempDir, "builtin")
+		builtinCfg := writeAllServiceConfigs(builtinDir, projectName, repoURL, builtinTarget)
+
+		for _, service := range []string{"base_project", "base_lint", "base_test", "base_local"} {
+			cfgFile := filepath.Join(builtinDir, service+".yml")
+			content := regexp.MustCompile(`(?m)^sourcePath: .*\n`).ReplaceAllString(readFile(cfgFile), "")
+			writeConfig(cfgFile, content)
+		}
+
+		// Run outside the repository, where relative template paths cannot resolve.
+		originalDir, err := os.Getwd()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(os.Chdir(tempDir)).To(Succeed())
+		DeferCleanup(os.Chdir, originalDir)
+
+		Expect(run([]string{"--config", builtinCfg})).To(Succeed())
+
+		disk := readTree(filepath.Join(diskTarget, projectName))
+		builtin := readTree(filepath.Join(builtinTarget, projectName))
+
+		Expect(builtin).To(HaveLen(len(disk)))
+
+		for name, content := range disk {
+			if name == ".goboot.lock" {
+				continue
+			}
+
+			Expect(builtin).To(HaveKeyWithValue(name, content), "file %q differs from the disk templates", name)
+		}
+
+		lock := builtin[".goboot.lock"]
+		Expect(lock).To(ContainSubstring("sourcePath: builtin:project_base"))
+		Expect(lock).To(ContainSubstring("template: builtin:lint_base/.golangci.yml.tmpl"))
+
+		Expect(run([]string{"verify", "--dir", filepath.Join(builtinTarget, projectName)})).To(Succeed())
+	})
+
+	It("scaffolds a full project from a single goboot.yml", func() {
+		defer withFakeGo()()
+		tempDir := GinkgoT().TempDir()
+		projectRoot := filepath.Join(tempDir, "out", "E2ESingle")
+
+		cfgPath := filepath.Join(tempDir, "goboot.yml")
+		writeConfig(cfgPath, fmt.Sprintf(`
+projectName: E2ESingle
+repoUrl: github.com/example/e2e-single
+targetPath: %s
+services:
+  - {id: base_project, enabled: true}
+  - {id: base_lint, enabled: true}
+  - {id: base_test, enabled: true, config: {useStyle: go}}
+  - {id: base_local, enabled: true}
+`, filepath.Join(tempDir, "out")))
+
+		Expect(run([]string{"--config", cfgPath})).To(Succeed())
+
+		files := readTree(projectRoot)
+		Expect(files).To(HaveKey("go.mod"))
+		Expect(files).To(HaveKey(".golangci.yml"))
+		Expect(files).To(HaveKey("Taskfile.yml"))
+		Expect(files).NotTo(HaveKey(ContainSubstring("suite_test.go")))
+		Expect(files["Makefile"]).To(ContainSubstring(goboottypes.DefaultGoLintCmd[len("{{DOCKER_RUN}} "):]))
+
+		Expect(files["Makefile"]).To(ContainSubstring("-coverprofile=coverage.txt ./..."))
+
+		Expect(run([]string{"verify", "--dir", projectRoot})).To(Succeed())
+	})
+
+	It("runs the commands of the shipped configs with the built-in defaults", func() {
+		root := repoRoot(GinkgoT())
+		repoURL := "github.com/example/e2e-defaults"
+
+		shipped := func(name string, cfg config.ServiceConfig) {
+			data, err := os.ReadFile(filepath.Join(root, "configs", name))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.DecodeConfig(data, repoURL)).To(Succeed())
+			Expect(cfg.Validate()).To(Succeed())
+		}
+
+		builtin := func(data []byte, cfg config.ServiceConfig) {
+			Expect(cfg.DecodeConfig(data, repoURL)).To(Succeed())
+			Expect(cfg.Validate()).To(Succeed())
+		}
+
+		lintShipped, lintBuiltin := config.NewBaseLintConfig("E2E"), config.NewBaseLintConfig("E2E")
+		shipped("base_lint.yml", lintShipped)
+		builtin(config.BaseLintDefaults(), lintBuiltin)
+
+		for name, linter := range lintShipped.Linters {
+			Expect(lintBuiltin.Linters).To(HaveKey(name))
+			Expect(lintBuiltin.Linters[name].Cmd).To(Equal(linter.Cmd), "command of linter %q", name)
+		}
+
+		testShipped, testBuiltin := config.NewBaseTestConfig("E2E"), config.NewBaseTestConfig("E2E")
+		shipped("base_test.yml", testShipped)
+		builtin(config.BaseTestDefaults(), testBuiltin)
+
+		Expect(strings.Fields(testBuiltin.TestCMD)).To(Equal(strings.Fields(testShipped.TestCMD)))
+	})
+
+	It("runs plugin services next to the built-in services", func() {
+		// Build the plugin before the fake go binary shadows the real one.
+		fakePlugin, err := gexec.Build("github.com/it-timo/goboot/pkg/gobootplugin/testdata/fakeplugin")
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(gexec.CleanupBuildArtifacts)
+
+		defer withFakeGo()()
+		tempDir := GinkgoT().TempDir()
+		projectRoot := filepath.Join(tempDir, "out", "E2EPlugin")
+
+		cfgPath := filepath.Join(tempDir, "goboot.yml")
+		writeConfig(cfgPath, fmt.Sprintf(`
+projectName: E2EPlugin
+repoUrl: github.com/example/e2e-plugin
+targetPath: %s
+services:
+  - {id: base_project, enabled: true}
+  - {id: base_local, enabled: true}
+  - {id: acme_license, plugin: %s, enabled: true, config: {holder: ACME}}
+`, filepath.Join(tempDir, "out"), fakePlugin))
+
+		originalWriter := outputWriter
+		DeferCleanup(func() { outputWriter = originalWriter })
+
+		output := &bytes.Buffer{}
+		outputWriter = output
+
+		// A dry run runs the plugin into memory and reports it.
+		Expect(run([]string{"--config", cfgPath, "--dry-run", "--set", "services.acme_license.holder=Dry"})).To(Succeed())
+		Expect(output.String()).To(ContainSubstring("no commands were executed, except the plugins"))
+		Expect(output.String()).To(ContainSubstring(fakePlugin + " (plugin acme_license)"))
+		Expect(output.String()).To(ContainSubstring("LICENSE_HEADER.txt"))
+		Expect(projectRoot).NotTo(BeADirectory())
+
+		// Plugins are enabled and added like built-in services.
+		Expect(run([]string{"--config", cfgPath, "--set", "services.acme_license.enabled=false"})).To(Succeed())
+		Expect(filepath.Join(projectRoot, "LICENSE_HEADER.txt")).NotTo(BeAnExistingFile())
+		Expect(run([]string{"add", "acme_license", "--dir", projectRoot, "--config", cfgPath})).To(Succeed())
+
+		files := readTree(projectRoot)
+		Expect(files["LICENSE_HEADER.txt"]).To(Equal("Copyright ACME. Part of E2EPlugin.\n"))
+		Expect(files["Makefile"]).To(ContainSubstring("acme_license:\n\tacme-check ./..."))
+		Expect(files["Taskfile.yml"]).To(ContainSubstring("acme_license:"))
+		Expect(files).To(HaveKey("tools/acme/check.sh"))
+
+		lock, err := goboot.ParseLock([]byte(files[goboottypes.LockFileName]))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(lock.Services).To(ContainElement(And(
+			HaveField("ID", "acme_license"),
+			HaveField("Plugin", fakePlugin),
+		)))
+
+		// The plugin path comes from the lock file, so verify only runs it when asked to.
+		err = run([]string{"verify", "--dir", projectRoot})
+		Expect(err).To(MatchError(goboot.ErrPluginsNotAllowed))
+		Expect(err.Error()).To(ContainSubstring("pass -allow-plugins to run them"))
+
+		output.Reset()
+		Expect(run([]string{"verify", "--dir", projectRoot, "--allow-plugins"})).To(Succeed())
+		Expect(output.String()).To(ContainSubstring("Ran " + fakePlugin + " (plugin acme_license)"))
+	})
+
+	It("merges layered configs and -set overrides", func() {
+		defer withFakeGo()()
+		tempDir := GinkgoT().TempDir()
+		projectRoot := filepath.Join(tempDir, "out", "E2ELayered")
+
+		orgCfg := filepath.Join(tempDir, "org.yml")
+		writeConfig(orgCfg, fmt.Sprintf(`
+projectName: E2EOrg
+repoUrl: github.com/example/e2e-org
+targetPath: %s
+services:
+  - {id: base_project, enabled: true}
+  - {id: base_lint, enabled: true}
+  - {id: base_test, enabled: true, config: {useStyle: go}}
+  - {id: base_local, enabled: true}
+`, filepath.Join(tempDir, "out")))
+
+		projectCfg := filepath.Join(tempDir, "project.yml")
+		writeConfig(projectCfg, `
+projectName: E2ELayered
+repoUrl: github.com/example/e2e-layered
+services:
+  - {id: base_local, config: {fileList: [task]}}
+`)
+
+		Expect(run([]string{"--config", orgCfg, "--config", projectCfg,
+			"--set", "services.base_lint.linters.golang.enabled=false"})).To(Succeed())
+
+		files := readTree(projectRoot)
+		Expect(files["go.mod"]).To(HavePrefix("module github.com/example/e2e-layered\n"))
+		Expect(files).To(HaveKey("Taskfile.yml"))
+		Expect(files).NotTo(HaveKey("Makefile"))
+		Expect(files).To(HaveKey(".yamllint.yml"))
+		Expect(files).NotTo(HaveKey(".golangci.yml"))
+
+		Expect(run([]string{"verify", "--dir", projectRoot})).To(Succeed())
+
+		err := run([]string{"--config", orgCfg, "--set", "services.base_lint.linter.golang.enabled=false"})
+		Expect(err).To(MatchError(ContainSubstring(`unknown key "services.base_lint.linter" (did you mean "linters"?)`)))
+	})
+
+	DescribeTable("generates and verifies a project for each profile",
+		func(profile string, present, absent []string) {
+			defer withFakeGo()()
+			tempDir := GinkgoT().TempDir()
+			projectRoot := filepath.Join(tempDir, "out", "profiled")
+
+			cfgPath := filepath.Join(tempDir, "goboot.yml")
+			writeConfig(cfgPath, fmt.Sprintf(`
+projectName: profiled
+repoUrl: github.com/example/profiled
+targetPath: %s
+profile: %s
+`, filepath.Join(tempDir, "out"), profile))
+
+			Expect(run([]string{"--config", cfgPath})).To(Succeed())
+
+			files := readTree(projectRoot)
+			Expect(files).To(HaveKey("go.mod"))
+			Expect(files).To(HaveKey(".golangci.yml"))
+
+			for _, name := range present {
+				Expect(files).To(HaveKey(name), "profile %s", profile)
+			}
+
+			for _, name := range absent {
+				Expect(files).NotTo(HaveKey(name), "profile %s", profile)
+			}
+
+			Expect(run([]string{"verify", "--dir", projectRoot})).To(Succeed())
+		},
+		Entry(goboottypes.ProfileMinimal, goboottypes.ProfileMinimal,
+			[]string{"Makefile"},
+			[]string{".yamllint.yml", "Taskfile.yml", "pkg/profiled/profiled_suite_test.go", "SECURITY.md"}),
+		Entry(goboottypes.ProfileStandard, goboottypes.ProfileStandard,
+			[]string{".yamllint.yml", "Makefile", "Taskfile.yml", "pkg/profiled/profiled_suite_test.go"},
+			[]string{"SECURITY.md", "CONTRIBUTING.md"}),
+		Entry(goboottypes.ProfileEnterprise, goboottypes.ProfileEnterprise,
+			[]string{"SECURITY.md", ".yamllint.yml", "Taskfile.yml", "pkg/profiled/profiled_suite_test.go"},
+			[]string{"CONTRIBUTING.md"}),
+		Entry(goboottypes.ProfileOSS, goboottypes.ProfileOSS,
+			[]string{"CONTRIBUTING.md", "CODE_OF_CONDUCT.md", ".yamllint.yml", "Taskfile.yml"},
+			[]string{"SECURITY.md", "pkg/profiled/profiled_suite_test.go"}),
+	)
+
+	Describe("scaffolding from flags", func() {
+		var targetDir string
+
+		BeforeEach(func() {
+			DeferCleanup(withFakeGo())
+			targetDir = GinkgoT().TempDir()
+		})
+
+		It("generates the selected services without config files", func() {
+			Expect(run([]string{"new", "mytool", "--module", "github.com/acme/mytool", "--style", "go",
+				"--services", "project,test,local", "--target", targetDir})).To(Succeed())
+
+			projectRoot := filepath.Join(targetDir, "mytool")
+			files := readTree(projectRoot)
+			Expect(files["go.mod"]).To(HavePrefix("module github.com/acme/mytool\n"))
+			Expect(files).To(HaveKey("Makefile"))
+			Expect(files).NotTo(HaveKey(".golangci.yml"))
+			Expect(files).NotTo(HaveKey(ContainSubstring("suite_test.go")))
+			Expect(files[".goboot.lock"]).To(ContainSubstring("useStyle: go"))
+
+			Expect(run([]string{"verify", "--dir", projectRoot})).To(Succeed())
+		})
+
+		It("rejects missing names, unknown services, and invalid values", func() {
+			Expect(run([]string{"new", "--target", targetDir})).To(MatchError(errMissingProjectName))
+
+			err := run([]string{"new", "mytool", "--services", "project,docs", "--target", targetDir})
+			Expect(err).To(MatchError(ContainSubstring(`unknown service "docs"`)))
+
+			err = run([]string{"new", "mytool", "--style", "spock", "--target", targetDir})
+			Expect(err).To(MatchError(ContainSubstring("useStyle")))
+
+			err = run([]string{"new", "mytool", "--style", "go", "--services", "project", "--target", targetDir})
+			Expect(err).To(MatchError(ContainSubstring("-style requires the base_test service")))
+
+			Expect(filepath.Join(targetDir, "mytool")).NotTo(BeAnExistingFile())
+		})
+	})
+
+	Describe("initializing configs from stdin", func() {
+		var (
+			tempDir   string
+			configDir string
+			output    *bytes.Buffer
+		)
+
+		withInput := func(lines ...string) {
+			inputReader = strings.NewReader(strings.Join(lines, "\n") + "\n")
+		}
+
+		BeforeEach(func() {
+			DeferCleanup(withFakeGo())
+			tempDir = GinkgoT().TempDir()
+			configDir = filepath.Join(tempDir, "configs")
+
+			originalReader, originalWriter := inputReader, outputWriter
+			DeferCleanup(func() { inputReader, outputWriter = originalReader, originalWriter })
+
+			output = &bytes.Buffer{}
+			outputWriter = output
+		})
+
+		It("writes valid configs from piped answers and defaults", func() {
+			withInput(
+				"mytool", "https://github.com/acme/mytool", tempDir, "", "",
+				"", "", "", "", "", "Jane Doe", "", "",
+				"golang,yaml",
+				"spock", "go",
+			)
+
+			Expect(run([]string{"init", "-dir", configDir})).To(Succeed())
+			Expect(output.String()).To(ContainSubstring("Test style (ginkgo, go) [ginkgo]: "))
+			Expect(output.String()).To(ContainSubstring(`"spock" is not one of: ginkgo, go`))
+			Expect(output.String()).To(ContainSubstring("Git user or organization [acme]: "))
+
+			project := readFile(filepath.Join(configDir, "base_project.yml"))
+			Expect(project).To(ContainSubstring("author: Jane Doe\n"))
+			Expect(project).To(ContainSubstring("gitProvider: github\ngitUser: acme\n"))
+			Expect(project).NotTo(ContainSubstring("sourcePath"))
+			Expect(readFile(filepath.Join(configDir, "base_lint.yml"))).To(ContainSubstring("make:\n    enabled: false\n"))
+			Expect(readFile(filepath.Join(configDir, "base_test.yml"))).To(HaveSuffix("useStyle: go\n"))
+			Expect(readFile(filepath.Join(configDir, "goboot.yml"))).To(ContainSubstring(
+				"confPath: " + filepath.ToSlash(filepath.Join(configDir, "base_local.yml"))))
+
+			Expect(run([]string{"--config", filepath.Join(configDir, "goboot.yml")})).To(Succeed())
+
+			files := readTree(filepath.Join(tempDir, "mytool"))
+			Expect(files["go.mod"]).To(HavePrefix("module github.com/acme/mytool\n"))
+			Expect(files["LICENSE"]).To(ContainSubstring("Jane Doe"))
+			Expect(files).NotTo(HaveKey(ContainSubstring("suite_test.go")))
+		})
+
+		It("only writes the configs of the selected services", func() {
+			withInput("my-tool", "mytool", "https://example.com/my tool", "https://example.com/mytool", tempDir,
+				"skip-existing", "project,local")
+
+			Expect(run([]string{"init", "-dir", configDir})).To(Succeed())
+			Expect(output.String()).To(ContainSubstring(`invalid projectName "my-tool": ` +
+				`must start with a letter and contain only letters and digits (e.g., "MyTool")`))
+			Expect(output.String()).To(ContainSubstring(`invalid repoUrl "https://example.com/my tool"`))
+
+			entries, err := os.ReadDir(configDir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(entries).To(HaveLen(3))
+			Expect(readFile(filepath.Join(configDir, "base_project.yml"))).NotTo(ContainSubstring("gitProvider"))
+			Expect(readFile(filepath.Join(configDir, "goboot.yml"))).To(ContainSubstring("conflictPolicy: skip-existing"))
+		})
+
+		It("fails without writing anything if answers are missing or configs exist", func() {
+			withInput("mytool")
+
+			err := run([]string{"init", "-dir", configDir})
+			Expect(err).To(MatchError(ContainSubstring(`no valid answer for "Repository URL`)))
+			Expect(configDir).NotTo(BeADirectory())
+
+			withInput("mytool", "https://github.com/acme/mytool", tempDir, "", "lint,docs")
+
+			err = run([]string{"init", "-dir", configDir})
+			Expect(err).To(MatchError(ContainSubstring(`"docs" is not one of: project, lint, test, local`)))
+
+			Expect(os.MkdirAll(configDir, 0o755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(configDir, "goboot.yml"), []byte("keep"), 0o644)).To(Succeed())
+			withInput("mytool", "https://github.com/acme/mytool", tempDir)
+
+			err = run([]string{"init", "-dir", configDir})
+			Expect(err).To(MatchError(ContainSubstring("goboot.yml already exists (use -force to overwrite)")))
+			Expect(readFile(filepath.Join(configDir, "goboot.yml"))).To(Equal("keep"))
+
+			withInput("mytool", "https://github.com/acme/mytool", tempDir)
+			Expect(run([]string{"init", "-dir", configDir, "-force"})).To(Succeed())
+			Expect(readFile(filepath.Join(configDir, "goboot.yml"))).To(ContainSubstring("projectName: mytool"))
+		})
+	})
+
+	Describe("emitting config schemas", func() {
+		var output *bytes.Buffer
+
+		BeforeEach(func() {
+			originalWriter := outputWriter
+			DeferCleanup(func() { outputWriter = originalWriter })
+
+			output = &bytes.Buffer{}
+			outputWriter = output
+		})
+
+		It("prints a single schema", func() {
+			Expect(run([]string{"schema", "base_test"})).To(Succeed())
+
+			schema := map[string]any{}
+			Expect(json.Unmarshal(output.Bytes(), &schema)).To(Succeed())
+			Expect(schema).To(HaveKeyWithValue("required", []any{"useStyle"}))
+			Expect(schema["properties"]).To(HaveKeyWithValue("useStyle", HaveKeyWithValue("enum", []any{"ginkgo", "go"})))
+		})
+
+		It("writes every schema to a directory", func() {
+			outDir := filepath.Join(GinkgoT().TempDir(), "schemas")
+
+			Expect(run([]string{"schema", "-out", outDir})).To(Succeed())
+
+			files := readTree(outDir)
+			Expect(files).To(HaveLen(5))
+
+			for _, name := range []string{"goboot", "base_project", "base_lint", "base_test", "base_local"} {
+				Expect(files).To(HaveKey(name + schemaSuffix))
+				Expect(json.Valid([]byte(files[name+schemaSuffix]))).To(BeTrue(), "schema of %s", name)
+			}
+
+			Expect(files["base_local.schema.json"]).To(ContainSubstring(`"enum": [
+          "make",`))
+		})
+
+		It("rejects unknown or missing schema names", func() {
+			Expect(run([]string{"schema"})).To(MatchError(errMissingSchema))
+			Expect(run([]string{"schema", "docker"})).To(MatchError(ContainSubstring(`unknown config schema "docker"`)))
+		})
+	})
+
+	Describe("validating configs", func() {
+		var output *bytes.Buffer
+
+		BeforeEach(func() {
+			originalWriter := outputWriter
+			DeferCleanup(func() { outputWriter = originalWriter })
+
+			output = &bytes.Buffer{}
+			outputWriter = output
+		})
+
+		It("reports every invalid service config at once without generating anything", func() {
+			tempDir := GinkgoT().TempDir()
+			targetDir := filepath.Join(tempDir, "out")
+			cfgPath := writeAllServiceConfigs(tempDir, "mytool", "github.com/acme/mytool", targetDir)
+
+			Expect(run([]string{"validate", "-config", cfgPath})).To(Succeed())
+			Expect(output.String()).To(ContainSubstring(cfgPath + " is valid"))
+
+			projectCfg := filepath.Join(tempDir, "base_project.yml")
+			project := strings.NewReplacer(`usedGoVersion: "1.22.5"`, "usedGoVersion: go1.22", `"E2E Author"`, `""`)
+			writeConfig(projectCfg, project.Replace(readFile(projectCfg)))
+			testCfg := filepath.Join(tempDir, "base_test.yml")
+			writeConfig(testCfg, strings.Replace(readFile(testCfg), `"ginkgo"`, "spock", 1))
+
+			err := run([]string{"validate", "-config", cfgPath, "-set", "services.base_local.fileList=[]"})
+			Expect(err).To(MatchError(ContainSubstring("invalid service configs (4 errors):\n" +
+				"  base_project.author (" + projectCfg + "): missing required field\n" +
+				"  base_project.usedGoVersion (" + projectCfg + "): invalid Go version \"go1.22\"")))
+			Expect(err).To(MatchError(ContainSubstring("  base_test.useStyle (" + testCfg + "): must be 'ginkgo' or 'go'")))
+			Expect(err).To(MatchError(ContainSubstring(
+				"  base_local.fileList (" + filepath.Join(tempDir, "base_local.yml") + "): missing required field")))
+			Expect(targetDir).NotTo(BeADirectory())
+		})
+
+		It("rejects configs whose services cannot run", func() {
+			tempDir := GinkgoT().TempDir()
+			targetDir := filepath.Join(tempDir, "out")
+			cfgPath := filepath.Join(tempDir, "goboot.yml")
+			writeConfig(cfgPath, fmt.Sprintf(`version: 1
+projectName: mytool
+repoUrl: github.com/acme/mytool
+targetPath: %s
+services:
+  - {id: base_lint, enabled: true}
+  - {id: base_lint, enabled: true}
+`, targetDir))
+
+			err := run([]string{"validate", "-config", cfgPath})
+			Expect(err).To(MatchError(ContainSubstring(`service "base_lint" already registered`)))
+			Expect(output.String()).NotTo(ContainSubstring("is valid"))
+			Expect(targetDir).NotTo(BeADirectory())
+		})
+	})
+
+	Describe("migrating configs", func() {
+		var output *bytes.Buffer
+
+		BeforeEach(func() {
+			originalWriter := outputWriter
+			DeferCleanup(func() { outputWriter = originalWriter })
+
+			output = &bytes.Buffer{}
+			outputWriter = output
+		})
+
+		It("upgrades the goboot config and its service config files in place", func() {
+			tempDir := GinkgoT().TempDir()
+			cfgPath := writeAllServiceConfigs(tempDir, "mytool", "github.com/acme/mytool", filepath.Join(tempDir, "out"))
+			lintCfg := filepath.Join(tempDir, "base_lint.yml")
+			version := fmt.Sprintf("version: %d\n", config.ConfigVersion)
+
+			Expect(run([]string{"config", "migrate", "-config", cfgPath})).To(Succeed())
+			Expect(output.String()).To(ContainSubstring(fmt.Sprintf("migrated %s from version 0 to %d\n",
+				cfgPath, config.ConfigVersion)))
+			Expect(output.String()).To(ContainSubstring(fmt.Sprintf("migrated %s from version 0 to %d\n",
+				lintCfg, config.ConfigVersion)))
+
+			for _, name := range []string{"goboot.yml", "base_project.yml", "base_lint.yml", "base_test.yml", "base_local.yml"} {
+				Expect(readFile(filepath.Join(tempDir, name))).To(HavePrefix(version+"\n"), "config %s", name)
+			}
+
+			output.Reset()
+			Expect(run([]string{"config", "migrate", "-config", cfgPath})).To(Succeed())
+			Expect(output.String()).To(ContainSubstring(fmt.Sprintf("%s is up to date (version %d)\n",
+				lintCfg, config.ConfigVersion)))
+			Expect(run([]string{"validate", "-config", cfgPath})).To(Succeed())
+		})
+
+		It("rejects unknown or missing config commands", func() {
+			Expect(run([]string{"config"})).To(MatchError(errMissingConfigCommand))
+			Expect(run([]string{"config", "upgrade"})).To(MatchError(ContainSubstring(`unknown config command "upgrade"`)))
+		})
+	})
+
+	It("writes a lock file covering every generated file", func() {
+		defer withFakeGo()()
+		tempDir := GinkgoT().TempDir()
+		projectName := "E2ELock"
+		targetDir := filepath.Join(tempDir, "out")
+		projectRoot := filepath.Join(targetDir, projectName)
+
+		cfgPath := writeAllServiceConfigs(tempDir, projectName, "github.com/example/e2e-lock", targetDir)
+		Expect(run([]string{"--config", cfgPath})).To(Succeed())
+
+		tree := readTree(projectRoot)
+		Expect(tree).To(HaveKey(goboottypes.LockFileName))
+
+		lock, err := goboot.ParseLock([]byte(tree[goboottypes.LockFileName]))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(lock.Services).To(HaveLen(4))
+		Expect(lock.Files).To(HaveLen(len(tree) - 2))
+
+		base, err := goboot.ParseBase([]byte(tree[goboottypes.BaseFileName]))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(base.Files).To(HaveLen(len(lock.Files)))
+
+		for i, file := range lock.Files {
+			Expect(tree).To(HaveKey(file.Path))
+			Expect(file.SHA256).To(Equal(gobootutils.HashContent([]byte(tree[file.Path]))), "checksum of %q", file.Path)
+			Expect(file.Template).To(HaveSuffix(goboottypes.TemplateSuffix))
+			Expect(base.Files[i]).To(Equal(goboot.BaseFile{Path: file.Path, Content: tree[file.Path]}))
+		}
+		// The generated .gitignore ignores *.lock, but the lock and the merge base must be committed.
+		if _, err := exec.LookPath("git"); err != nil {
+			Skip("git is not available")
+		}
+
+		Expect(exec.Command("git", "init", "-q", projectRoot).Run()).To(Succeed())
+
+		checkIgnore := exec.Command("git", "-C", projectRoot, "check-ignore", "--no-index",
+			goboottypes.LockFileName, goboottypes.BaseFileName)
+		ignored, err := checkIgnore.Output()
+		Expect(string(ignored)).To(BeEmpty())
+		Expect(checkIgnore.ProcessState.ExitCode()).To(Equal(1), "check-ignore: %v", err)
+	})
+
+	It("reports a dry run without touching the disk", func() {
+		defer withFakeGo()()
+		tempDir := GinkgoT().TempDir()
+		projectName := "E2EDryRun"
+		targetDir := filepath.Join(tempDir, "out")
+
+		cfgPath := writeAllServiceConfigs(tempDir, projectName, "github.com/example/e2e-dry-run", targetDir)
+
+		originalWriter := outputWriter
+		defer func() { outputWriter = originalWriter }()
+
+		buf := &bytes.Buffer{}
+		outputWriter = buf
+
+		Expect(run([]string{"--config", cfgPath, "--dry-run"})).To(Succeed())
+
+		Expect(targetDir).NotTo(BeADirectory())
+
+		output := buf.String()
+		Expect(output).To(ContainSubstring("Dry run: no files were written"))
+		Expect(output).To(MatchRegexp(`create\s+README\.md`))
+		Expect(output).To(MatchRegexp(`create\s+Makefile`))
+		Expect(output).To(MatchRegexp(`create\s+scripts/lint\.sh`))
+		Expect(output).To(ContainSubstring(`base_lint: lines "base_lint"`))
+		Expect(output).To(ContainSubstring(`base_test: file "test.sh"`))
+		Expect(output).To(ContainSubstring("golangci-lint run"))
+		Expect(output).To(ContainSubstring("go mod tidy (in " + filepath.Join(targetDir, projectName) + ")"))
+	})
+
+	It("reports existing files as overwritten in a dry run", func() {
+		defer withFakeGo()()
+		tempDir := GinkgoT().TempDir()
+		projectName := "E2EDryRunExisting"
+		targetDir := filepath.Join(tempDir, "out")
+		projectRoot := filepath.Join(targetDir, projectName)
+
+		cfgPath := writeAllServiceConfigs(tempDir, projectName, "github.com/example/e2e-dry-run", targetDir)
+		Expect(run([]string{"--config", cfgPath})).To(Succeed())
+
+		before := readTree(projectRoot)
+		Expect(os.Remove(filepath.Join(projectRoot, "Makefile"))).To(Succeed())
+
+		originalWriter := outputWriter
+		defer func() { outputWriter = originalWriter }()
+
+		buf := &bytes.Buffer{}
+		outputWriter = buf
+
+		Expect(run([]string{"--config", cfgPath, "--dry-run", "--conflict-policy", "overwrite"})).To(Succeed())
+
+		output := buf.String()
+		Expect(output).To(MatchRegexp(`overwrite\s+README\.md`))
+		Expect(output).To(MatchRegexp(`create\s+Makefile`))
+
+		after := readTree(projectRoot)
+		Expect(after).NotTo(HaveKey("Makefile"))
+		Expect(after).To(HaveLen(len(before) - 1))
+	})
+
+	Describe("re-running into an existing project", func() {
+		var (
+			cfgPath     string
+			projectRoot string
+			readmePath  string
+			buf         *bytes.Buffer
+		)
+
+		BeforeEach(func() {
+			DeferCleanup(withFakeGo())
+			tempDir := GinkgoT().TempDir()
+			projectName := "E2EConflicts"
+			targetDir := filepath.Join(tempDir, "out")
+			projectRoot = filepath.Join(targetDir, projectName)
+			readmePath = filepath.Join(projectRoot, "README.md")
+
+			cfgPath = writeAllServiceConfigs(tempDir, projectName, "github.com/example/e2e-conflicts", targetDir)
+			Expect(run([]string{"--config", cfgPath})).To(Succeed())
+			Expect(os.WriteFile(readmePath, []byte("edited by hand\n"), 0o644)).To(Succeed())
+
+			originalWriter := outputWriter
+			DeferCleanup(func() { outputWriter = originalWriter })
+
+			buf = &bytes.Buffer{}
+			outputWriter = buf
+		})
+
+		It("fails by default and keeps edited files", func() {
+			err := run([]string{"--config", cfgPath})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(MatchRegexp(`\d+ files already exist \(conflict policy "fail"\): .*README\.md`))
+			Expect(readFile(readmePath)).To(Equal("edited by hand\n"))
+		})
+
+		It("keeps edited files with skip-existing", func() {
+			Expect(run([]string{"--config", cfgPath, "--conflict-policy", "skip-existing"})).To(Succeed())
+
+			Expect(readFile(readmePath)).To(Equal("edited by hand\n"))
+			Expect(buf.String()).To(MatchRegexp(`Conflicts resolved \(\d+\):`))
+			Expect(buf.String()).To(MatchRegexp(`skip\s+README\.md`))
+		})
+
+		It("replaces edited files with overwrite", func() {
+			Expect(run([]string{"--config", cfgPath, "--conflict-policy", "overwrite"})).To(Succeed())
+
+			Expect(readFile(readmePath)).NotTo(Equal("edited by hand\n"))
+			Expect(buf.String()).To(MatchRegexp(`overwrite\s+README\.md`))
+		})
+
+		It("saves edited files as .orig with backup", func() {
+			Expect(run([]string{"--config", cfgPath, "--conflict-policy", "backup"})).To(Succeed())
+
+			Expect(readFile(readmePath)).NotTo(Equal("edited by hand\n"))
+			Expect(readFile(readmePath + ".orig")).To(Equal("edited by hand\n"))
+			Expect(buf.String()).To(ContainSubstring("backup     README.md (saved as README.md.orig)"))
+
+			Expect(os.WriteFile(readmePath, []byte("edited again\n"), 0o644)).To(Succeed())
+			Expect(run([]string{"--config", cfgPath, "--conflict-policy", "backup"})).To(Succeed())
+			Expect(readFile(readmePath + ".orig")).To(Equal("edited by hand\n"))
+			Expect(readFile(readmePath + ".orig.1")).To(Equal("edited again\n"))
+		})
+
+		It("rejects unknown policies", func() {
+			err := run([]string{"--config", cfgPath, "--conflict-policy", "merge"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid -conflict-policy flag"))
+		})
+	})
+
+	Describe("verifying a generated project", func() {
+		var (
+			projectRoot string
+			buf         *bytes.Buffer
+		)
+
+		BeforeEach(func() {
+			// Emulate go mod tidy rewriting go.mod after generation.
+			DeferCleanup(withFakeGoScript("#!/usr/bin/env bash\necho 'require example.com/dep v1.0.0' >> go.mod\n"))
+			tempDir := GinkgoT().TempDir()
+			projectName := "E2EVerify"
+			targetDir := filepath.Join(tempDir, "out")
+			projectRoot = filepath.Join(targetDir, projectName)
+
+			cfgPath := writeAllServiceConfigs(tempDir, projectName, "github.com/example/e2e-verify", targetDir)
+			Expect(run([]string{"--config", cfgPath})).To(Succeed())
+
+			originalWriter := outputWriter
+			DeferCleanup(func() { outputWriter = originalWriter })
+
+			buf = &bytes.Buffer{}
+			outputWriter = buf
+		})
+
+		It("passes for an untouched project", func() {
+			Expect(readFile(filepath.Join(projectRoot, "go.mod"))).To(ContainSubstring("require example.com/dep"))
+
+			Expect(run([]string{"verify", "--dir", projectRoot})).To(Succeed())
+			Expect(buf.String()).To(MatchRegexp(`Verified \d+ files: \d+ unchanged, 0 modified, 0 deleted, 0 extra`))
+		})
+
+		It("reports modified, deleted, and extra files", func() {
+			Expect(os.WriteFile(filepath.Join(projectRoot, "README.md"), []byte("edited\n"), 0o644)).To(Succeed())
+			Expect(os.Remove(filepath.Join(projectRoot, "Makefile"))).To(Succeed())
+
+			// A file recorded by an earlier goboot version that the current templates no longer generate.
+			lockPath := filepath.Join(projectRoot, goboottypes.LockFileName)
+			lock, err := goboot.ParseLock([]byte(readFile(lockPath)))
+			Expect(err).NotTo(HaveOccurred())
+			lock.Files = append(lock.Files, goboot.LockFile{Path: "OLD.md", SHA256: gobootutils.HashContent(nil)})
+			data, err := lock.Marshal()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(os.WriteFile(lockPath, data, 0o644)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(projectRoot, "OLD.md"), nil, 0o644)).To(Succeed())
+
+			// Files goboot never generated: in a generated directory, and in a directory of the project's own.
+			Expect(os.WriteFile(filepath.Join(projectRoot, "notes.txt"), nil, 0o644)).To(Succeed())
+			Expect(os.MkdirAll(filepath.Join(projectRoot, "internal", "app"), 0o755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(projectRoot, "internal", "app", "app.go"), nil, 0o644)).To(Succeed())
+
+			err = run([]string{"verify", "--dir", projectRoot})
+			Expect(err).To(MatchError(errDrift))
+
+			Expect(buf.String()).To(MatchRegexp(`modified\s+README\.md`))
+			Expect(buf.String()).To(MatchRegexp(`deleted\s+Makefile`))
+			Expect(buf.String()).To(MatchRegexp(`extra\s+OLD\.md`))
+			Expect(buf.String()).To(MatchRegexp(`extra\s+notes\.txt`))
+			Expect(buf.String()).NotTo(ContainSubstring("app.go"))
+			Expect(buf.String()).To(ContainSubstring("1 modified, 1 deleted, 2 extra"))
+		})
+
+		It("fails without a lock file", func() {
+			Expect(os.Remove(filepath.Join(projectRoot, goboottypes.LockFileName))).To(Succeed())
+
+			err := run([]string{"verify", "--dir", projectRoot})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("failed to read .goboot.lock"))
+		})
+
+		It("rejects tampered service configs", func() {
+			lockPath := filepath.Join(projectRoot, goboottypes.LockFileName)
+			tampered := strings.Replace(readFile(lockPath), "E2E Author", "Someone Else", 1)
+			Expect(os.WriteFile(lockPath, []byte(tampered), 0o644)).To(Succeed())
+
+			err := run([]string{"verify", "--dir", projectRoot})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(`recorded config for "base_project" does not match its configHash`))
+		})
+	})
+
+	Describe("upgrading a generated project", func() {
+		var (
+			tempDir     string
+			cfgPath     string
+			projectRoot string
+			buf         *bytes.Buffer
+		)
+
+		BeforeEach(func() {
+			DeferCleanup(withFakeGo())
+			tempDir = GinkgoT().TempDir()
+			projectName := "E2EUpgrade"
+			targetDir := filepath.Join(tempDir, "out")
+			projectRoot = filepath.Join(targetDir, projectName)
+
+			cfgPath = writeAllServiceConfigs(tempDir, projectName, "github.com/example/e2e-upgrade", targetDir)
+			Expect(run([]string{"--config", cfgPath})).To(Succeed())
+
+			// Pin a newer golangci-lint version, as a goboot release would do via DefaultGoLintCmd.
+			root := repoRoot(GinkgoT())
+			writeConfig(filepath.Join(tempDir, "base_lint.yml"), fmt.Sprintf(`
+sourcePath: %s
+linters:
+  golang:
+    cmd: golangci-lint-v99 run ./...
+    enabled: true
+  yaml:
+    enabled: true
+  make:
+    enabled: true
+  markdown:
+    enabled: true
+  shellcheck:
+    enabled: true
+  shfmt:
+    enabled: true
+`, filepath.Join(root, "templates", "lint_base")))
+
+			originalWriter := outputWriter
+			DeferCleanup(func() { outputWriter = originalWriter })
+
+			buf = &bytes.Buffer{}
+			outputWriter = buf
+		})
+
+		It("merges new template output with local edits", func() {
+			makefile := filepath.Join(projectRoot, "Makefile")
+			Expect(readFile(makefile)).NotTo(ContainSubstring("golangci-lint-v99"))
+			Expect(os.WriteFile(makefile, []byte("# Team conventions apply.\n"+readFile(makefile)), 0o644)).To(Succeed())
+
+			Expect(run([]string{"upgrade", "--dir", projectRoot, "--config", cfgPath})).To(Succeed())
+
+			Expect(readFile(makefile)).To(HavePrefix("# Team conventions apply.\n"))
+			Expect(readFile(makefile)).To(ContainSubstring("golangci-lint-v99"))
+			Expect(buf.String()).To(MatchRegexp(`merged\s+Makefile`))
+			Expect(buf.String()).To(ContainSubstring("0 conflicts"))
+
+			buf.Reset()
+			err := run([]string{"verify", "--dir", projectRoot})
+			Expect(err).To(MatchError(errDrift))
+			Expect(buf.String()).To(MatchRegexp(`modified\s+Makefile`))
+			Expect(buf.String()).To(ContainSubstring("1 modified, 0 deleted, 0 extra"))
+		})
+
+		It("fails with conflicts and writes .rej files on request", func() {
+			makefile := filepath.Join(projectRoot, "Makefile")
+			edited := strings.Replace(readFile(makefile), "golangci-lint run", "golangci-lint-custom run", 1)
+			Expect(edited).NotTo(Equal(readFile(makefile)))
+			Expect(os.WriteFile(makefile, []byte(edited), 0o644)).To(Succeed())
+
+			err := run([]string{"upgrade", "--dir", projectRoot, "--config", cfgPath, "--conflict-style", "rej"})
+			Expect(err).To(MatchError(errUpgradeConflicts))
+
+			Expect(readFile(makefile)).To(Equal(edited))
+			Expect(readFile(makefile + ".rej")).To(ContainSubstring("+"))
+			Expect(readFile(makefile + ".rej")).To(ContainSubstring("golangci-lint-v99"))
+			Expect(buf.String()).To(ContainSubstring("(rejected sections in Makefile.rej)"))
+		})
+
+		It("keeps reporting conflicts until their markers are resolved", func() {
+			makefile := filepath.Join(projectRoot, "Makefile")
+			edited := strings.Replace(readFile(makefile), "golangci-lint run", "golangci-lint-custom run", 1)
+			Expect(os.WriteFile(makefile, []byte(edited), 0o644)).To(Succeed())
+
+			err := run([]string{"upgrade", "--dir", projectRoot, "--config", cfgPath})
+			Expect(err).To(MatchError(errUpgradeConflicts))
+
+			conflicted := readFile(makefile)
+			Expect(conflicted).To(ContainSubstring("<<<<<<< current"))
+
+			buf.Reset()
+			err = run([]string{"upgrade", "--dir", projectRoot, "--config", cfgPath})
+			Expect(err).To(MatchError(errUpgradeConflicts))
+			Expect(readFile(makefile)).To(Equal(conflicted))
+			Expect(buf.String()).To(ContainSubstring("Makefile still has unresolved conflict markers"))
+			Expect(buf.String()).To(MatchRegexp(`conflict\s+Makefile`))
+		})
+	})
+
+	Describe("adding a service to a generated project", func() {
+		var (
+			cfgPath     string
+			projectRoot string
+			buf         *bytes.Buffer
+		)
+
+		BeforeEach(func() {
+			DeferCleanup(withFakeGo())
+			tempDir := GinkgoT().TempDir()
+			projectName := "E2EAdd"
+			targetDir := filepath.Join(tempDir, "out")
+			projectRoot = filepath.Join(targetDir, projectName)
+
+			// Start without linting; base_lint stays declared, so goboot add finds its config.
+			cfgPath = writeAllServiceConfigs(tempDir, projectName, "github.com/example/e2e-add", targetDir)
+			lintEntry := "confPath: " + filepath.Join(tempDir, "base_lint.yml") + "\n    enabled: "
+			writeConfig(cfgPath, strings.Replace(readFile(cfgPath), lintEntry+"true", lintEntry+"false", 1))
+
+			Expect(run([]string{"--config", cfgPath})).To(Succeed())
+
+			originalWriter := outputWriter
+			DeferCleanup(func() { outputWriter = originalWriter })
+
+			buf = &bytes.Buffer{}
+			outputWriter = buf
+		})
+
+		It("adds the service and keeps existing Makefile targets", func() {
+			makefile := filepath.Join(projectRoot, "Makefile")
+			Expect(readFile(makefile)).To(ContainSubstring("No linters enabled"))
+			Expect(filepath.Join(projectRoot, ".golangci.yml")).NotTo(BeAnExistingFile())
+
+			custom := "\n#  Deploy to staging\ndeploy:\n\t@./scripts/deploy.sh\n"
+			Expect(os.WriteFile(makefile, []byte(readFile(makefile)+custom), 0o644)).To(Succeed())
+
+			Expect(run([]string{"add", "base_lint", "--dir", projectRoot, "--config", cfgPath})).To(Succeed())
+
+			Expect(filepath.Join(projectRoot, ".golangci.yml")).To(BeAnExistingFile())
+			Expect(readFile(makefile)).NotTo(ContainSubstring("No linters enabled"))
+			Expect(readFile(makefile)).To(ContainSubstring("golangci-lint run"))
+			Expect(readFile(makefile)).To(HaveSuffix(custom))
+			Expect(buf.String()).To(MatchRegexp(`created\s+\.golangci\.yml`))
+			Expect(buf.String()).To(MatchRegexp(`merged\s+Makefile`))
+			Expect(readFile(filepath.Join(projectRoot, ".goboot.lock"))).To(ContainSubstring("id: base_lint"))
+
+			err := run([]string{"add", "base_lint", "--dir", projectRoot, "--config", cfgPath})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("already part of the project"))
+		})
+
+		It("requires a service declared in the config", func() {
+			Expect(run([]string{"add", "--dir", projectRoot})).To(MatchError(errMissingService))
+
+			err := run([]string{"add", "base_docs", "--dir", projectRoot, "--config", cfgPath})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(`service "base_docs" is not declared`))
+		})
+	})
+
 	It("supports go-style tests and selectively enabled linters", func() {
 		defer withFakeGo()()
 		tempDir := GinkgoT().TempDir()
@@ -11,6 +11,11 @@ import (
 )
 
 func withFakeGo() func() {
+	return withFakeGoScript("#!/usr/bin/env bash\nexit 0\n")
+}
+
+// withFakeGoScript puts a fake go binary running the given script first on the PATH.
+func withFakeGoScript(script string) func() {
 	fakeDir, err := os.MkdirTemp("", "fake-go-*")
 	if err != nil {
 		panic(err)
@@ -18,7 +23,6 @@ func withFakeGo() func() {
 
 	goPath := filepath.Join(fakeDir, "go")
 
-	script := "#!/usr/bin/env bash\nexit 0\n"
 	if err := os.WriteFile(goPath, []byte(script), 0o755); err != nil {
 		panic(err)
 	}
@@ -42,7 +46,7 @@ var _ = Describe("CLI entrypoint", func() {
 		configFile := filepath.Join(tempDir, "goboot.yml")
 		targetDir := filepath.Join(tempDir, "out")
 
-		yamlContent := `projectName: cli-project
+		yamlContent := `projectName: cliproject
 targetPath: ` + targetDir + `
 services: []
 `
@@ -152,7 +156,7 @@ services:
 			configFile := filepath.Join(tempDir, "goboot.yml")
 			targetDir := filepath.Join(tempDir, "out")
 
-			yamlContent := `projectName: cli-main
+			yamlContent := `projectName: climain
 targetPath: ` + targetDir + `
 services: []
 `
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/it-timo/goboot/pkg/config"
+	"github.com/it-timo/goboot/pkg/goboot"
+	"github.com/it-timo/goboot/pkg/goboottypes"
+)
+
+// cmdNew is the name of the new subcommand.
+const cmdNew = "new"
+
+// errMissingProjectName is returned by runNew if no project name is given.
+var errMissingProjectName = errors.New("missing project name (usage: goboot new [flags] <name>)")
+
+// serviceAliases maps the short service names accepted by "goboot new -services" to service IDs.
+var serviceAliases = map[string]string{
+	"project": goboottypes.ServiceNameBaseProject,
+	"lint":    goboottypes.ServiceNameBaseLint,
+	"test":    goboottypes.ServiceNameBaseTest,
+	"local":   goboottypes.ServiceNameBaseLocal,
+}
+
+// serviceNames lists the keys of serviceAliases in the order services are usually listed.
+var serviceNames = []string{"project", "lint", "test", "local"}
+
+// newOptions holds the flags of the new subcommand.
+type newOptions struct {
+	name           string // Project name (positional argument).
+	module         string // Go module path; defaults to the project name.
+	style          string // Test style of base_test; empty keeps the built-in default.
+	services       string // Comma-separated short names or IDs of the services to run.
+	targetPath     string // Directory the project directory is created in.
+	conflictPolicy string // Policy for existing files; empty keeps the default.
+	dryRun         bool   // Whether to render into memory only.
+}
+
+// runNew generates a project from flags and the built-in service defaults, without any config files.
+func runNew(args []string) error {
+	opts := newOptions{}
+
+	fs := flag.NewFlagSet("goboot new", flag.ContinueOnError)
+	fs.StringVar(&opts.module, "module", "", "Go module path of the project (default: the project name)")
+	fs.StringVar(&opts.style, "style", "", "Test style: ginkgo (default) or go")
+	fs.StringVar(&opts.services, "services", strings.Join(serviceNames, ","),
+		"Comma-separated services to run: project, lint, test, local (or their IDs)")
+	fs.StringVar(&opts.targetPath, "target", ".", "Directory to create the project directory in")
+	fs.StringVar(&opts.conflictPolicy, "conflict-policy", "",
+		"Policy for existing files: fail (default), skip-existing, overwrite, or backup")
+	fs.BoolVar(&opts.dryRun, "dry-run", false, "Show the files, scripts, and commands of a run without touching the disk")
+
+	name, err := parseWithArgument(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if name == "" {
+		return errMissingProjectName
+	}
+
+	opts.name = name
+
+	cfg, err := newConfig(opts)
+	if err != nil {
+		return fmt.Errorf("failed to initialize configuration: %w", err)
+	}
+
+	return generate(cfg, opts.dryRun)
+}
+
+// newConfig builds the goboot config and the configs of the selected services from the flags
+// and the built-in service defaults.
+//
+// Every service config is validated on registration, followed by the goboot config itself.
+func newConfig(opts newOptions) (*config.GoBoot, error) {
+	ids, err := resolveServices(opts.services)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.style != "" && !slices.Contains(ids, goboottypes.ServiceNameBaseTest) {
+		return nil, fmt.Errorf("-style requires the %s service", goboottypes.ServiceNameBaseTest)
+	}
+
+	module := opts.module
+	if module == "" {
+		module = opts.name
+	}
+
+	cfg := config.NewGoBoot(goboot.ServiceConfigFactories(), "")
+	cfg.ProjectName = opts.name
+	cfg.RepoURL = module
+	cfg.TargetPath = opts.targetPath
+	cfg.ConflictPolicy = opts.conflictPolicy
+
+	for _, id := range ids {
+		svcCfg, err := cfg.NewServiceConfig(id)
+		if err != nil {
+			return nil, err
+		}
+
+		testCfg, ok := svcCfg.(*config.BaseTestConfig)
+		if ok && opts.style != "" {
+			testCfg.UseStyle = opts.style
+		}
+
+		err = cfg.RegisterServiceConfig(svcCfg)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = cfg.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// resolveServices maps the comma-separated service names of the -services flag to unique service IDs.
+func resolveServices(services string) ([]string, error) {
+	var ids []string
+
+	for name := range strings.SplitSeq(services, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		id, ok := serviceAliases[name]
+		if !ok {
+			id = name
+		}
+
+		if !slices.Contains(slices.Collect(maps.Values(serviceAliases)), id) {
+			return nil, fmt.Errorf("unknown service %q in -services (must be one of: %s)",
+				name, strings.Join(serviceNames, ", "))
+		}
+
+		if !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+
+	if len(ids) == 0 {
+		return nil, errors.New("-services must name at least one service")
+	}
+
+	return ids, nil
+}
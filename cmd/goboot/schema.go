@@ -0,0 +1,107 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/it-timo/goboot/pkg/goboot"
+	"github.com/it-timo/goboot/pkg/goboottypes"
+)
+
+// cmdSchema is the name of the schema subcommand.
+const cmdSchema = "schema"
+
+// schemaSuffix is appended to the schema name for the files written by "goboot schema -out".
+const schemaSuffix = ".schema.json"
+
+// errMissingSchema is returned by runSchema if neither a schema name nor an output directory is given.
+var errMissingSchema = errors.New("missing schema name or -out directory (usage: goboot schema [flags] [name])")
+
+// runSchema emits the JSON Schema documents of the goboot config files (e.g., for YAML language servers).
+//
+// With a schema name (see config.SchemaNames), the schema is printed; with -out, the schemas are written
+// to "<name>.schema.json" files in that directory instead (all of them if no name is given).
+func runSchema(args []string) error {
+	fs := flag.NewFlagSet("goboot schema", flag.ContinueOnError)
+	outDir := ""
+
+	fs.StringVar(&outDir, "out", "", "Directory to write <name>"+schemaSuffix+" files to, instead of printing")
+
+	name, err := parseWithArgument(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if name == "" && outDir == "" {
+		return errMissingSchema
+	}
+
+	names := goboot.ServiceConfigFactories().SchemaNames()
+	if name != "" {
+		names = []string{name}
+	}
+
+	for _, name := range names {
+		data, err := encodeSchema(name)
+		if err != nil {
+			return err
+		}
+
+		if outDir == "" {
+			_, err = outputWriter.Write(data)
+			if err != nil {
+				return fmt.Errorf("failed to write output: %w", err)
+			}
+
+			continue
+		}
+
+		err = writeSchema(outDir, name, data)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// encodeSchema returns the indented JSON Schema document of the named config.
+func encodeSchema(name string) ([]byte, error) {
+	schema, err := goboot.ServiceConfigFactories().ConfigSchema(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build schema: %w", err)
+	}
+
+	data, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode schema %q: %w", name, err)
+	}
+
+	return append(data, '\n'), nil
+}
+
+// writeSchema writes the schema document data of the named config to dir.
+func writeSchema(dir, name string, data []byte) error {
+	err := os.MkdirAll(dir, goboottypes.DirPerm)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+
+	path := filepath.Join(dir, name+schemaSuffix)
+
+	err = os.WriteFile(path, data, goboottypes.FilePerm)
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	_, err = fmt.Fprintf(outputWriter, "wrote %s\n", path)
+	if err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+
+	return nil
+}
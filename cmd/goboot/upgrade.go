@@ -0,0 +1,55 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/it-timo/goboot/pkg/goboot"
+)
+
+// cmdUpgrade is the name of the upgrade subcommand.
+const cmdUpgrade = "upgrade"
+
+// errUpgradeConflicts is returned by runUpgrade if files need manual conflict resolution.
+var errUpgradeConflicts = errors.New("upgrade finished with conflicts")
+
+// runUpgrade merges the current templates and configs into an already generated project.
+//
+// It returns errUpgradeConflicts if any file could not be merged cleanly.
+func runUpgrade(args []string) error {
+	fs := flag.NewFlagSet("goboot upgrade", flag.ContinueOnError)
+	projectDir := ""
+	conflictStyle := ""
+
+	fs.StringVar(&projectDir, "dir", ".", "Path to the generated project (containing .goboot.lock)")
+	cfgFlags := addConfigFlags(fs, "Path to the goboot config file to upgrade to")
+	fs.StringVar(&conflictStyle, "conflict-style", "",
+		"How to write merge conflicts: markers (default) or rej (writes <file>.rej)")
+
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("failed to parse flags: %w", err)
+	}
+
+	cfg, err := cfgFlags.initConfig()
+	if err != nil {
+		return err
+	}
+
+	report, err := goboot.Upgrade(projectDir, cfg, conflictStyle)
+	if err != nil {
+		return fmt.Errorf("upgrade failed: %w", err)
+	}
+
+	err = report.Print(outputWriter)
+	if err != nil {
+		fmt.Println("Failed to write upgrade report to output:", err)
+	}
+
+	conflicts := report.Count(goboot.UpgradeConflict)
+	if conflicts > 0 {
+		return fmt.Errorf("%w: resolve %d files and run goboot verify", errUpgradeConflicts, conflicts)
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/it-timo/goboot/pkg/goboot"
+)
+
+// cmdValidate is the name of the validate subcommand.
+const cmdValidate = "validate"
+
+// runValidate reads, merges, and validates the goboot config and all enabled service configs,
+// and resolves the execution plan of the enabled services without generating anything (e.g., in CI).
+//
+// The errors of all service configs are reported at once, each with its field path and file.
+func runValidate(args []string) error {
+	fs := flag.NewFlagSet("goboot validate", flag.ContinueOnError)
+	cfgFlags := addConfigFlags(fs, "Path to the goboot config file")
+
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("failed to parse flags: %w", err)
+	}
+
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments: %v", fs.Args())
+	}
+
+	cfg, err := cfgFlags.initConfig()
+	if err != nil {
+		return err
+	}
+
+	// Register the services like a run does, so configs that can never run fail here; a dry run writes nothing.
+	app := goboot.NewGoBoot(cfg)
+	app.SetDryRun(true)
+
+	err = app.RegisterServices()
+	if err != nil {
+		return fmt.Errorf("service registration failed: %w", err)
+	}
+
+	_, err = app.ResolvePlan()
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(outputWriter, "%s is valid\n", cfgFlags)
+	if err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+
+	return nil
+}
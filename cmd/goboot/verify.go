@@ -0,0 +1,59 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/it-timo/goboot/pkg/goboot"
+)
+
+// cmdVerify is the name of the verify subcommand.
+const cmdVerify = "verify"
+
+// allowPluginsUsage is the usage of the -allow-plugins flag of the subcommands re-rendering the lock file.
+const allowPluginsUsage = "Run the plugin executables recorded in .goboot.lock (only for trusted lock files)"
+
+// errDrift is returned by runVerify if the project drifted from its lock file.
+var errDrift = errors.New("drift detected")
+
+// runVerify re-renders a generated project from its lock file and reports drifted files.
+//
+// It returns errDrift if any file was modified, deleted, or is extra, so CI runs fail.
+func runVerify(args []string) error {
+	fs := flag.NewFlagSet("goboot verify", flag.ContinueOnError)
+	projectDir := ""
+	allowPlugins := false
+
+	fs.StringVar(&projectDir, "dir", ".", "Path to the generated project (containing .goboot.lock)")
+	fs.BoolVar(&allowPlugins, "allow-plugins", false, allowPluginsUsage)
+
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("failed to parse flags: %w", err)
+	}
+
+	report, err := goboot.Verify(projectDir, allowPlugins)
+	if err != nil {
+		return fmt.Errorf("verification failed: %w", withPluginsHint(err))
+	}
+
+	err = report.Print(outputWriter)
+	if err != nil {
+		fmt.Println("Failed to write verify report to output:", err)
+	}
+
+	if report.HasDrift() {
+		return fmt.Errorf("%w in %s", errDrift, projectDir)
+	}
+
+	return nil
+}
+
+// withPluginsHint adds the -allow-plugins flag to errors of lock files recording plugins.
+func withPluginsHint(err error) error {
+	if errors.Is(err, goboot.ErrPluginsNotAllowed) {
+		return fmt.Errorf("%w (pass -allow-plugins to run them)", err)
+	}
+
+	return err
+}
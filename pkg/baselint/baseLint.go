@@ -17,11 +17,14 @@ package baselint
 import (
 	"errors"
 	"fmt"
-	"os"
+	"io/fs"
+	"maps"
 	"path"
+	"slices"
 	"strings"
 
 	"github.com/it-timo/goboot/pkg/config"
+	"github.com/it-timo/goboot/pkg/gobootfs"
 	"github.com/it-timo/goboot/pkg/goboottypes"
 	"github.com/it-timo/goboot/pkg/gobootutils"
 )
@@ -30,11 +33,13 @@ import (
 // for generating linter configuration files based on user-defined config.
 //
 // It holds a reference to the resolved config.BaseLintConfig and tracks the
-// target directory and secure root for file operations.
+// target directory and output for file operations.
 type BaseLint struct {
 	cfg       *config.BaseLintConfig // Validated service configuration.
 	targetDir string                 // Destination path for rendered files.
-	root      *os.Root               // Secure a root handle for a safe file writes.
+	output    goboottypes.OutputFS   // Output injected by the orchestrator; may be nil.
+	out       goboottypes.OutputFS   // Output used during Run.
+	templates fs.FS                  // Template source used during Run.
 	script    goboottypes.Registrar  // Contains the Methods to run in base_local.
 }
 
@@ -55,6 +60,13 @@ func (b *BaseLint) SetScriptReceiver(reg goboottypes.Registrar) {
 	b.script = reg
 }
 
+// SetOutput sets the output the generated files are written into.
+//
+// Without an output, Run writes into targetDir/ProjectName on disk.
+func (b *BaseLint) SetOutput(out goboottypes.OutputFS) {
+	b.output = out
+}
+
 // ID returns the static service identifier used to register and retrieve this service.
 //
 // It matches the constant defined in the type package and must align with
@@ -76,6 +88,11 @@ func (b *BaseLint) SetConfig(cfg config.ServiceConfig) error {
 
 	b.cfg = baseCfg
 
+	// Embedded templates cannot be overwritten.
+	if gobootfs.IsBuiltin(b.cfg.SourcePath) {
+		return nil
+	}
+
 	// Ensure source and target paths are different (prevent accidental overwrite).
 	err := gobootutils.ComparePaths(b.cfg.SourcePath, b.targetDir, true)
 	if err != nil {
@@ -87,25 +104,22 @@ func (b *BaseLint) SetConfig(cfg config.ServiceConfig) error {
 
 // Run executes the base lint generation logic.
 //
-// It performs a type assertion to ensure the correct config type was passed.
-//
-// After storing the typed config, it begins the file scaffolding process.
+// It opens the output (injected or on disk) and begins the file scaffolding process.
 //
 // This assumes config has been validated during initialization.
 func (b *BaseLint) Run() error {
-	curRoot, err := gobootutils.CreateRootDir(b.targetDir, b.cfg.ProjectName)
+	out, release, err := gobootfs.Open(b.output, b.targetDir, b.cfg.ProjectName)
 	if err != nil {
 		return fmt.Errorf("failed to create root dir: %w", err)
 	}
+	defer release()
 
-	defer func() {
-		err := curRoot.Close()
-		if err != nil {
-			fmt.Println("Failed to close root dir:", err)
-		}
-	}()
+	b.out = out
 
-	b.root = curRoot
+	b.templates, err = gobootfs.Templates(b.cfg.SourcePath)
+	if err != nil {
+		return fmt.Errorf("failed to open templates: %w", err)
+	}
 
 	// Trigger the core logic to copy and render relevant linter files.
 	err = b.copyFiles()
@@ -128,8 +142,8 @@ func (b *BaseLint) Run() error {
 //
 //nolint:cyclop // Flat switch is preferred for explicit control and traceability.
 func (b *BaseLint) copyFiles() error {
-	for name, info := range b.cfg.Linters {
-		if !info.Enabled {
+	for _, name := range b.linterNames() {
+		if !b.cfg.Linters[name].Enabled {
 			continue
 		}
 
@@ -177,60 +191,63 @@ func (b *BaseLint) copyFiles() error {
 }
 
 // handleLintFile is a helper that encapsulates the steps to:
-//   - Copy a static linter config file from sourcePath to the project.
+//   - Read a static linter config template from sourcePath.
 //   - Apply template rendering with project-specific values.
+//   - Write the rendered file into the output.
 //
 // Parameters:
 //   - name: Linter name (used for log context).
-//   - fileName: File to copy and render.
+//   - fileName: File to render.
 func (b *BaseLint) handleLintFile(name, fileName string) error {
-	err := b.copyFile(fileName)
+	src, content, err := b.readTemplate(fileName)
 	if err != nil {
 		return fmt.Errorf("failed to copy %s: %w", name, err)
 	}
 
-	err = gobootutils.RenderTemplateToFile("lint_file", b.root, fileName, b.cfg)
+	rendered, err := gobootutils.ExecuteTemplateText("lint_file", string(content), b.cfg)
+	if err != nil {
+		return fmt.Errorf("failed template render: %w", err)
+	}
+
+	err = b.out.WriteFile(goboottypes.OutputFile{
+		Path:     fileName,
+		Content:  []byte(rendered),
+		Perm:     goboottypes.FilePerm,
+		Template: src,
+	})
 	if err != nil {
-		return fmt.Errorf("failed to render template to file: %w", err)
+		return fmt.Errorf("failed to write file %q: %w", fileName, err)
 	}
 
 	return nil
 }
 
-// copyFile reads a single static file from the SourcePath and writes it
-// into the target directory within the secure os.Root.
-//
-// Used for initial transfer before template rendering is applied.
+// readTemplate reads a single static template file from the SourcePath.
 //
-// Expect a relative filename (e.g., ".golangci.yml").
+// Expect a relative filename (e.g., ".golangci.yml"); the template suffix is appended.
 //
-// Returns an error if reading or writing fails.
-func (b *BaseLint) copyFile(fileName string) error {
-	src := path.Join(b.cfg.SourcePath, fileName+goboottypes.TemplateSuffix)
+// Returns the template path and its content, or an error if the template is missing or cannot be read.
+func (b *BaseLint) readTemplate(fileName string) (string, []byte, error) {
+	name := fileName + goboottypes.TemplateSuffix
+	src := path.Join(b.cfg.SourcePath, name)
 
-	// #nosec G304 -- path is safe and user-defined; used intentionally for scaffolding.
-	content, err := os.ReadFile(src)
+	content, err := fs.ReadFile(b.templates, name)
 	if err != nil {
-		if errors.Is(err, os.ErrNotExist) {
-			return fmt.Errorf("missing required template %q (expected %q)", fileName, src)
+		if errors.Is(err, fs.ErrNotExist) {
+			return "", nil, fmt.Errorf("missing required template %q (expected %q)", fileName, src)
 		}
 
-		return fmt.Errorf("failed to read template file %q: %w", src, err)
-	}
-
-	// Create and write a file into the secured target root.
-	dstFile, err := b.root.Create(fileName)
-	if err != nil {
-		return fmt.Errorf("failed to create file %q in root: %w", fileName, err)
+		return "", nil, fmt.Errorf("failed to read template file %q: %w", src, err)
 	}
-	defer gobootutils.CloseFileWithErr(dstFile)
 
-	_, err = dstFile.Write(content)
-	if err != nil {
-		return fmt.Errorf("failed to write file %q: %w", fileName, err)
-	}
+	return src, content, nil
+}
 
-	return nil
+// linterNames returns the configured linter names in sorted order.
+//
+// The linters are stored in a map, so sorting keeps the rendered script order stable across runs.
+func (b *BaseLint) linterNames() []string {
+	return slices.Sorted(maps.Keys(b.cfg.Linters))
 }
 
 // registerScripts collects all enabled linter commands and registers them
@@ -243,7 +260,8 @@ func (b *BaseLint) copyFile(fileName string) error {
 func (b *BaseLint) registerScripts() error {
 	cmds := make([]string, 0, len(b.cfg.Linters))
 
-	for name, entry := range b.cfg.Linters {
+	for _, name := range b.linterNames() {
+		entry := b.cfg.Linters[name]
 		if !entry.Enabled {
 			continue
 		}
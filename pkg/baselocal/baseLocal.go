@@ -17,10 +17,12 @@ package baselocal
 import (
 	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path"
 
 	"github.com/it-timo/goboot/pkg/config"
+	"github.com/it-timo/goboot/pkg/gobootfs"
 	"github.com/it-timo/goboot/pkg/goboottypes"
 	"github.com/it-timo/goboot/pkg/gobootutils"
 )
@@ -29,11 +31,13 @@ import (
 // for generating local scripts.
 //
 // It holds a reference to the resolved config.BaseLocalConfig and tracks the
-// target directory and secure root for file operations.
+// target directory and output for file operations.
 type BaseLocal struct {
 	cfg       *config.BaseLocalConfig // Validated service configuration.
 	targetDir string                  // Destination path for rendered files.
-	root      *os.Root                // Secure a root handle for a safe file writes.
+	output    goboottypes.OutputFS    // Output injected by the orchestrator; may be nil.
+	out       goboottypes.OutputFS    // Output used during Run.
+	templates fs.FS                   // Template source used during Run.
 	scriptRegistry
 }
 
@@ -69,6 +73,13 @@ func (b *BaseLocal) ID() string {
 	return goboottypes.ServiceNameBaseLocal
 }
 
+// SetOutput sets the output the generated files are written into.
+//
+// Without an output, Run writes into targetDir/ProjectName on disk.
+func (b *BaseLocal) SetOutput(out goboottypes.OutputFS) {
+	b.output = out
+}
+
 // SetConfig assigns the base local configuration.
 //
 // It performs a type assertion to ensure the correct config type was passed.
@@ -82,6 +93,11 @@ func (b *BaseLocal) SetConfig(cfg config.ServiceConfig) error {
 
 	b.cfg = baseCfg
 
+	// Embedded templates cannot be overwritten.
+	if gobootfs.IsBuiltin(b.cfg.SourcePath) {
+		return nil
+	}
+
 	// Ensure source and target paths are different (prevent accidental overwrite).
 	err := gobootutils.ComparePaths(b.cfg.SourcePath, b.targetDir, true)
 	if err != nil {
@@ -93,25 +109,22 @@ func (b *BaseLocal) SetConfig(cfg config.ServiceConfig) error {
 
 // Run executes the base local generation logic.
 //
-// It performs a type assertion to ensure the correct config type was passed.
-//
-// After storing the typed config, it begins the file scaffolding process.
+// It opens the output (injected or on disk) and begins the file scaffolding process.
 //
 // This assumes config has been validated during initialization.
 func (b *BaseLocal) Run() error {
-	curRoot, err := gobootutils.CreateRootDir(b.targetDir, b.cfg.ProjectName)
+	out, release, err := gobootfs.Open(b.output, b.targetDir, b.cfg.ProjectName)
 	if err != nil {
 		return fmt.Errorf("failed to create root dir: %w", err)
 	}
+	defer release()
 
-	defer func() {
-		err := curRoot.Close()
-		if err != nil {
-			fmt.Println("Failed to close root dir:", err)
-		}
-	}()
+	b.out = out
 
-	b.root = curRoot
+	b.templates, err = gobootfs.Templates(b.cfg.SourcePath)
+	if err != nil {
+		return fmt.Errorf("failed to open templates: %w", err)
+	}
 	b.ProjectName = b.cfg.ProjectName
 
 	// Trigger the core logic to copy and render relevant script files.
@@ -191,31 +204,29 @@ func (b *BaseLocal) copyFiles() error {
 	for _, entry := range b.cfg.FileList {
 		switch entry {
 		case goboottypes.ScriptNameMake:
-			err := b.copyFile(b.cfg.SourcePath, "", "Makefile")
+			err := b.copyFile("", "", "Makefile")
 			if err != nil {
 				return fmt.Errorf("failed to copy Makefile: %w", err)
 			}
 		case goboottypes.ScriptNameTask:
-			err := b.copyFile(b.cfg.SourcePath, "", "Taskfile.yml")
+			err := b.copyFile("", "", "Taskfile.yml")
 			if err != nil {
 				return fmt.Errorf("failed to copy Taskfile: %w", err)
 			}
 		case goboottypes.ScriptNameCommit:
-			err := b.copyFile(b.cfg.SourcePath, "", ".pre-commit-config.yaml")
+			err := b.copyFile("", "", ".pre-commit-config.yaml")
 			if err != nil {
 				return fmt.Errorf("failed to copy Pre-Commit: %w", err)
 			}
 		case goboottypes.ScriptNameScript:
 			if len(b.ScriptFiles) > 0 {
-				err := gobootutils.EnsureDir(goboottypes.ScriptDirNameScript, b.root, goboottypes.DirPerm)
+				err := b.out.MkdirAll(goboottypes.ScriptDirNameScript)
 				if err != nil {
 					return fmt.Errorf("failed to create scripts dir: %w", err)
 				}
 
-				scriptsSrcPath := path.Join(b.cfg.SourcePath, goboottypes.ScriptDirNameScript)
-
 				for fileName := range b.ScriptFiles {
-					err = b.copyFile(scriptsSrcPath, goboottypes.ScriptDirNameScript, fileName)
+					err = b.copyFile(goboottypes.ScriptDirNameScript, goboottypes.ScriptDirNameScript, fileName)
 					if err != nil {
 						return fmt.Errorf("failed to copy %q: %w", fileName, err)
 					}
@@ -227,54 +238,48 @@ func (b *BaseLocal) copyFiles() error {
 	return nil
 }
 
-// copyFile reads a single static file from the SourcePath and writes it
-// into the target directory within the secure os.Root.
-//
-// Used for initial transfer before template rendering is applied.
+// copyFile reads a single template file from the SourcePath, renders it with the collected scripts,
+// and writes it into the output.
 //
-// Expect the target path and a relative filename (e.g., "Makefile").
+// Expect the source directory relative to the SourcePath (empty for its root), the target path,
+// and a relative filename (e.g., "Makefile").
+// Files in the script directory are written as executables.
 //
-// Returns an error if reading or writing fails.
-func (b *BaseLocal) copyFile(srcPath, targetPath, fileName string) error {
-	src := path.Join(srcPath, fileName+goboottypes.TemplateSuffix)
+// Returns an error if reading, rendering, or writing fails.
+func (b *BaseLocal) copyFile(srcDir, targetPath, fileName string) error {
+	name := path.Join(srcDir, fileName+goboottypes.TemplateSuffix)
+	src := path.Join(b.cfg.SourcePath, name)
 
-	// #nosec G304 -- this file path is safe and user-defined; used intentionally for scaffolding.
-	content, err := os.ReadFile(src)
+	content, err := fs.ReadFile(b.templates, name)
 	if err != nil {
-		if errors.Is(err, os.ErrNotExist) {
+		if errors.Is(err, fs.ErrNotExist) {
 			return fmt.Errorf("missing required template %q (expected %q)", fileName, src)
 		}
 
 		return fmt.Errorf("failed to read template file %q: %w", src, err)
 	}
 
+	perm := os.FileMode(goboottypes.FilePerm)
+
 	if targetPath == goboottypes.ScriptDirNameScript {
 		fileName = path.Join(targetPath, fileName)
+		perm = goboottypes.ScriptPerm
 	}
 
-	// Create and write a file into the secured target root.
-	dstFile, err := b.root.Create(fileName)
+	rendered, err := gobootutils.ExecuteTemplateText("script_file", string(content), b.scriptRegistry)
 	if err != nil {
-		return fmt.Errorf("failed to create file %q in root: %w", fileName, err)
+		return fmt.Errorf("failed template render: %w", err)
 	}
-	defer gobootutils.CloseFileWithErr(dstFile)
 
-	_, err = dstFile.Write(content)
+	err = b.out.WriteFile(goboottypes.OutputFile{
+		Path:     fileName,
+		Content:  []byte(rendered),
+		Perm:     perm,
+		Template: src,
+	})
 	if err != nil {
 		return fmt.Errorf("failed to write file %q: %w", fileName, err)
 	}
 
-	if targetPath == goboottypes.ScriptDirNameScript {
-		err = dstFile.Chmod(goboottypes.ScriptPerm)
-		if err != nil {
-			return fmt.Errorf("failed to set executable permissions on %q: %w", fileName, err)
-		}
-	}
-
-	err = gobootutils.RenderTemplateToFile("script_file", b.root, fileName, b.scriptRegistry)
-	if err != nil {
-		return fmt.Errorf("failed to render template to file: %w", err)
-	}
-
 	return nil
 }
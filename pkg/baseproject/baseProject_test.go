@@ -9,6 +9,7 @@ import (
 
 	"github.com/it-timo/goboot/pkg/baseproject"
 	"github.com/it-timo/goboot/pkg/config"
+	"github.com/it-timo/goboot/pkg/gobootfs"
 	"github.com/it-timo/goboot/pkg/goboottypes"
 )
 
@@ -185,6 +186,25 @@ var _ = Describe("BaseProject Service", func() {
 			Expect(string(readmeContent)).To(ContainSubstring(cfg.CapsProjectName))
 		})
 
+		It("writes into an injected output instead of the disk", func() {
+			writeTemplate("README.md", "# {{.CapsProjectName}}")
+
+			cfg := buildConfig()
+			baseProj = baseproject.NewBaseProject(tempDir)
+			Expect(baseProj.SetConfig(cfg)).To(Succeed())
+
+			memory := gobootfs.NewMemoryFS(nil)
+			baseProj.SetOutput(memory)
+
+			Expect(baseProj.Run()).To(Succeed())
+
+			Expect(filepath.Join(tempDir, cfg.ProjectName)).NotTo(BeADirectory())
+
+			content, err := memory.ReadFile("README.md")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(Equal("# " + cfg.CapsProjectName))
+		})
+
 		It("errors on invalid path templates", func() {
 			// invalid template in filename
 			writeTemplate("{{.ProjectName", "content")
@@ -16,11 +16,11 @@ import (
 	"errors"
 	"fmt"
 	"io/fs"
-	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/it-timo/goboot/pkg/config"
+	"github.com/it-timo/goboot/pkg/gobootfs"
 	"github.com/it-timo/goboot/pkg/goboottypes"
 	"github.com/it-timo/goboot/pkg/gobootutils"
 )
@@ -29,11 +29,13 @@ import (
 // for generating testing configuration files based on user-defined config.
 //
 // It holds a reference to the resolved config.BaseTestConfig and tracks the
-// target directory and secure root for file operations.
+// target directory and output for file operations.
 type BaseTest struct {
 	cfg       *config.BaseTestConfig // Validated service configuration.
 	targetDir string                 // Destination path for rendered files.
-	root      *os.Root               // Secure a root handle for a safe file writes.
+	output    goboottypes.OutputFS   // Output injected by the orchestrator; may be nil.
+	out       goboottypes.OutputFS   // Output used during Run.
+	templates fs.FS                  // Template source used during Run.
 	script    goboottypes.Registrar  // Contains the Methods to run in base_local.
 }
 
@@ -54,6 +56,13 @@ func (b *BaseTest) SetScriptReceiver(reg goboottypes.Registrar) {
 	b.script = reg
 }
 
+// SetOutput sets the output the generated files are written into.
+//
+// Without an output, Run writes into targetDir/ProjectName on disk.
+func (b *BaseTest) SetOutput(out goboottypes.OutputFS) {
+	b.output = out
+}
+
 // ID returns the static service identifier used to register and retrieve this service.
 func (b *BaseTest) ID() string {
 	return goboottypes.ServiceNameBaseTest
@@ -72,6 +81,11 @@ func (b *BaseTest) SetConfig(cfg config.ServiceConfig) error {
 
 	b.cfg = baseCfg
 
+	// Embedded templates cannot be overwritten.
+	if gobootfs.IsBuiltin(b.cfg.SourcePath) {
+		return nil
+	}
+
 	// Ensure source and target paths are different (prevent accidental overwrite).
 	err := gobootutils.ComparePaths(b.cfg.SourcePath, b.targetDir, true)
 	if err != nil {
@@ -83,15 +97,16 @@ func (b *BaseTest) SetConfig(cfg config.ServiceConfig) error {
 
 // Run executes the base test generation logic.
 //
-// It recursively walks the configured SourcePath (templates), copies files to the target,
-// and applies template rendering to both file paths and file content.
+// It recursively walks the configured SourcePath (templates), renders both file paths and file content,
+// and writes the result into the output (injected or on disk).
 func (b *BaseTest) Run() error {
-	curRoot, err := gobootutils.CreateRootDir(b.targetDir, b.cfg.ProjectName)
+	out, release, err := gobootfs.Open(b.output, b.targetDir, b.cfg.ProjectName)
 	if err != nil {
 		return fmt.Errorf("failed to create root dir: %w", err)
 	}
+	defer release()
 
-	b.root = curRoot
+	b.out = out
 
 	err = b.createNewTestSetup()
 	if err != nil {
@@ -110,22 +125,22 @@ func (b *BaseTest) Run() error {
 
 // createNewTestSetup initializes the test structure by rendering paths and file contents.
 //
-// It performs two passes over the template directory inside the secure root:
-//   - 1. Renders file and directory paths using Go templates.
-//   - 2. Renders the contents of template files using the BaseTest config.
+// Each entry of the template directory is rendered in a single pass:
+//   - The relative path is rendered using Go templates.
+//   - The content of template files is rendered using the BaseTest config.
 //
-// All operations are strictly contained within the `*os.Root` directory.
+// Only the rendered result is written into the output; the templates are never copied as-is.
 func (b *BaseTest) createNewTestSetup() error {
-	// Step 1: Copy from host template into root.
-	err := b.walkAndApply(os.DirFS(b.cfg.SourcePath), b.renderPath)
+	templates, err := gobootfs.Templates(b.cfg.SourcePath)
 	if err != nil {
-		return fmt.Errorf("failed to render path: %w", err)
+		return fmt.Errorf("failed to open templates: %w", err)
 	}
 
-	// 2. Render file contents inside an already-copied structure.
-	err = b.walkAndApply(b.root.FS(), b.renderContent)
+	b.templates = templates
+
+	err = b.walkAndApply(templates, b.renderEntry)
 	if err != nil {
-		return fmt.Errorf("failed to render content: %w", err)
+		return fmt.Errorf("failed to render templates: %w", err)
 	}
 
 	return nil
@@ -134,7 +149,7 @@ func (b *BaseTest) createNewTestSetup() error {
 // walkAndApply traverses the given fs.FS starting from the root ".", applying the handler function to each entry.
 //
 // Parameters:
-//   - fsys: the filesystem to walk (e.g., os.DirFS(rootDir), b.root.FS()).
+//   - fsys: the filesystem to walk (e.g., the result of gobootfs.Templates).
 //   - handler: the function to apply to each entry.
 //
 // Returns an error if walking or handling fails.
@@ -158,17 +173,16 @@ func (b *BaseTest) walkAndApply(fsys fs.FS, handler func(path string, d fs.DirEn
 	return nil
 }
 
-// renderPath processes directories and files from the template source,
-// applying Go template rendering to all relative paths and replicating the structure inside the root.
-//
-// For files, the content is copied as-is (templating happens in renderContent).
+// renderEntry processes directories and files from the template source,
+// applying Go template rendering to the relative path and the file content,
+// and replicating the structure inside the output.
 //
 // Parameters:
-//   - path: The relative target path within the root.
+//   - relTemplatePath: The relative path within the template source.
 //   - dirEntry: The directory entry metadata.
 //
-// Returns an error if path rendering, reading, or writing fails.
-func (b *BaseTest) renderPath(relTemplatePath string, dirEntry fs.DirEntry) error {
+// Returns an error if path rendering, reading, content rendering, or writing fails.
+func (b *BaseTest) renderEntry(relTemplatePath string, dirEntry fs.DirEntry) error {
 	// Render the target path using template logic (e.g. "cmd/{{project_name}}/main.go").
 	renderedPath, err := gobootutils.ExecuteTemplateText("relpath", relTemplatePath, b.cfg)
 	if err != nil {
@@ -183,9 +197,9 @@ func (b *BaseTest) renderPath(relTemplatePath string, dirEntry fs.DirEntry) erro
 	// Remove the template suffix for the test files.
 	renderedPath = strings.TrimSuffix(renderedPath, goboottypes.TemplateSuffix)
 
-	// If it's a directory, create it inside the root.
+	// If it's a directory, create it inside the output.
 	if dirEntry.IsDir() {
-		err = gobootutils.EnsureDir(renderedPath, b.root, goboottypes.DirPerm)
+		err = b.out.MkdirAll(renderedPath)
 		if err != nil {
 			return fmt.Errorf("failed to ensure directory %q: %w", renderedPath, err)
 		}
@@ -193,29 +207,25 @@ func (b *BaseTest) renderPath(relTemplatePath string, dirEntry fs.DirEntry) erro
 		return nil
 	}
 
-	// Read a template file from the rootDir.
+	// Read a template file from the template source.
 	fullTemplatePath := filepath.Join(b.cfg.SourcePath, relTemplatePath)
 
-	// #nosec G304 -- the path is user-defined and expected to be dynamic.
-	content, err := os.ReadFile(fullTemplatePath)
+	content, err := fs.ReadFile(b.templates, relTemplatePath)
 	if err != nil {
 		return fmt.Errorf("failed to read template file %q: %w", fullTemplatePath, err)
 	}
 
-	// Ensure destination directory exists.
-	err = gobootutils.EnsureDir(filepath.Dir(renderedPath), b.root, goboottypes.DirPerm)
-	if err != nil {
-		return fmt.Errorf("failed to ensure destination directory %q: %w", filepath.Dir(renderedPath), err)
-	}
-
-	// Create and write a file into root.
-	dstFile, err := b.root.Create(renderedPath)
+	rendered, err := gobootutils.ExecuteTemplateText("test_file", string(content), b.cfg)
 	if err != nil {
-		return fmt.Errorf("failed to create file %q in root: %w", renderedPath, err)
+		return fmt.Errorf("failed template render: %w", err)
 	}
-	defer gobootutils.CloseFileWithErr(dstFile)
 
-	_, err = dstFile.Write(content)
+	err = b.out.WriteFile(goboottypes.OutputFile{
+		Path:     renderedPath,
+		Content:  []byte(rendered),
+		Perm:     goboottypes.FilePerm,
+		Template: filepath.ToSlash(fullTemplatePath),
+	})
 	if err != nil {
 		return fmt.Errorf("failed to write file %q: %w", renderedPath, err)
 	}
@@ -223,29 +233,6 @@ func (b *BaseTest) renderPath(relTemplatePath string, dirEntry fs.DirEntry) erro
 	return nil
 }
 
-// renderContent applies Go template rendering to the content of all non-directory files
-// within the root directory, using the BaseTest configuration as the template context.
-//
-// The rendered content is written back to the same file location.
-//
-// Parameters:
-//   - path: The relative path to the file within root.
-//   - dirEntry: The directory entry metadata.
-//
-// Returns an error if reading, rendering, or writing fails.
-func (b *BaseTest) renderContent(path string, dirEntry fs.DirEntry) error {
-	if dirEntry.IsDir() {
-		return nil
-	}
-
-	err := gobootutils.RenderTemplateToFile("test_file", b.root, path, b.cfg)
-	if err != nil {
-		return fmt.Errorf("failed to render template to file: %w", err)
-	}
-
-	return nil
-}
-
 // registerScripts registers the standard test command.
 func (b *BaseTest) registerScripts() error {
 	err := b.script.RegisterLines(goboottypes.ServiceNameBaseTest, []string{b.cfg.TestCMD})
@@ -1,7 +1,9 @@
 package config
 
 import (
-	"fmt"
+	"errors"
+	"maps"
+	"slices"
 	"strings"
 
 	"github.com/it-timo/goboot/pkg/goboottypes"
@@ -10,7 +12,11 @@ import (
 // BaseLintConfig defines the metadata used by goboot to generate linting setup for a project.
 // It injects values into templates (e.g., .golangci.yml) and governs how project-specific linting is rendered.
 type BaseLintConfig struct {
-	// SourcePath is the path to the template source directory (e.g., "./templates/lint_base").
+	// Version is the version of the config format (see ConfigVersion); older configs are migrated when read.
+	Version int `yaml:"version"`
+
+	// SourcePath is the path to the template source directory (e.g., "./templates/lint_base"),
+	// or an embedded template set (e.g., "builtin:lint_base"). Defaults to the embedded set if empty.
 	SourcePath string `yaml:"sourcePath"`
 
 	// ProjectName is the short identifier for the project (e.g., "goboot").
@@ -27,6 +33,9 @@ type BaseLintConfig struct {
 	// AllowedPackages is a list of packages that are allowed to be imported.
 	// Used in linter config like depguard.
 	AllowedPackages []string `yaml:"allowedPackages"`
+
+	// warn receives the warnings of decoding and validating the config; nil drops them (see GoBoot.Warnings).
+	warn warnFunc
 }
 
 // Linter defines an individual linter to be included in the generated linting setup.
@@ -48,8 +57,17 @@ var lintCmds = map[string]string{
 	goboottypes.LinterSHFMT: goboottypes.DefaultSHFMTCmd,
 }
 
-// newBaseLintConfig returns a newly initialized BaseLintConfig with the project name.
-func newBaseLintConfig(projectName string) *BaseLintConfig {
+// LinterNames returns the names of all linters with a default command, sorted alphabetically.
+func LinterNames() []string {
+	return slices.Sorted(maps.Keys(lintCmds))
+}
+
+// baseLintKeys are the keys of the base lint config (see BaseLintConfig and Linter).
+var baseLintKeys = newConfigKeys("version", "sourcePath", "allowedPackages").
+	with("linters", &configKeys{values: newConfigKeys("cmd", "enabled")})
+
+// NewBaseLintConfig returns a newly initialized BaseLintConfig with the project name.
+func NewBaseLintConfig(projectName string) *BaseLintConfig {
 	return &BaseLintConfig{
 		ProjectName: projectName,
 	}
@@ -60,26 +78,60 @@ func (bl *BaseLintConfig) ID() string {
 	return goboottypes.ServiceNameBaseLint
 }
 
+// configKeys returns the keys the YAML mapping of this config may contain.
+func (bl *BaseLintConfig) configKeys() *configKeys {
+	return baseLintKeys
+}
+
+// stampVersion sets the version of a config built in code, which has none, to ConfigVersion.
+func (bl *BaseLintConfig) stampVersion() {
+	if bl.Version == 0 {
+		bl.Version = ConfigVersion
+	}
+}
+
+// setWarn sets the receiver of the warnings of decoding and validating the config.
+func (bl *BaseLintConfig) setWarn(warn warnFunc) {
+	bl.warn = warn
+}
+
+// Role returns RoleMain, as the linter setup is added to the bootstrapped project.
+func (bl *BaseLintConfig) Role() Role {
+	return RoleMain
+}
+
 // ReadConfig loads the base lint configuration from the provided YAML file path.
 //
 // It overwrites the current config values with the file contents.
 func (bl *BaseLintConfig) ReadConfig(confPath string, repoURL string) error {
-	bl.RepoImportPath = strings.TrimPrefix(repoURL, "https://")
-	bl.RepoImportPath = strings.TrimPrefix(bl.RepoImportPath, "http://")
+	data, err := readYMLFile(confPath)
+	if err != nil {
+		return err
+	}
+
+	return withFile(bl.DecodeConfig(data, repoURL), confPath)
+}
+
+// DecodeConfig loads the base lint configuration from YAML data.
+//
+// It overwrites the current config values with the decoded values.
+func (bl *BaseLintConfig) DecodeConfig(data []byte, repoURL string) error {
+	bl.RepoImportPath = repoPath(repoURL)
 
-	return readYMLConfig(confPath, bl)
+	return decodeYMLConfig(data, bl, bl.warn)
 }
 
 // Validate verifies the BaseLintConfig for use in scaffolding.
 //
 // It returns an error if required values are missing/invalid, or calls fillNeededInfos.
 func (bl *BaseLintConfig) Validate() error {
-	var missing []string
-
-	if strings.TrimSpace(bl.SourcePath) == "" {
-		missing = append(missing, "sourcePath")
+	sourcePath, sourceErr := resolveSourcePath(bl.SourcePath, goboottypes.TemplateSetLintBase)
+	if sourceErr == nil {
+		bl.SourcePath = sourcePath
 	}
 
+	var missing []string
+
 	if strings.TrimSpace(bl.ProjectName) == "" {
 		missing = append(missing, "projectName")
 	}
@@ -88,8 +140,9 @@ func (bl *BaseLintConfig) Validate() error {
 		missing = append(missing, "repoImportPath")
 	}
 
-	if len(missing) > 0 {
-		return fmt.Errorf("missing required config fields: %s", strings.Join(missing, ", "))
+	err := errors.Join(fieldErr("sourcePath", sourceErr), missingFields(missing))
+	if err != nil {
+		return err
 	}
 
 	bl.fillNeededInfos()
@@ -113,7 +166,9 @@ func (bl *BaseLintConfig) fillNeededInfos() {
 				continue
 			}
 
-			fmt.Printf("[WARN] Unknown linter %q; no default command defined", name)
+			if bl.warn != nil {
+				bl.warn("unknown linter %q; no default command defined", name)
+			}
 		}
 	}
 }
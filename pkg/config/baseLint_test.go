@@ -55,6 +55,15 @@ var _ = Describe("BaseLintConfig", func() {
 		})
 	})
 
+	Describe("LinterNames", func() {
+		It("lists the linters with a default command in a stable order", func() {
+			Expect(config.LinterNames()).To(Equal([]string{
+				goboottypes.LinterGo, goboottypes.LinterMake, goboottypes.LinterMD,
+				goboottypes.LinterShell, goboottypes.LinterSHFMT, goboottypes.LinterYAML,
+			}))
+		})
+	})
+
 	Describe("Validate", func() {
 		Context("with valid config", func() {
 			It("validates successfully", func() {
@@ -79,11 +88,10 @@ var _ = Describe("BaseLintConfig", func() {
 		})
 
 		Context("with missing required fields", func() {
-			It("errors when sourcePath is missing", func() {
+			It("falls back to the builtin templates when sourcePath is missing", func() {
 				baseLint.SourcePath = ""
-				err := baseLint.Validate()
-				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(ContainSubstring("sourcePath"))
+				Expect(baseLint.Validate()).To(Succeed())
+				Expect(baseLint.SourcePath).To(Equal(goboottypes.BuiltinPrefix + goboottypes.TemplateSetLintBase))
 			})
 
 			It("errors when projectName is missing", func() {
@@ -101,11 +109,9 @@ var _ = Describe("BaseLintConfig", func() {
 			})
 
 			It("errors when multiple fields are missing", func() {
-				baseLint.SourcePath = ""
 				baseLint.ProjectName = ""
 				err := baseLint.Validate()
 				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(ContainSubstring("sourcePath"))
 				Expect(err.Error()).To(ContainSubstring("projectName"))
 			})
 		})
@@ -113,9 +119,8 @@ var _ = Describe("BaseLintConfig", func() {
 		Context("with whitespace-only values", func() {
 			It("treats whitespace-only sourcePath as missing", func() {
 				baseLint.SourcePath = "    "
-				err := baseLint.Validate()
-				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(ContainSubstring("sourcePath"))
+				Expect(baseLint.Validate()).To(Succeed())
+				Expect(baseLint.SourcePath).To(Equal(goboottypes.BuiltinPrefix + goboottypes.TemplateSetLintBase))
 			})
 
 			It("treats whitespace-only projectName as missing", func() {
@@ -1,7 +1,7 @@
 package config
 
 import (
-	"fmt"
+	"errors"
 	"strings"
 
 	"github.com/it-timo/goboot/pkg/goboottypes"
@@ -11,7 +11,11 @@ import (
 //
 // It injects values into templates (e.g., Makefile) and governs how project-specific scripts are rendered.
 type BaseLocalConfig struct {
-	// SourcePath is the path to the template source directory (e.g., "./templates/local_base").
+	// Version is the version of the config format (see ConfigVersion); older configs are migrated when read.
+	Version int `yaml:"version"`
+
+	// SourcePath is the path to the template source directory (e.g., "./templates/local_base"),
+	// or an embedded template set (e.g., "builtin:local_base"). Defaults to the embedded set if empty.
 	SourcePath string `yaml:"sourcePath"`
 
 	// ProjectName is the short identifier for the project (e.g., "goboot").
@@ -20,10 +24,16 @@ type BaseLocalConfig struct {
 
 	// FileList is a list of files to be copied from the source path to the target path.
 	FileList []string `yaml:"fileList"`
+
+	// warn receives the warnings of decoding and validating the config; nil drops them (see GoBoot.Warnings).
+	warn warnFunc
 }
 
-// newBaseLocalConfig returns a newly initialized BaseLocalConfig with the project name.
-func newBaseLocalConfig(projectName string) *BaseLocalConfig {
+// baseLocalKeys are the keys of the base local config (see BaseLocalConfig).
+var baseLocalKeys = newConfigKeys("version", "sourcePath", "fileList")
+
+// NewBaseLocalConfig returns a newly initialized BaseLocalConfig with the project name.
+func NewBaseLocalConfig(projectName string) *BaseLocalConfig {
 	return &BaseLocalConfig{
 		ProjectName: projectName,
 	}
@@ -34,23 +44,58 @@ func (bl *BaseLocalConfig) ID() string {
 	return goboottypes.ServiceNameBaseLocal
 }
 
+// configKeys returns the keys the YAML mapping of this config may contain.
+func (bl *BaseLocalConfig) configKeys() *configKeys {
+	return baseLocalKeys
+}
+
+// stampVersion sets the version of a config built in code, which has none, to ConfigVersion.
+func (bl *BaseLocalConfig) stampVersion() {
+	if bl.Version == 0 {
+		bl.Version = ConfigVersion
+	}
+}
+
+// setWarn sets the receiver of the warnings of decoding and validating the config.
+func (bl *BaseLocalConfig) setWarn(warn warnFunc) {
+	bl.warn = warn
+}
+
+// Role returns RoleFinalizer, as the local tooling renders the scripts registered by all other services.
+func (bl *BaseLocalConfig) Role() Role {
+	return RoleFinalizer
+}
+
 // ReadConfig loads the base local configuration from the provided YAML file path.
 //
 // It overwrites the current config values with the file contents.
-func (bl *BaseLocalConfig) ReadConfig(confPath string, _ string) error {
-	return readYMLConfig(confPath, bl)
+func (bl *BaseLocalConfig) ReadConfig(confPath string, repoURL string) error {
+	data, err := readYMLFile(confPath)
+	if err != nil {
+		return err
+	}
+
+	return withFile(bl.DecodeConfig(data, repoURL), confPath)
+}
+
+// DecodeConfig loads the base local configuration from YAML data.
+//
+// It overwrites the current config values with the decoded values.
+func (bl *BaseLocalConfig) DecodeConfig(data []byte, _ string) error {
+	return decodeYMLConfig(data, bl, bl.warn)
 }
 
 // Validate verifies the BaseLocalConfig for use in scaffolding.
 //
 // It returns an error if required values are missing/invalid, or calls fillNeededInfos.
 func (bl *BaseLocalConfig) Validate() error {
-	var missing []string
-
-	if strings.TrimSpace(bl.SourcePath) == "" {
-		missing = append(missing, "sourcePath")
+	sourcePath, sourceErr := resolveSourcePath(bl.SourcePath, goboottypes.TemplateSetLocalBase)
+	if sourceErr == nil {
+		bl.SourcePath = sourcePath
 	}
 
+	var missing []string
+
 	if strings.TrimSpace(bl.ProjectName) == "" {
 		missing = append(missing, "projectName")
 	}
@@ -60,8 +105,9 @@ func (bl *BaseLocalConfig) Validate() error {
 		missing = append(missing, "fileList")
 	}
 
-	if len(missing) > 0 {
-		return fmt.Errorf("missing required config fields: %s", strings.Join(missing, ", "))
+	err := errors.Join(fieldErr("sourcePath", sourceErr), missingFields(missing))
+	if err != nil {
+		return err
 	}
 
 	seen := make(map[string]struct{})
@@ -70,13 +116,13 @@ func (bl *BaseLocalConfig) Validate() error {
 	for _, file := range bl.FileList {
 		trimmed := strings.TrimSpace(file)
 		if trimmed == "" {
-			invalid = append(invalid, "fileList contains blank entries")
+			invalid = append(invalid, "contains blank entries")
 
 			continue
 		}
 
 		if _, exists := seen[trimmed]; exists {
-			invalid = append(invalid, "fileList contains duplicates")
+			invalid = append(invalid, "contains duplicates")
 
 			continue
 		}
@@ -85,7 +131,7 @@ func (bl *BaseLocalConfig) Validate() error {
 	}
 
 	if len(invalid) > 0 {
-		return fmt.Errorf("invalid config: %s", strings.Join(invalid, ", "))
+		return fieldErr("fileList", errors.New(strings.Join(invalid, ", ")))
 	}
 
 	return nil
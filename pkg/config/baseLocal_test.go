@@ -51,11 +51,10 @@ var _ = Describe("BaseLocalConfig", func() {
 		})
 
 		Context("with missing required fields", func() {
-			It("errors when sourcePath is missing", func() {
+			It("falls back to the builtin templates when sourcePath is missing", func() {
 				baseLocal.SourcePath = ""
-				err := baseLocal.Validate()
-				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(ContainSubstring("sourcePath"))
+				Expect(baseLocal.Validate()).To(Succeed())
+				Expect(baseLocal.SourcePath).To(Equal(goboottypes.BuiltinPrefix + goboottypes.TemplateSetLocalBase))
 			})
 
 			It("errors when projectName is missing", func() {
@@ -80,12 +79,10 @@ var _ = Describe("BaseLocalConfig", func() {
 			})
 
 			It("errors when multiple fields are missing", func() {
-				baseLocal.SourcePath = ""
 				baseLocal.ProjectName = ""
 				baseLocal.FileList = nil
 				err := baseLocal.Validate()
 				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(ContainSubstring("sourcePath"))
 				Expect(err.Error()).To(ContainSubstring("projectName"))
 				Expect(err.Error()).To(ContainSubstring("fileList"))
 			})
@@ -94,9 +91,8 @@ var _ = Describe("BaseLocalConfig", func() {
 		Context("with whitespace-only values", func() {
 			It("treats whitespace-only sourcePath as missing", func() {
 				baseLocal.SourcePath = "   \t\n"
-				err := baseLocal.Validate()
-				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(ContainSubstring("sourcePath"))
+				Expect(baseLocal.Validate()).To(Succeed())
+				Expect(baseLocal.SourcePath).To(Equal(goboottypes.BuiltinPrefix + goboottypes.TemplateSetLocalBase))
 			})
 
 			It("treats whitespace-only projectName as missing", func() {
@@ -176,7 +172,6 @@ fileList:
 
 			It("loads single file in fileList", func() {
 				yamlContent := `sourcePath: ./templates
-projectName: proj
 fileList:
   - single.txt
 `
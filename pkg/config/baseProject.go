@@ -1,7 +1,7 @@
 package config
 
 import (
-	"fmt"
+	"errors"
 	"strings"
 	"time"
 
@@ -13,7 +13,11 @@ import (
 // It injects values into templates (e.g., README, LICENSE, CI configs) and governs
 // how project-specific identity and versioning are rendered.
 type BaseProjectConfig struct {
-	// SourcePath is the path where the project will walk to get the template files.
+	// Version is the version of the config format (see ConfigVersion); older configs are migrated when read.
+	Version int `yaml:"version"`
+
+	// SourcePath is the path where the project will walk to get the template files,
+	// or an embedded template set (e.g., "builtin:project_base"). Defaults to the embedded set if empty.
 	SourcePath string `yaml:"sourcePath"`
 
 	// ProjectURL is the full repository URL (e.g., "https://github.com/user/project").
@@ -36,7 +40,18 @@ type BaseProjectConfig struct {
 	// Used for safe filenames, Docker images, etc.
 	LowerProjectName string `yaml:"-"`
 
-	// UsedGoVersion specifies the Go version to write into config files (e.g., "1.22.2").
+	// PascalProjectName is the PascalCase variant (e.g., "GoBoot" for "goBoot").
+	// Used in Go identifiers (e.g., "NewGoBootConfig").
+	PascalProjectName string `yaml:"-"`
+
+	// SnakeProjectName is the snake_case variant (e.g., "go_boot" for "goBoot").
+	SnakeProjectName string `yaml:"-"`
+
+	// KebabProjectName is the kebab-case variant (e.g., "go-boot" for "goBoot").
+	KebabProjectName string `yaml:"-"`
+
+	// UsedGoVersion specifies the Go version to write into config files (e.g., "1.22.2"),
+	// or "auto" for the version of the local Go toolchain.
 	UsedGoVersion string `yaml:"usedGoVersion"`
 
 	// UsedNodeVersion specifies the Node.js version for optional tooling (e.g., "20.11.1").
@@ -62,10 +77,17 @@ type BaseProjectConfig struct {
 
 	// GitUser is the GitHub username or org (used in badges and URLs).
 	GitUser string `yaml:"gitUser"`
+
+	// warn receives the warnings of decoding and validating the config; nil drops them (see GoBoot.Warnings).
+	warn warnFunc
 }
 
-// newBaseProjectConfig returns a newly initialized BaseProjectConfig with the project name.
-func newBaseProjectConfig(projectName string) *BaseProjectConfig {
+// baseProjectKeys are the keys of the base project config (see BaseProjectConfig).
+var baseProjectKeys = newConfigKeys("version", "sourcePath", "usedGoVersion", "usedNodeVersion",
+	"currentYear", "releaseCurrentWindow", "releaseUpcomingWindow", "releaseLongTerm", "author", "gitProvider", "gitUser")
+
+// NewBaseProjectConfig returns a newly initialized BaseProjectConfig with the project name.
+func NewBaseProjectConfig(projectName string) *BaseProjectConfig {
 	return &BaseProjectConfig{
 		ProjectName: projectName,
 	}
@@ -76,12 +98,45 @@ func (bp *BaseProjectConfig) ID() string {
 	return goboottypes.ServiceNameBaseProject
 }
 
+// configKeys returns the keys the YAML mapping of this config may contain.
+func (bp *BaseProjectConfig) configKeys() *configKeys {
+	return baseProjectKeys
+}
+
+// stampVersion sets the version of a config built in code, which has none, to ConfigVersion.
+func (bp *BaseProjectConfig) stampVersion() {
+	if bp.Version == 0 {
+		bp.Version = ConfigVersion
+	}
+}
+
+// setWarn sets the receiver of the warnings of decoding and validating the config.
+func (bp *BaseProjectConfig) setWarn(warn warnFunc) {
+	bp.warn = warn
+}
+
+// Role returns RoleBootstrap, as the base project creates the target project.
+func (bp *BaseProjectConfig) Role() Role {
+	return RoleBootstrap
+}
+
 // ReadConfig loads the base project configuration from the provided YAML file path.
 // It overwrites the current config values with the file contents.
 func (bp *BaseProjectConfig) ReadConfig(confPath string, repoURL string) error {
+	data, err := readYMLFile(confPath)
+	if err != nil {
+		return err
+	}
+
+	return withFile(bp.DecodeConfig(data, repoURL), confPath)
+}
+
+// DecodeConfig loads the base project configuration from YAML data.
+// It overwrites the current config values with the decoded values.
+func (bp *BaseProjectConfig) DecodeConfig(data []byte, repoURL string) error {
 	bp.ProjectURL = repoURL
 
-	return readYMLConfig(confPath, bp)
+	return decodeYMLConfig(data, bp, bp.warn)
 }
 
 // Validate verifies the BaseProjectConfig for use in scaffolding.
@@ -90,12 +145,13 @@ func (bp *BaseProjectConfig) ReadConfig(confPath string, repoURL string) error {
 //
 //nolint:cyclop // flat validation logic preferred for clarity and extensibility.
 func (bp *BaseProjectConfig) Validate() error {
-	var missing []string
-
-	if strings.TrimSpace(bp.SourcePath) == "" {
-		missing = append(missing, "sourcePath")
+	sourcePath, sourceErr := resolveSourcePath(bp.SourcePath, goboottypes.TemplateSetProjectBase)
+	if sourceErr == nil {
+		bp.SourcePath = sourcePath
 	}
 
+	var missing []string
+
 	if strings.TrimSpace(bp.ProjectURL) == "" {
 		missing = append(missing, "projectUrl")
 	}
@@ -134,25 +190,40 @@ func (bp *BaseProjectConfig) Validate() error {
 		}
 	}
 
-	if len(missing) > 0 {
-		return fmt.Errorf("missing required config fields: %s", strings.Join(missing, ", "))
+	err := errors.Join(fieldErr("sourcePath", sourceErr), missingFields(missing), bp.validateVersions())
+	if err != nil {
+		return err
 	}
 
-	bp.fillNeededInfos()
-
-	return nil
+	return bp.fillNeededInfos()
 }
 
-// fillNeededInfos fills any derived fields in the config.
-func (bp *BaseProjectConfig) fillNeededInfos() {
-	// Normalize caps/lower.
+// fillNeededInfos fills any derived fields in the config and resolves usedGoVersion goboottypes.GoVersionAuto.
+func (bp *BaseProjectConfig) fillNeededInfos() error {
+	// Normalize caps/lower and the case variants.
 	bp.CapsProjectName = strings.ToUpper(bp.ProjectName)
 	bp.LowerProjectName = strings.ToLower(bp.ProjectName)
-	bp.RepoPath = strings.TrimPrefix(bp.ProjectURL, "https://")
-	bp.RepoPath = strings.TrimPrefix(bp.RepoPath, "http://")
+
+	words := projectNameWords(bp.ProjectName)
+	bp.PascalProjectName = pascalCase(words)
+	bp.SnakeProjectName = joinLower(words, "_")
+	bp.KebabProjectName = joinLower(words, "-")
+
+	bp.RepoPath = repoPath(bp.ProjectURL)
 
 	// Autofill year if not set.
 	if bp.CurrentYear == 0 {
 		bp.CurrentYear = time.Now().Year()
 	}
+
+	if bp.UsedGoVersion == goboottypes.GoVersionAuto {
+		goVersion, err := localGoVersion()
+		if err != nil {
+			return err
+		}
+
+		bp.UsedGoVersion = goVersion
+	}
+
+	return nil
 }
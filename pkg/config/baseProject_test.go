@@ -93,8 +93,6 @@ var _ = Describe("BaseProjectConfig", func() {
 					Expect(err).To(HaveOccurred())
 					Expect(err.Error()).To(ContainSubstring(expectedField))
 				},
-				Entry("sourcePath", func(bp *config.BaseProjectConfig) { bp.SourcePath = "" },
-					"sourcePath"),
 				Entry("projectUrl", func(bp *config.BaseProjectConfig) { bp.ProjectURL = "" },
 					"projectUrl"),
 				Entry("projectName", func(bp *config.BaseProjectConfig) { bp.ProjectName = "" },
@@ -113,12 +111,10 @@ var _ = Describe("BaseProjectConfig", func() {
 			)
 
 			It("errors when multiple fields are missing", func() {
-				baseProject.SourcePath = ""
 				baseProject.ProjectName = ""
 				baseProject.Author = ""
 				err := baseProject.Validate()
 				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(ContainSubstring("sourcePath"))
 				Expect(err.Error()).To(ContainSubstring("projectName"))
 				Expect(err.Error()).To(ContainSubstring("author"))
 			})
@@ -153,9 +149,15 @@ var _ = Describe("BaseProjectConfig", func() {
 		Context("with whitespace-only values", func() {
 			It("treats whitespace-only sourcePath as missing", func() {
 				baseProject.SourcePath = "     "
+				Expect(baseProject.Validate()).To(Succeed())
+				Expect(baseProject.SourcePath).To(Equal(goboottypes.BuiltinPrefix + goboottypes.TemplateSetProjectBase))
+			})
+
+			It("rejects unknown builtin template sets", func() {
+				baseProject.SourcePath = "builtin:docs_base"
 				err := baseProject.Validate()
 				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(ContainSubstring("sourcePath"))
+				Expect(err.Error()).To(ContainSubstring(`invalid sourcePath "builtin:docs_base"`))
 			})
 
 			It("treats whitespace-only projectName as missing", func() {
@@ -221,6 +223,16 @@ var _ = Describe("BaseProjectConfig", func() {
 				Expect(baseProject.CapsProjectName).To(Equal("ALREADYANOTHERUPPER"))
 				Expect(baseProject.LowerProjectName).To(Equal("alreadyanotherupper"))
 			})
+
+			It("derives the PascalCase, snake_case, and kebab-case variants", func() {
+				baseProject.ProjectName = "myHTTPServer2"
+				err := baseProject.Validate()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(baseProject.PascalProjectName).To(Equal("MyHTTPServer2"))
+				Expect(baseProject.SnakeProjectName).To(Equal("my_http_server2"))
+				Expect(baseProject.KebabProjectName).To(Equal("my-http-server2"))
+			})
 		})
 	})
 
@@ -270,9 +282,6 @@ currentYear: 2024
 
 			It("loads Git configuration", func() {
 				yamlContent := `sourcePath: ./templates
-projectUrl: https://gitlab.com/group/project
-repoPath: gitlab.com/group/project
-projectName: project
 usedGoVersion: "1.21.0"
 usedNodeVersion: "18.0.0"
 releaseCurrentWindow: Q1 2025
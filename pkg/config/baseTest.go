@@ -1,7 +1,9 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/it-timo/goboot/pkg/goboottypes"
@@ -10,7 +12,11 @@ import (
 // BaseTestConfig defines the metadata used by goboot to generate testing setup for a project.
 // It injects values into templates (e.g., .golangci.yml) and governs how project-specific testing is rendered.
 type BaseTestConfig struct {
-	// SourcePath is the path to the template source directory (e.g., "./templates/test_base").
+	// Version is the version of the config format (see ConfigVersion); older configs are migrated when read.
+	Version int `yaml:"version"`
+
+	// SourcePath is the path to the template source directory (e.g., "./templates/test_base"),
+	// or an embedded template set (e.g., "builtin:test_base"). Defaults to the embedded set if empty.
 	SourcePath string `yaml:"sourcePath"`
 
 	// UseStyle is the style to be used for testing.
@@ -32,10 +38,25 @@ type BaseTestConfig struct {
 
 	// LowerProjectName is the lowercase variant (e.g., "goboot").
 	LowerProjectName string `yaml:"-"`
+
+	// PascalProjectName is the PascalCase variant (e.g., "GoBoot" for "goBoot"), used in Go identifiers.
+	PascalProjectName string `yaml:"-"`
+
+	// SnakeProjectName is the snake_case variant (e.g., "go_boot" for "goBoot").
+	SnakeProjectName string `yaml:"-"`
+
+	// KebabProjectName is the kebab-case variant (e.g., "go-boot" for "goBoot").
+	KebabProjectName string `yaml:"-"`
+
+	// warn receives the warnings of decoding and validating the config; nil drops them (see GoBoot.Warnings).
+	warn warnFunc
 }
 
-// newBaseTestConfig returns a newly initialized BaseTestConfig with the project name.
-func newBaseTestConfig(projectName string) *BaseTestConfig {
+// baseTestKeys are the keys of the base test config (see BaseTestConfig).
+var baseTestKeys = newConfigKeys("version", "sourcePath", "useStyle", "testCmd")
+
+// NewBaseTestConfig returns a newly initialized BaseTestConfig with the project name.
+func NewBaseTestConfig(projectName string) *BaseTestConfig {
 	return &BaseTestConfig{
 		ProjectName: projectName,
 	}
@@ -46,26 +67,60 @@ func (bt *BaseTestConfig) ID() string {
 	return goboottypes.ServiceNameBaseTest
 }
 
+// configKeys returns the keys the YAML mapping of this config may contain.
+func (bt *BaseTestConfig) configKeys() *configKeys {
+	return baseTestKeys
+}
+
+// stampVersion sets the version of a config built in code, which has none, to ConfigVersion.
+func (bt *BaseTestConfig) stampVersion() {
+	if bt.Version == 0 {
+		bt.Version = ConfigVersion
+	}
+}
+
+// setWarn sets the receiver of the warnings of decoding and validating the config.
+func (bt *BaseTestConfig) setWarn(warn warnFunc) {
+	bt.warn = warn
+}
+
+// Role returns RoleMain, as the test setup is added to the bootstrapped project.
+func (bt *BaseTestConfig) Role() Role {
+	return RoleMain
+}
+
 // ReadConfig loads the base test configuration from the provided YAML file path.
 //
 // It overwrites the current config values with the file contents.
 func (bt *BaseTestConfig) ReadConfig(confPath string, repoURL string) error {
-	bt.RepoImportPath = strings.TrimPrefix(repoURL, "https://")
-	bt.RepoImportPath = strings.TrimPrefix(bt.RepoImportPath, "http://")
+	data, err := readYMLFile(confPath)
+	if err != nil {
+		return err
+	}
+
+	return withFile(bt.DecodeConfig(data, repoURL), confPath)
+}
+
+// DecodeConfig loads the base test configuration from YAML data.
+//
+// It overwrites the current config values with the decoded values.
+func (bt *BaseTestConfig) DecodeConfig(data []byte, repoURL string) error {
+	bt.RepoImportPath = repoPath(repoURL)
 
-	return readYMLConfig(confPath, bt)
+	return decodeYMLConfig(data, bt, bt.warn)
 }
 
 // Validate verifies the BaseTestConfig for use in scaffolding.
 //
 // It returns an error if required values are missing/invalid, or calls fillNeededInfos.
 func (bt *BaseTestConfig) Validate() error {
-	var missing []string
-
-	if strings.TrimSpace(bt.SourcePath) == "" {
-		missing = append(missing, "sourcePath")
+	sourcePath, sourceErr := resolveSourcePath(bt.SourcePath, goboottypes.TemplateSetTestBase)
+	if sourceErr == nil {
+		bt.SourcePath = sourcePath
 	}
 
+	var missing []string
+
 	if strings.TrimSpace(bt.ProjectName) == "" {
 		missing = append(missing, "projectName")
 	}
@@ -78,8 +133,9 @@ func (bt *BaseTestConfig) Validate() error {
 		missing = append(missing, "useStyle")
 	}
 
-	if len(missing) > 0 {
-		return fmt.Errorf("missing required config fields: %s", strings.Join(missing, ", "))
+	err := errors.Join(fieldErr("sourcePath", sourceErr), missingFields(missing))
+	if err != nil {
+		return err
 	}
 
 	bt.fillNeededInfos()
@@ -92,6 +148,11 @@ func (bt *BaseTestConfig) fillNeededInfos() {
 	bt.CapsProjectName = strings.ToUpper(bt.ProjectName)
 	bt.LowerProjectName = strings.ToLower(bt.ProjectName)
 
+	words := projectNameWords(bt.ProjectName)
+	bt.PascalProjectName = pascalCase(words)
+	bt.SnakeProjectName = joinLower(words, "_")
+	bt.KebabProjectName = joinLower(words, "-")
+
 	if strings.TrimSpace(bt.TestCMD) == "" {
 		bt.TestCMD = goboottypes.DefaultGoTestCMD
 	}
@@ -99,9 +160,8 @@ func (bt *BaseTestConfig) fillNeededInfos() {
 
 // validateValues validates the values in the config.
 func (bt *BaseTestConfig) validateValues() error {
-	if strings.TrimSpace(bt.UseStyle) != goboottypes.TestStyleGinkgo &&
-		strings.TrimSpace(bt.UseStyle) != goboottypes.TestStyleGo {
-		return fmt.Errorf("useStyle must be '%s' or '%s'", goboottypes.TestStyleGinkgo, goboottypes.TestStyleGo)
+	if !slices.Contains(goboottypes.TestStyles(), strings.TrimSpace(bt.UseStyle)) {
+		return fieldErr("useStyle", fmt.Errorf("must be '%s' or '%s'", goboottypes.TestStyleGinkgo, goboottypes.TestStyleGo))
 	}
 
 	return nil
@@ -73,11 +73,10 @@ var _ = Describe("BaseTestConfig", func() {
 		})
 
 		Context("with missing required fields", func() {
-			It("errors when sourcePath is missing", func() {
+			It("falls back to the builtin templates when sourcePath is missing", func() {
 				baseTest.SourcePath = ""
-				err := baseTest.Validate()
-				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(ContainSubstring("sourcePath"))
+				Expect(baseTest.Validate()).To(Succeed())
+				Expect(baseTest.SourcePath).To(Equal(goboottypes.BuiltinPrefix + goboottypes.TemplateSetTestBase))
 			})
 
 			It("errors when projectName is missing", func() {
@@ -102,12 +101,10 @@ var _ = Describe("BaseTestConfig", func() {
 			})
 
 			It("errors when multiple fields are missing", func() {
-				baseTest.SourcePath = ""
 				baseTest.ProjectName = ""
 				baseTest.UseStyle = ""
 				err := baseTest.Validate()
 				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(ContainSubstring("sourcePath"))
 				Expect(err.Error()).To(ContainSubstring("projectName"))
 				Expect(err.Error()).To(ContainSubstring("useStyle"))
 			})
@@ -116,9 +113,8 @@ var _ = Describe("BaseTestConfig", func() {
 		Context("with whitespace-only values", func() {
 			It("treats whitespace-only sourcePath as missing", func() {
 				baseTest.SourcePath = "    "
-				err := baseTest.Validate()
-				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(ContainSubstring("sourcePath"))
+				Expect(baseTest.Validate()).To(Succeed())
+				Expect(baseTest.SourcePath).To(Equal(goboottypes.BuiltinPrefix + goboottypes.TemplateSetTestBase))
 			})
 
 			It("treats whitespace-only projectName as missing", func() {
@@ -148,7 +144,7 @@ var _ = Describe("BaseTestConfig", func() {
 				baseTest.UseStyle = "pytest"
 				err := baseTest.Validate()
 				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(ContainSubstring("useStyle must be"))
+				Expect(err.Error()).To(ContainSubstring("useStyle: must be"))
 				Expect(err.Error()).To(ContainSubstring(goboottypes.TestStyleGinkgo))
 				Expect(err.Error()).To(ContainSubstring(goboottypes.TestStyleGo))
 			})
@@ -5,8 +5,13 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+
+	"github.com/it-timo/goboot/pkg/goboot"
 )
 
+// factories are the config factories of the built-in services, which the configs under test resolve services through.
+var factories = goboot.ServiceConfigFactories()
+
 func TestConfig(t *testing.T) {
 	t.Parallel()
 	RegisterFailHandler(Fail)
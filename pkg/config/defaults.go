@@ -0,0 +1,38 @@
+package config
+
+import (
+	_ "embed" // embeds the built-in default configs.
+	"slices"
+)
+
+// The built-in default configs of the services, used if a service declares neither confPath nor config.
+var (
+	//go:embed defaults/base_project.yml
+	baseProjectDefaults []byte
+	//go:embed defaults/base_lint.yml
+	baseLintDefaults []byte
+	//go:embed defaults/base_test.yml
+	baseTestDefaults []byte
+	//go:embed defaults/base_local.yml
+	baseLocalDefaults []byte
+)
+
+// BaseProjectDefaults returns the built-in default config of BaseProjectConfig.
+func BaseProjectDefaults() []byte {
+	return slices.Clone(baseProjectDefaults)
+}
+
+// BaseLintDefaults returns the built-in default config of BaseLintConfig.
+func BaseLintDefaults() []byte {
+	return slices.Clone(baseLintDefaults)
+}
+
+// BaseTestDefaults returns the built-in default config of BaseTestConfig.
+func BaseTestDefaults() []byte {
+	return slices.Clone(baseTestDefaults)
+}
+
+// BaseLocalDefaults returns the built-in default config of BaseLocalConfig.
+func BaseLocalDefaults() []byte {
+	return slices.Clone(baseLocalDefaults)
+}
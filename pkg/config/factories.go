@@ -0,0 +1,67 @@
+package config
+
+import "fmt"
+
+// ServiceConfigFactory describes the config of a built-in service: how it is created, defaulted, and checked.
+type ServiceConfigFactory struct {
+	// ID is the ID of the service (e.g., goboottypes.ServiceNameBaseLint).
+	ID string
+
+	// NewConfig creates an empty config of the service for the given project name.
+	NewConfig func(projectName string) ServiceConfig
+
+	// Defaults is the built-in default config of the service (e.g., BaseLintDefaults).
+	Defaults []byte
+
+	// Schema returns the JSON Schema of the config, without the fields shared by all service configs.
+	Schema func() *Schema
+}
+
+// ServiceConfigFactories are the config factories of the built-in services, in the order of their service table.
+//
+// The config package cannot import the services, so it does not own the table: the caller passes it in
+// (see goboot.ServiceConfigFactories), and only configs listed in it can be used during runtime.
+type ServiceConfigFactories []ServiceConfigFactory
+
+// factory returns the config factory of the given service.
+//
+// The second return value indicates whether the service is known.
+func (factories ServiceConfigFactories) factory(id string) (ServiceConfigFactory, bool) {
+	for _, factory := range factories {
+		if factory.ID == id {
+			return factory, true
+		}
+	}
+
+	return ServiceConfigFactory{}, false
+}
+
+// newConfig returns an empty config of the given service, or nil for unknown services.
+func (factories ServiceConfigFactories) newConfig(id, projectName string) ServiceConfig {
+	factory, ok := factories.factory(id)
+	if !ok {
+		return nil
+	}
+
+	return factory.NewConfig(projectName)
+}
+
+// keys returns the keys of the config of the given service, or nil for unknown services.
+func (factories ServiceConfigFactories) keys(id string) *configKeys {
+	cfg := factories.newConfig(id, "")
+	if cfg == nil {
+		return nil
+	}
+
+	return serviceKeys(cfg)
+}
+
+// defaults returns the built-in default config of the given service.
+func (factories ServiceConfigFactories) defaults(id string) ([]byte, error) {
+	factory, ok := factories.factory(id)
+	if !ok || factory.Defaults == nil {
+		return nil, fmt.Errorf("no built-in default config for %q", id)
+	}
+
+	return factory.Defaults, nil
+}
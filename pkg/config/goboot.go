@@ -19,6 +19,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/it-timo/goboot/pkg/goboottypes"
@@ -35,9 +36,33 @@ import (
 //   - A list of modular config declarations (ServiceConfigMeta)
 //   - A central config manager (ConfManager) to register and resolve modules
 type GoBoot struct {
+	// factories are the config factories of the built-in services (see ServiceConfigFactories).
+	factories ServiceConfigFactories
+
 	// configPath is the path to the main goboot YAML config file (e.g., ./configs/goboot.yml).
 	configPath string
 
+	// data is the goboot config given as YAML data instead of the file at configPath (see DecodeConfig).
+	data []byte
+
+	// overlays are further goboot config files merged on top of configPath, in order (see mergeLayer).
+	overlays []string
+
+	// overrides are single values set after merging all config files (see SetOverrides).
+	overrides []override
+
+	// configSources are the files of the service configs inlined while merging the config files, by service ID.
+	configSources map[string]string
+
+	// log receives the progress messages and warnings of loading the config (see SetLogger); nil logs to stdout.
+	log goboottypes.Logger
+
+	// warnings are the warnings of loading the config, in order (see Warnings).
+	warnings []string
+
+	// Version is the version of the config format (see ConfigVersion); older configs are migrated when read.
+	Version int `yaml:"version"`
+
 	// ProjectName is the identifier for the project (e.g., "goboot").
 	// Used in headings, comments, and other rendered metadata.
 	ProjectName string `yaml:"projectName"`
@@ -49,77 +74,409 @@ type GoBoot struct {
 	// TargetPath is the path to the project target / output.
 	TargetPath string `yaml:"targetPath"`
 
+	// ConflictPolicy decides what happens to files that already exist in the target project
+	// (e.g., "fail", "skip-existing", "overwrite", "backup"). Defaults to "fail".
+	ConflictPolicy string `yaml:"conflictPolicy"`
+
+	// Profile selects a built-in bundle of services, service configs, and template overlays
+	// (e.g., "minimal", "oss"), which the config files are merged onto (see profileLayer).
+	Profile string `yaml:"profile"`
+
 	// Services is a list of external service config declarations to load (e.g., base_project, linting).
 	Services []ServiceConfigMeta `yaml:"services"`
 
 	// ConfManager holds validated and registered configuration modules.
 	//
 	// It provides access to modular service configs during generation.
-	ConfManager *Manager
+	ConfManager *Manager `yaml:"-"`
 }
 
-// NewGoBoot creates a new GoBoot instance with the given base configuration path.
+// gobootKeys are the keys of the goboot config (see GoBoot).
 //
-// It initializes an empty ConfManager for later population.
-func NewGoBoot(confPath string) *GoBoot {
+// The keys of inline service configs are checked per service (see ServiceConfigFactories).
+var gobootKeys = newConfigKeys("version", "projectName", "repoUrl", "targetPath", "conflictPolicy", "profile").
+	with("services", newConfigKeys("id", "confPath", "config", "enabled", "plugin"))
+
+// NewGoBoot creates a new GoBoot instance for the built-in services of factories with the given base configuration
+// path, optionally followed by overlay configs deep-merged on top of it in order (see mergeLayer).
+//
+// It initializes an empty ConfManager for later population, and the Version of configs built in code.
+func NewGoBoot(factories ServiceConfigFactories, confPath string, overlays ...string) *GoBoot {
 	return &GoBoot{
+		factories:   factories,
 		configPath:  confPath,
+		overlays:    overlays,
+		Version:     ConfigVersion,
 		ConfManager: NewConfigManager(),
 	}
 }
 
+// SetLogger sets the logger for the progress messages and warnings of loading the config (stdout by default).
+//
+// It must be called before Init.
+func (gb *GoBoot) SetLogger(logger goboottypes.Logger) {
+	gb.log = logger
+}
+
+// Warnings returns the warnings of loading the config (e.g., configs migrated in memory), in order.
+func (gb *GoBoot) Warnings() []string {
+	return gb.warnings
+}
+
+// warnFunc receives a warning in the manner of fmt.Printf (see GoBoot.warn).
+type warnFunc func(format string, args ...any)
+
+// warnReceiver is implemented by service configs reporting warnings while decoding and validating
+// (e.g., configs migrated in memory).
+type warnReceiver interface {
+	setWarn(warn warnFunc)
+}
+
+// setWarn makes the given service config report its warnings to the GoBoot, if it reports any.
+func (gb *GoBoot) setWarn(cfg ServiceConfig) {
+	receiver, ok := cfg.(warnReceiver)
+	if ok {
+		receiver.setWarn(gb.warn)
+	}
+}
+
+// warn records a warning of loading the config and logs it.
+func (gb *GoBoot) warn(format string, args ...any) {
+	msg := fmt.Sprintf(format, args...)
+	gb.warnings = append(gb.warnings, msg)
+
+	gb.logger().Printf("[WARN] %s\n", msg)
+}
+
+// logger returns the logger set with SetLogger, or a logger printing to stdout.
+func (gb *GoBoot) logger() goboottypes.Logger {
+	if gb.log == nil {
+		return goboottypes.NewStdoutLogger()
+	}
+
+	return gb.log
+}
+
 // Init loads and validates the goboot base configuration and all declared service modules.
 //
 // It performs the following:
 //   - Reads and parses the main goboot YAML config
 //   - Iterates over declared services and loads their configs via factory
 //   - Validates and registers each service config with the ConfManager
+//
+// The errors of all enabled service configs are returned together as a ValidationError.
 func (gb *GoBoot) Init() error {
 	err := gb.readConfig()
 	if err != nil {
 		return fmt.Errorf("failed to read goboot config: %w", err)
 	}
 
-	err = gb.validateBase()
+	err = gb.Validate()
 	if err != nil {
-		return fmt.Errorf("invalid goboot config: %w", err)
+		return err
 	}
 
+	var errs []*FieldError
+
 	for _, svc := range gb.Services {
 		if !svc.IsEnabled() {
 			continue
 		}
 
-		fmt.Printf("loading service config for %q\n", svc.ID)
+		gb.logger().Printf("loading service config for %q\n", svc.ID)
+
+		err = gb.loadService(svc)
+		if err != nil {
+			errs = append(errs, serviceErrors(svc.ID, gb.serviceSource(svc), err)...)
+		}
+	}
+
+	if len(errs) > 0 {
+		return &ValidationError{Errors: errs}
+	}
+
+	return nil
+}
+
+// DecodeConfig is Init for a goboot config given as YAML data (e.g., built in memory) instead of the config file.
+//
+// Overlays and overrides are merged on top of it as usual. Service configs are taken from the inline configs,
+// the built-in defaults, or confPath files relative to the working directory. The path passed to NewGoBoot,
+// if any, only names the config in error messages.
+func (gb *GoBoot) DecodeConfig(data []byte) error {
+	gb.data = data
+
+	return gb.Init()
+}
+
+// serviceSource describes where the config of a declared service was taken from, for error messages.
+func (gb *GoBoot) serviceSource(svc ServiceConfigMeta) string {
+	file, inlined := gb.configSources[svc.ID]
+	if inlined {
+		return file
+	}
+
+	return svc.source()
+}
+
+// loadService decodes, validates, and registers the config of a declared service.
+func (gb *GoBoot) loadService(svc ServiceConfigMeta) error {
+	cfg := svc.newConfig(gb.factories, gb.ProjectName)
+	if cfg == nil {
+		return fmt.Errorf("invalid or nil config returned for service ID: %q", svc.ID)
+	}
+
+	gb.setWarn(cfg)
+
+	data, err := svc.ConfigData(gb.factories)
+	if err != nil {
+		return err
+	}
+
+	err = withFile(cfg.DecodeConfig(data, gb.RepoURL), svc.ConfPath)
+	if err != nil {
+		return err
+	}
+
+	return gb.ConfManager.Register(cfg)
+}
+
+// Validate verifies the goboot base configuration (e.g., after building it in code instead of reading it).
+//
+// It checks the required fields and the declared services, and normalizes the conflict policy.
+func (gb *GoBoot) Validate() error {
+	err := gb.validateBase()
+	if err != nil {
+		return fmt.Errorf("invalid goboot config: %w", err)
+	}
+
+	err = gb.validatePlugins()
+	if err != nil {
+		return fmt.Errorf("invalid goboot config: %w", err)
+	}
+
+	err = gb.SetConflictPolicy(gb.ConflictPolicy)
+	if err != nil {
+		return fmt.Errorf("invalid goboot config: %w", err)
+	}
+
+	return nil
+}
+
+// LoadServiceConfig decodes, validates, and registers the config of a single service from YAML data
+// (e.g., a config recorded in a lock file) and declares the service as enabled.
+//
+// ProjectName and RepoURL must be set before, as they are injected into the service config.
+func (gb *GoBoot) LoadServiceConfig(id string, data []byte) error {
+	cfg := gb.factories.newConfig(id, gb.ProjectName)
+	if cfg == nil {
+		return fmt.Errorf("invalid or nil config returned for service ID: %q", id)
+	}
+
+	gb.setWarn(cfg)
+
+	err := cfg.DecodeConfig(data, gb.RepoURL)
+	if err != nil {
+		return fmt.Errorf("failed to decode config for %q: %w", id, err)
+	}
+
+	return gb.RegisterServiceConfig(cfg)
+}
+
+// LoadPluginConfig is LoadServiceConfig for a service implemented by the given plugin executable
+// (see ServiceConfigMeta.Plugin).
+func (gb *GoBoot) LoadPluginConfig(id, plugin string, data []byte) error {
+	cfg := newPluginConfig(id, plugin, gb.ProjectName)
+
+	err := cfg.DecodeConfig(data, gb.RepoURL)
+	if err != nil {
+		return fmt.Errorf("failed to decode config for %q: %w", id, err)
+	}
+
+	return gb.RegisterServiceConfig(cfg)
+}
+
+// RegisterServiceConfig validates and registers a service config and declares the service as enabled.
+// Registering a service again replaces its config and declaration, keeping its position.
+//
+// It is meant for configs built in code (see NewServiceConfig).
+func (gb *GoBoot) RegisterServiceConfig(cfg ServiceConfig) error {
+	err := gb.ConfManager.Register(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to register config for %q: %w", cfg.ID(), err)
+	}
+
+	meta := ServiceConfigMeta{ID: cfg.ID(), Enabled: true}
+	if plugin, ok := cfg.(*PluginConfig); ok {
+		meta.Plugin = plugin.Plugin
+	}
+
+	i := slices.IndexFunc(gb.Services, func(svc ServiceConfigMeta) bool { return svc.ID == meta.ID })
+	if i >= 0 {
+		gb.Services[i] = meta
+
+		return nil
+	}
+
+	gb.Services = append(gb.Services, meta)
+
+	return nil
+}
+
+// NewServiceConfig returns the config of the given service, decoded from its built-in defaults.
+// ProjectName and RepoURL must be set before, as they are injected into the service config.
+//
+// The config is not validated yet; adjust it and pass it to RegisterServiceConfig.
+func (gb *GoBoot) NewServiceConfig(id string) (ServiceConfig, error) {
+	cfg := gb.factories.newConfig(id, gb.ProjectName)
+	if cfg == nil {
+		return nil, fmt.Errorf("invalid or nil config returned for service ID: %q", id)
+	}
+
+	data, err := gb.factories.defaults(id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = cfg.DecodeConfig(data, gb.RepoURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode default config for %q: %w", id, err)
+	}
+
+	return cfg, nil
+}
+
+// readConfig reads the goboot base configuration from its YAML path (or data, see DecodeConfig),
+// merges the selected profile, overlays, and overrides with it, and unmarshal the values
+// into the current GoBoot struct instance.
+//
+// Each file is interpolated and checked for unknown keys on its own (see readLayer),
+// while its line numbers still match the file.
+func (gb *GoBoot) readConfig() error {
+	paths := append([]string{gb.configPath}, gb.overlays...)
+	layers := make([]*yaml.Node, 0, len(paths))
+
+	for i, path := range paths {
+		layer, err := gb.readLayer(i, path)
+		if err != nil {
+			return err
+		}
+
+		layers = append(layers, layer)
+	}
+
+	merged, err := gb.mergeLayers(paths, layers)
+	if err != nil {
+		return err
+	}
+
+	for _, o := range gb.overrides {
+		err = o.apply(merged, gb.factories)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = merged.Decode(gb)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+
+	return nil
+}
+
+// readLayer reads the config layer at the given index and path; the first layer is the config data, if given.
+func (gb *GoBoot) readLayer(index int, path string) (*yaml.Node, error) {
+	if index > 0 || gb.data == nil {
+		return gb.factories.readLayer(path, gb.warn)
+	}
+
+	return gb.factories.parseLayer(gb.data, path, gb.warn)
+}
+
+// mergeLayers merges the config layers read from paths onto the selected profile, if any (see mergeLayer).
+//
+// A single layer without profile and overrides is returned as is.
+func (gb *GoBoot) mergeLayers(paths []string, layers []*yaml.Node) (*yaml.Node, error) {
+	profile := selectedProfile(layers, gb.overrides)
+	if profile == "" && len(layers) == 1 && len(gb.overrides) == 0 {
+		return layers[0], nil
+	}
+
+	gb.configSources = make(map[string]string)
 
-		cfg := createServiceConfig(svc.ID, gb.ProjectName)
-		if cfg == nil {
-			return fmt.Errorf("invalid or nil config returned for service ID: %q", svc.ID)
+	for _, layer := range layers {
+		err := gb.factories.inlineConfigFiles(layer, gb.configSources, gb.warn)
+		if err != nil {
+			return nil, err
 		}
+	}
+
+	merged, rest, restPaths := layers[0], layers[1:], paths[1:]
 
-		err = cfg.ReadConfig(svc.ConfPath, gb.RepoURL)
+	if profile != "" {
+		profileRoot, err := gb.factories.profileLayer(profile)
 		if err != nil {
-			return fmt.Errorf("failed to read config for %q: %w", svc.ID, err)
+			return nil, err
 		}
 
-		err = gb.ConfManager.Register(cfg)
+		merged, rest, restPaths = profileRoot, layers, paths
+	}
+
+	for i, layer := range rest {
+		err := gb.factories.mergeLayer(merged, layer)
 		if err != nil {
-			return fmt.Errorf("failed to register config for %q: %w", svc.ID, err)
+			return nil, fmt.Errorf("failed to merge %s: %w", restPaths[i], err)
 		}
 	}
 
+	return merged, nil
+}
+
+// SetConflictPolicy validates and sets the conflict policy, e.g., to apply a CLI override after Init.
+//
+// An empty policy falls back to goboottypes.DefaultConflictPolicy.
+func (gb *GoBoot) SetConflictPolicy(policy string) error {
+	policy = strings.TrimSpace(policy)
+	if policy == "" {
+		policy = goboottypes.DefaultConflictPolicy
+	}
+
+	if !slices.Contains(goboottypes.ConflictPolicies(), policy) {
+		return fmt.Errorf("invalid conflictPolicy %q (must be one of: %s)",
+			policy, strings.Join(goboottypes.ConflictPolicies(), ", "))
+	}
+
+	gb.ConflictPolicy = policy
+
 	return nil
 }
 
-// readConfig reads the goboot base configuration from its YAML path
-// and unmarshal the values into the current GoBoot struct instance.
-func (gb *GoBoot) readConfig() error {
-	return readYMLConfig(gb.configPath, gb)
+// String summarizes the loaded config: the project, the target path, the conflict policy, and the services.
+//
+// It leaves out the service configs and the factory table (e.g., the built-in defaults), which are too long to log.
+func (gb *GoBoot) String() string {
+	services := make([]string, 0, len(gb.Services))
+
+	for _, svc := range gb.Services {
+		switch {
+		case !svc.IsEnabled():
+			services = append(services, svc.ID+" (disabled)")
+		case svc.IsPlugin():
+			services = append(services, fmt.Sprintf("%s (plugin %s)", svc.ID, svc.Plugin))
+		default:
+			services = append(services, svc.ID)
+		}
+	}
+
+	return fmt.Sprintf("project %q (%s) in %q, conflict policy %q, services: %s",
+		gb.ProjectName, gb.RepoURL, gb.TargetPath, gb.ConflictPolicy, strings.Join(services, ", "))
 }
 
-// validateBase checks the top-level goboot config for required fields and enabled service paths.
+// validateBase checks the top-level goboot config for required fields and enabled service config sources.
 //
-// It ensures projectName, targetPath, and each enabled service's confPath are present.
+// It ensures projectName and targetPath are present, that no enabled service sets both a confPath
+// and an inline config, and that the profile is known.
 //
 //nolint:cyclop // flat logic preferred for clarity and extensibility.
 func (gb *GoBoot) validateBase() error {
@@ -133,7 +490,10 @@ func (gb *GoBoot) validateBase() error {
 		missing = append(missing, "targetPath")
 	}
 
-	var importPathMissing bool
+	var (
+		importPathMissing bool
+		ambiguous         []string
+	)
 
 	for _, svc := range gb.Services {
 		if !svc.IsEnabled() {
@@ -155,8 +515,8 @@ func (gb *GoBoot) validateBase() error {
 			}
 		}
 
-		if strings.TrimSpace(svc.ConfPath) == "" {
-			missing = append(missing, fmt.Sprintf("services[%s].confPath", svc.ID))
+		if strings.TrimSpace(svc.ConfPath) != "" && svc.HasInlineConfig() {
+			ambiguous = append(ambiguous, svc.ID)
 		}
 	}
 
@@ -164,50 +524,145 @@ func (gb *GoBoot) validateBase() error {
 		return fmt.Errorf("missing required fields: %s", strings.Join(missing, ", "))
 	}
 
+	if len(ambiguous) > 0 {
+		return fmt.Errorf("services must set either confPath or config, not both: %s", strings.Join(ambiguous, ", "))
+	}
+
+	err := ValidateProjectName(gb.ProjectName)
+	if err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(gb.RepoURL) != "" {
+		err = ValidateRepoURL(gb.RepoURL)
+		if err != nil {
+			return err
+		}
+	}
+
+	if gb.Profile != "" && !slices.Contains(goboottypes.Profiles(), gb.Profile) {
+		return fmt.Errorf("unknown profile %q (must be one of: %s)", gb.Profile, strings.Join(goboottypes.Profiles(), ", "))
+	}
+
 	return nil
 }
 
-// createServiceConfig acts as the central mapping point for service config IDs.
-//
-// Each known ServiceConfig type must be registered here explicitly.
-//
-// This maps string identifiers (e.g., "base_project") to their concrete implementations.
-//
-// Only configs listed here can be used during runtime.
-func createServiceConfig(id, projectName string) ServiceConfig {
-	switch id {
-	case goboottypes.ServiceNameBaseProject:
-		return newBaseProjectConfig(projectName)
-	case goboottypes.ServiceNameBaseLint:
-		return newBaseLintConfig(projectName)
-	case goboottypes.ServiceNameBaseLocal:
-		return newBaseLocalConfig(projectName)
-	case goboottypes.ServiceNameBaseTest:
-		return newBaseTestConfig(projectName)
-	// Extend with more cases for additional service types.
-	default:
-		return nil
+// validatePlugins checks that plugin services do not use the ID of a built-in service,
+// which would make it ambiguous which implementation runs.
+func (gb *GoBoot) validatePlugins() error {
+	var builtin []string
+
+	for _, svc := range gb.Services {
+		if _, known := gb.factories.factory(svc.ID); svc.IsEnabled() && svc.IsPlugin() && known {
+			builtin = append(builtin, svc.ID)
+		}
 	}
+
+	if len(builtin) > 0 {
+		return fmt.Errorf("plugin services must not use the ID of a built-in service: %s", strings.Join(builtin, ", "))
+	}
+
+	return nil
 }
 
-// readYMLConfig reads the given YAML file path and unmarshal it into the provided destination struct.
+// resolveSourcePath returns the template source path of a service config.
 //
-// It returns an error if the file cannot be read or the YAML is malformed.
-func readYMLConfig(confPath string, cfg interface{}) error {
+// An empty sourcePath falls back to the embedded templateSet (e.g., "builtin:project_base").
+// A builtin sourcePath must name one of goboottypes.TemplateSets.
+func resolveSourcePath(sourcePath, templateSet string) (string, error) {
+	sourcePath = strings.TrimSpace(sourcePath)
+	if sourcePath == "" {
+		return goboottypes.BuiltinPrefix + templateSet, nil
+	}
+
+	name, builtin := strings.CutPrefix(sourcePath, goboottypes.BuiltinPrefix)
+	if builtin && !slices.Contains(goboottypes.TemplateSets(), name) {
+		return "", fmt.Errorf("invalid sourcePath %q (builtin template sets: %s)",
+			sourcePath, strings.Join(goboottypes.TemplateSets(), ", "))
+	}
+
+	return sourcePath, nil
+}
+
+// readYMLFile reads the raw content of the given YAML file path.
+func readYMLFile(confPath string) ([]byte, error) {
 	curPath, err := filepath.Abs(path.Clean(confPath))
 	if err != nil {
-		return fmt.Errorf("failed to resolve config path: %w", err)
+		return nil, fmt.Errorf("failed to resolve config path: %w", err)
 	}
 
 	data, err := os.ReadFile(curPath) // #nosec G304 -- the path is user-defined and expected to be dynamic.
 	if err != nil {
-		return fmt.Errorf("failed to read config file: %w", err)
+		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
-	err = yaml.Unmarshal(data, cfg)
+	return data, nil
+}
+
+// decodeYMLConfig unmarshal the given YAML data of a service config into the provided destination struct
+// (see parseYMLConfig), checking the keys against the keys it declares.
+func decodeYMLConfig(data []byte, cfg ServiceConfig, warn warnFunc) error {
+	node, err := parseYMLConfig(data, cfg.ID(), serviceKeys(cfg), warn)
+	if err != nil {
+		return err
+	}
+
+	err = node.Decode(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to unmarshal config: %w", err)
 	}
 
 	return nil
 }
+
+// parseYMLConfig parses the given YAML data of a config of the given kind (SchemaGoBoot or a service ID)
+// into the node of its top-level value, which is an empty mapping for empty documents.
+//
+// Environment variables in the values are expanded first if the config opts in
+// (see interpolate and interpolateLayer), so validation sees the expanded values.
+// Configs of older versions are migrated to ConfigVersion with a warning to warn, if any (see migrateConfig).
+// Keys not declared in keys are rejected with an UnknownKeysError (without a file; see withFile);
+// nil keys (a config declaring none) accept every key.
+func parseYMLConfig(data []byte, kind string, keys *configKeys, warn warnFunc) (*yaml.Node, error) {
+	var doc yaml.Node
+
+	err := yaml.Unmarshal(data, &doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+
+	if kind == SchemaGoBoot {
+		err = interpolateLayer(&doc)
+	} else {
+		err = interpolate(&doc)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	root := documentRoot(&doc)
+	if root == nil {
+		root = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	}
+
+	from, err := migrateConfig(root, kind)
+	if err != nil {
+		return nil, err
+	}
+
+	if from < ConfigVersion {
+		warnMigrated(warn, kind, from)
+	}
+
+	if keys == nil {
+		return root, nil
+	}
+
+	unknown := unknownKeys(root, keys, "")
+	if len(unknown) > 0 {
+		return nil, &UnknownKeysError{Keys: unknown}
+	}
+
+	return root, nil
+}
@@ -34,31 +34,177 @@ var _ = Describe("GoBoot Configuration Orchestrator", func() {
 
 	Describe("NewGoBoot", func() {
 		It("creates a new GoBoot instance", func() {
-			gb := config.NewGoBoot(configPath)
+			gb := config.NewGoBoot(factories, configPath)
 			Expect(gb).NotTo(BeNil())
 		})
 
 		It("initializes the ConfManager", func() {
-			gb := config.NewGoBoot(configPath)
+			gb := config.NewGoBoot(factories, configPath)
 			Expect(gb.ConfManager).NotTo(BeNil())
 		})
 
 		It("reads from the provided config path during init", func() {
-			yamlContent := `projectName: from-custom-path
+			yamlContent := `projectName: fromCustomPath
 targetPath: /tmp/from-custom
 services: []
 `
 			err := os.WriteFile(configPath, []byte(yamlContent), 0644)
 			Expect(err).NotTo(HaveOccurred())
 
-			gb := config.NewGoBoot(configPath)
+			gb := config.NewGoBoot(factories, configPath)
 			err = gb.Init()
 			Expect(err).NotTo(HaveOccurred())
-			Expect(gb.ProjectName).To(Equal("from-custom-path"))
+			Expect(gb.ProjectName).To(Equal("fromCustomPath"))
 			Expect(gb.TargetPath).To(Equal("/tmp/from-custom"))
 		})
 	})
 
+	Describe("SetConflictPolicy", func() {
+		It("accepts every supported policy", func() {
+			goBoot = config.NewGoBoot(factories, configPath)
+
+			for _, policy := range goboottypes.ConflictPolicies() {
+				Expect(goBoot.SetConflictPolicy(policy)).To(Succeed())
+				Expect(goBoot.ConflictPolicy).To(Equal(policy))
+			}
+		})
+
+		It("rejects unknown policies and keeps the current one", func() {
+			goBoot = config.NewGoBoot(factories, configPath)
+			Expect(goBoot.SetConflictPolicy(goboottypes.ConflictPolicyBackup)).To(Succeed())
+
+			Expect(goBoot.SetConflictPolicy("merge")).NotTo(Succeed())
+			Expect(goBoot.ConflictPolicy).To(Equal(goboottypes.ConflictPolicyBackup))
+		})
+	})
+
+	Describe("String", func() {
+		It("summarizes the project and its services without the service configs", func() {
+			goBoot = config.NewGoBoot(factories, configPath)
+			goBoot.ProjectName, goBoot.RepoURL, goBoot.TargetPath = "mytool", "github.com/acme/mytool", "out"
+			goBoot.ConflictPolicy = goboottypes.ConflictPolicyFail
+			goBoot.Services = []config.ServiceConfigMeta{
+				{ID: goboottypes.ServiceNameBaseProject, Enabled: true},
+				{ID: goboottypes.ServiceNameBaseLint},
+				{ID: "acme_license", Enabled: true, Plugin: "./bin/goboot-acme"},
+			}
+
+			Expect(goBoot.String()).To(Equal(`project "mytool" (github.com/acme/mytool) in "out", ` +
+				`conflict policy "fail", services: base_project, base_lint (disabled), acme_license (plugin ./bin/goboot-acme)`))
+		})
+	})
+
+	Describe("LoadServiceConfig", func() {
+		BeforeEach(func() {
+			goBoot = config.NewGoBoot(factories, configPath)
+			goBoot.ProjectName = "recorded"
+			goBoot.RepoURL = "https://github.com/example/recorded"
+		})
+
+		It("decodes, validates, and registers the config as an enabled service", func() {
+			Expect(goBoot.LoadServiceConfig(goboottypes.ServiceNameBaseTest,
+				[]byte("sourcePath: ./templates/test_base\nuseStyle: go\n"))).To(Succeed())
+
+			Expect(goBoot.Services).To(Equal([]config.ServiceConfigMeta{
+				{ID: goboottypes.ServiceNameBaseTest, Enabled: true},
+			}))
+
+			cfg, ok := goBoot.ConfManager.Get(goboottypes.ServiceNameBaseTest)
+			Expect(ok).To(BeTrue())
+
+			testCfg, ok := cfg.(*config.BaseTestConfig)
+			Expect(ok).To(BeTrue())
+			Expect(testCfg.ProjectName).To(Equal("recorded"))
+			Expect(testCfg.RepoImportPath).To(Equal("github.com/example/recorded"))
+			Expect(testCfg.UseStyle).To(Equal("go"))
+		})
+
+		It("returns error for unknown services", func() {
+			err := goBoot.LoadServiceConfig("unknown", []byte("{}"))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(`invalid or nil config returned for service ID: "unknown"`))
+		})
+
+		It("returns error for malformed or invalid configs", func() {
+			err := goBoot.LoadServiceConfig(goboottypes.ServiceNameBaseLocal, []byte("fileList: [oops"))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("failed to decode config"))
+
+			err = goBoot.LoadServiceConfig(goboottypes.ServiceNameBaseLocal, []byte("fileList: []\n"))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("failed to register config"))
+			Expect(goBoot.Services).To(BeEmpty())
+		})
+	})
+
+	Describe("DecodeConfig", func() {
+		It("loads the goboot config from YAML data", func() {
+			goBoot = config.NewGoBoot(factories, "")
+			Expect(goBoot.DecodeConfig([]byte(`version: 1
+projectName: portal
+repoUrl: github.com/acme/portal
+targetPath: /tmp/portal
+services:
+  - {id: base_test, enabled: true, config: {useStyle: go}}
+`))).To(Succeed())
+
+			Expect(goBoot.ProjectName).To(Equal("portal"))
+			Expect(goBoot.Warnings()).To(BeEmpty())
+
+			cfg, ok := goBoot.ConfManager.Get(goboottypes.ServiceNameBaseTest)
+			Expect(ok).To(BeTrue())
+			Expect(cfg.(*config.BaseTestConfig).UseStyle).To(Equal("go"))
+		})
+
+		It("names the config path in errors only if given", func() {
+			data := []byte("projectName: portal\nrepoUrl: x\ntargetPath: /tmp\nunknown: 1\n")
+
+			err := config.NewGoBoot(factories, "").DecodeConfig(data)
+			Expect(err).To(MatchError(ContainSubstring("line 4: unknown key")))
+
+			err = config.NewGoBoot(factories, "portal.yml").DecodeConfig(data)
+			Expect(err).To(MatchError(ContainSubstring("portal.yml:4: unknown key")))
+		})
+	})
+
+	Describe("NewServiceConfig", func() {
+		BeforeEach(func() {
+			goBoot = config.NewGoBoot(factories, "")
+			goBoot.ProjectName = "mytool"
+			goBoot.RepoURL = "github.com/acme/mytool"
+			goBoot.TargetPath = "."
+		})
+
+		It("decodes the built-in defaults without registering them", func() {
+			cfg, err := goBoot.NewServiceConfig(goboottypes.ServiceNameBaseTest)
+			Expect(err).NotTo(HaveOccurred())
+
+			testCfg, ok := cfg.(*config.BaseTestConfig)
+			Expect(ok).To(BeTrue())
+			Expect(testCfg.ProjectName).To(Equal("mytool"))
+			Expect(testCfg.UseStyle).To(Equal("ginkgo"))
+			Expect(goBoot.Services).To(BeEmpty())
+
+			testCfg.UseStyle = "go"
+			Expect(goBoot.RegisterServiceConfig(testCfg)).To(Succeed())
+			Expect(goBoot.Validate()).To(Succeed())
+			Expect(goBoot.Services).To(Equal([]config.ServiceConfigMeta{
+				{ID: goboottypes.ServiceNameBaseTest, Enabled: true},
+			}))
+
+			testCfg.UseStyle = "ginkgo"
+			Expect(goBoot.RegisterServiceConfig(testCfg)).To(Succeed())
+			Expect(goBoot.Validate()).To(Succeed())
+			Expect(goBoot.Services).To(HaveLen(1), "registering again replaces the declaration")
+		})
+
+		It("returns error for unknown services", func() {
+			_, err := goBoot.NewServiceConfig("unknown")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(`invalid or nil config returned for service ID: "unknown"`))
+		})
+	})
+
 	Describe("Init", func() {
 		Context("with valid configuration", func() {
 			BeforeEach(func() {
@@ -76,7 +222,6 @@ services:
 
 				// Create the service config file
 				serviceConfigContent := `sourcePath: /tmp/templates
-projectName: testproject
 usedGoVersion: "1.22.0"
 usedNodeVersion: "20.0.0"
 releaseCurrentWindow: Q1 2025
@@ -90,7 +235,7 @@ gitUser: testuser
 				err = os.WriteFile(serviceConfigPath, []byte(serviceConfigContent), 0644)
 				Expect(err).NotTo(HaveOccurred())
 
-				goBoot = config.NewGoBoot(configPath)
+				goBoot = config.NewGoBoot(factories, configPath)
 			})
 
 			It("successfully initializes and loads config", func() {
@@ -110,12 +255,17 @@ gitUser: testuser
 				Expect(goBoot.TargetPath).To(Equal("/tmp/test"))
 			})
 
+			It("defaults the conflict policy to fail", func() {
+				Expect(goBoot.Init()).To(Succeed())
+				Expect(goBoot.ConflictPolicy).To(Equal(goboottypes.ConflictPolicyFail))
+			})
+
 			It("loads and registers service configs", func() {
 				err := goBoot.Init()
 				Expect(err).NotTo(HaveOccurred())
 
 				// Verify service was registered
-				cfg, ok := goBoot.ConfManager.GetRegistrar(goboottypes.ServiceNameBaseProject)
+				cfg, ok := goBoot.ConfManager.Get(goboottypes.ServiceNameBaseProject)
 				Expect(ok).To(BeTrue())
 				Expect(cfg).NotTo(BeNil())
 			})
@@ -123,7 +273,7 @@ gitUser: testuser
 
 		Context("with invalid configuration file", func() {
 			It("returns error for non-existent config", func() {
-				goBoot = config.NewGoBoot("/nonexistent/config.yml")
+				goBoot = config.NewGoBoot(factories, "/nonexistent/config.yml")
 				err := goBoot.Init()
 				Expect(err).To(HaveOccurred())
 				Expect(err.Error()).To(ContainSubstring("failed to read goboot config"))
@@ -136,7 +286,7 @@ services: not properly formatted
 				err := os.WriteFile(configPath, []byte(invalidYAML), 0644)
 				Expect(err).NotTo(HaveOccurred())
 
-				goBoot = config.NewGoBoot(configPath)
+				goBoot = config.NewGoBoot(factories, configPath)
 				err = goBoot.Init()
 				Expect(err).To(HaveOccurred())
 			})
@@ -149,27 +299,119 @@ services: []
 				err := os.WriteFile(configPath, []byte(yamlContent), 0644)
 				Expect(err).NotTo(HaveOccurred())
 
-				goBoot = config.NewGoBoot(configPath)
+				goBoot = config.NewGoBoot(factories, configPath)
 				err = goBoot.Init()
 				Expect(err).To(HaveOccurred())
 				Expect(err.Error()).To(ContainSubstring("projectName"))
 				Expect(err.Error()).To(ContainSubstring("targetPath"))
 			})
 
-			It("returns error when enabled service has no confPath", func() {
+			It("returns error for an unknown conflict policy", func() {
+				yamlContent := `projectName: testproject
+targetPath: /tmp/test
+conflictPolicy: replace
+services: []
+`
+				Expect(os.WriteFile(configPath, []byte(yamlContent), 0644)).To(Succeed())
+
+				goBoot = config.NewGoBoot(factories, configPath)
+				err := goBoot.Init()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring(`invalid conflictPolicy "replace"`))
+				Expect(err.Error()).To(ContainSubstring("skip-existing"))
+			})
+
+			It("returns error when a service sets both confPath and config", func() {
 				yamlContent := `projectName: testproject
+repoUrl: https://github.com/user/testproject
 targetPath: /tmp/test
 services:
-  - id: base_project
+  - id: base_test
+    confPath: /tmp/base_test.yml
+    config:
+      useStyle: go
     enabled: true
 `
 				err := os.WriteFile(configPath, []byte(yamlContent), 0644)
 				Expect(err).NotTo(HaveOccurred())
 
-				goBoot = config.NewGoBoot(configPath)
+				goBoot = config.NewGoBoot(factories, configPath)
 				err = goBoot.Init()
 				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(ContainSubstring("services[base_project].confPath"))
+				Expect(err.Error()).To(ContainSubstring("either confPath or config, not both: base_test"))
+			})
+
+			It("returns error when an inline config is not a mapping", func() {
+				yamlContent := `projectName: testproject
+repoUrl: https://github.com/user/testproject
+targetPath: /tmp/test
+services:
+  - id: base_test
+    config: [go]
+    enabled: true
+`
+				err := os.WriteFile(configPath, []byte(yamlContent), 0644)
+				Expect(err).NotTo(HaveOccurred())
+
+				goBoot = config.NewGoBoot(factories, configPath)
+				err = goBoot.Init()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring(`inline config of "base_test" must be a mapping`))
+			})
+		})
+
+		Context("with inline and built-in service configs", func() {
+			It("loads inline config blocks", func() {
+				yamlContent := `projectName: testproject
+repoUrl: https://github.com/user/testproject
+targetPath: /tmp/test
+services:
+  - id: base_test
+    config:
+      useStyle: go
+    enabled: true
+`
+				Expect(os.WriteFile(configPath, []byte(yamlContent), 0644)).To(Succeed())
+
+				goBoot = config.NewGoBoot(factories, configPath)
+				Expect(goBoot.Init()).To(Succeed())
+
+				cfg, ok := goBoot.ConfManager.Get(goboottypes.ServiceNameBaseTest)
+				Expect(ok).To(BeTrue())
+				Expect(cfg.(*config.BaseTestConfig).UseStyle).To(Equal(goboottypes.TestStyleGo))
+			})
+
+			It("falls back to the built-in defaults of every service", func() {
+				yamlContent := `projectName: testproject
+repoUrl: https://github.com/user/testproject
+targetPath: /tmp/test
+services:
+  - id: base_project
+    enabled: true
+  - id: base_lint
+    enabled: true
+  - id: base_test
+    enabled: true
+  - id: base_local
+    enabled: true
+`
+				Expect(os.WriteFile(configPath, []byte(yamlContent), 0644)).To(Succeed())
+
+				goBoot = config.NewGoBoot(factories, configPath)
+				Expect(goBoot.Init()).To(Succeed())
+
+				project, ok := goBoot.ConfManager.Get(goboottypes.ServiceNameBaseProject)
+				Expect(ok).To(BeTrue())
+				Expect(project.(*config.BaseProjectConfig).SourcePath).To(Equal("builtin:project_base"))
+
+				local, ok := goBoot.ConfManager.Get(goboottypes.ServiceNameBaseLocal)
+				Expect(ok).To(BeTrue())
+				Expect(local.(*config.BaseLocalConfig).FileList).To(HaveLen(4))
+
+				lint, ok := goBoot.ConfManager.Get(goboottypes.ServiceNameBaseLint)
+				Expect(ok).To(BeTrue())
+				Expect(lint.(*config.BaseLintConfig).Linters[goboottypes.LinterGo].Cmd).
+					To(Equal(goboottypes.DefaultGoLintCmd))
 			})
 		})
 
@@ -185,7 +427,7 @@ services:
 				err := os.WriteFile(configPath, []byte(yamlContent), 0644)
 				Expect(err).NotTo(HaveOccurred())
 
-				goBoot = config.NewGoBoot(configPath)
+				goBoot = config.NewGoBoot(factories, configPath)
 			})
 
 			It("skips loading disabled services", func() {
@@ -193,7 +435,7 @@ services:
 				Expect(err).NotTo(HaveOccurred())
 
 				// Disabled service should not be registered
-				_, ok := goBoot.ConfManager.GetRegistrar(goboottypes.ServiceNameBaseProject)
+				_, ok := goBoot.ConfManager.Get(goboottypes.ServiceNameBaseProject)
 				Expect(ok).To(BeFalse())
 			})
 		})
@@ -211,7 +453,7 @@ services:
 				err := os.WriteFile(configPath, []byte(yamlContent), 0644)
 				Expect(err).NotTo(HaveOccurred())
 
-				goBoot = config.NewGoBoot(configPath)
+				goBoot = config.NewGoBoot(factories, configPath)
 			})
 
 			It("returns error for unknown service", func() {
@@ -235,20 +477,21 @@ services:
 				Expect(err).NotTo(HaveOccurred())
 
 				// Create invalid service config (missing required fields)
-				invalidServiceConfig := `projectName: test
+				invalidServiceConfig := `author: test
 # Missing all required fields
 `
 				invalidPath := filepath.Join(tempDir, "invalid.yml")
 				err = os.WriteFile(invalidPath, []byte(invalidServiceConfig), 0644)
 				Expect(err).NotTo(HaveOccurred())
 
-				goBoot = config.NewGoBoot(configPath)
+				goBoot = config.NewGoBoot(factories, configPath)
 			})
 
 			It("returns error when service config validation fails", func() {
 				err := goBoot.Init()
 				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(ContainSubstring("failed to register config"))
+				Expect(err.Error()).To(ContainSubstring(
+					"base_project.usedGoVersion (" + filepath.Join(tempDir, "invalid.yml") + "): missing required field"))
 			})
 
 			It("returns error when service config file cannot be read", func() {
@@ -263,7 +506,7 @@ services:
 				err := os.WriteFile(configPath, []byte(yamlContent), 0644)
 				Expect(err).NotTo(HaveOccurred())
 
-				goBoot = config.NewGoBoot(configPath)
+				goBoot = config.NewGoBoot(factories, configPath)
 
 				err = goBoot.Init()
 				Expect(err).To(HaveOccurred())
@@ -292,7 +535,6 @@ services:
 
 				// Create base_project config
 				projectConfig := `sourcePath: /tmp/templates
-projectName: testproject
 usedGoVersion: "1.22.0"
 usedNodeVersion: "20.0.0"
 releaseCurrentWindow: Q1 2025
@@ -323,7 +565,7 @@ fileList:
 				err = os.WriteFile(filepath.Join(tempDir, "base_local.yml"), []byte(localConfig), 0644)
 				Expect(err).NotTo(HaveOccurred())
 
-				goBoot = config.NewGoBoot(configPath)
+				goBoot = config.NewGoBoot(factories, configPath)
 			})
 
 			It("loads and registers all services", func() {
@@ -331,15 +573,15 @@ fileList:
 				Expect(err).NotTo(HaveOccurred())
 
 				// Check base_project (registrar)
-				_, exist := goBoot.ConfManager.GetRegistrar(goboottypes.ServiceNameBaseProject)
+				_, exist := goBoot.ConfManager.Get(goboottypes.ServiceNameBaseProject)
 				Expect(exist).To(BeTrue())
 
 				// Check base_lint (service)
-				_, exist = goBoot.ConfManager.GetService(goboottypes.ServiceNameBaseLint)
+				_, exist = goBoot.ConfManager.Get(goboottypes.ServiceNameBaseLint)
 				Expect(exist).To(BeTrue())
 
 				// Check base_local (service)
-				_, exist = goBoot.ConfManager.GetService(goboottypes.ServiceNameBaseLocal)
+				_, exist = goBoot.ConfManager.Get(goboottypes.ServiceNameBaseLocal)
 				Expect(exist).To(BeTrue())
 			})
 		})
@@ -364,10 +606,10 @@ useStyle: ginkgo
 				err = os.WriteFile(baseTestPath, []byte(baseTestConfig), 0644)
 				Expect(err).NotTo(HaveOccurred())
 
-				goBoot = config.NewGoBoot(configPath)
+				goBoot = config.NewGoBoot(factories, configPath)
 				Expect(goBoot.Init()).To(Succeed())
 
-				rawCfg, ok := goBoot.ConfManager.GetService(goboottypes.ServiceNameBaseTest)
+				rawCfg, ok := goBoot.ConfManager.Get(goboottypes.ServiceNameBaseTest)
 				Expect(ok).To(BeTrue())
 
 				testCfg, ok := rawCfg.(*config.BaseTestConfig)
@@ -407,7 +649,7 @@ gitUser: johndoe
 				err = os.WriteFile(filepath.Join(tempDir, "project.yml"), []byte(projectConfig), 0644)
 				Expect(err).NotTo(HaveOccurred())
 
-				testGoBoot := config.NewGoBoot(configPath)
+				testGoBoot := config.NewGoBoot(factories, configPath)
 				err = testGoBoot.Init()
 				Expect(err).NotTo(HaveOccurred())
 
@@ -0,0 +1,219 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// interpolateKey is the top-level key a config sets to true to opt in to the interpolation of its values.
+const interpolateKey = "interpolate"
+
+// UnsetVariable is an environment variable referenced in a config value without being set.
+type UnsetVariable struct {
+	// Name is the name of the variable.
+	Name string
+	// Line is the line of the referencing value in the YAML source.
+	Line int
+}
+
+// UnsetVariablesError is returned when an interpolated config references environment variables that are not set
+// and have no default.
+type UnsetVariablesError struct {
+	// File is the path of the YAML file, or empty if the config was not read from a file.
+	File string
+	// Vars are the unset variables in the order they appear in the source.
+	Vars []UnsetVariable
+}
+
+// Error lists every unset variable, prefixed with the file if known.
+func (e *UnsetVariablesError) Error() string {
+	msgs := make([]string, 0, len(e.Vars))
+
+	for _, v := range e.Vars {
+		location := "line " + strconv.Itoa(v.Line)
+		if e.File != "" {
+			location = e.File + ":" + strconv.Itoa(v.Line)
+		}
+
+		msgs = append(msgs, fmt.Sprintf("%s: environment variable %s is not set (use ${%s:-default} for a default)",
+			location, v.Name, v.Name))
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+// interpolate expands environment variables in the values of a decoded YAML document
+// if the document opts in with "interpolate: true"; the interpolate key itself is removed.
+//
+// Only scalar values are expanded, never keys, and the result is never parsed as YAML again:
+//   - "${VAR}" is replaced with the value of VAR, which must be set
+//   - "${VAR:-default}" falls back to default if VAR is unset or empty
+//   - "$${" is kept as a literal "${"
+//   - everything else, including "$VAR" and "$(cmd)", is kept as is
+//
+// Values must be expanded only once, or escaped references are expanded as well;
+// goboot configs use interpolateLayer for that reason.
+func interpolate(doc *yaml.Node) error {
+	root := doc
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	enabled, err := optedIn(root)
+	if err != nil || !enabled {
+		return err
+	}
+
+	return expand(root)
+}
+
+// optedIn reports whether the config mapping root opts in to interpolation, and removes its interpolate key.
+func optedIn(root *yaml.Node) (bool, error) {
+	if root.Kind != yaml.MappingNode {
+		return false, nil
+	}
+
+	enabled := false
+
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value != interpolateKey {
+			continue
+		}
+
+		value := root.Content[i+1]
+
+		err := value.Decode(&enabled)
+		if err != nil {
+			return false, fmt.Errorf("line %d: %s must be true or false", value.Line, interpolateKey)
+		}
+
+		root.Content = append(root.Content[:i], root.Content[i+2:]...)
+
+		break
+	}
+
+	return enabled, nil
+}
+
+// expand expands the environment variables in all scalar values below node.
+//
+// It returns an UnsetVariablesError listing every referenced variable that is not set.
+func expand(node *yaml.Node) error {
+	var unset []UnsetVariable
+
+	err := expandValues(node, &unset)
+	if err != nil {
+		return err
+	}
+
+	if len(unset) > 0 {
+		return &UnsetVariablesError{Vars: unset}
+	}
+
+	return nil
+}
+
+// expandValues expands the environment variables in all scalar values below node,
+// collecting the referenced variables that are not set.
+//
+// It returns an error for the first reference without a variable name (e.g., "${}").
+func expandValues(node *yaml.Node, unset *[]UnsetVariable) error {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 1; i < len(node.Content); i += 2 {
+			err := expandValues(node.Content[i], unset)
+			if err != nil {
+				return err
+			}
+		}
+	case yaml.SequenceNode, yaml.DocumentNode:
+		for _, child := range node.Content {
+			err := expandValues(child, unset)
+			if err != nil {
+				return err
+			}
+		}
+	case yaml.ScalarNode:
+		if !strings.Contains(node.Value, "${") {
+			return nil
+		}
+
+		value, missing, err := expandVars(node.Value)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", node.Line, err)
+		}
+
+		for _, name := range missing {
+			*unset = append(*unset, UnsetVariable{Name: name, Line: node.Line})
+		}
+
+		node.Value = value
+
+		// Let plain values resolve their type again (e.g., "currentYear: ${YEAR}").
+		if node.Style == 0 {
+			node.Tag = ""
+		}
+	default:
+	}
+
+	return nil
+}
+
+// expandVars expands "${VAR}" and "${VAR:-default}" references in value (see interpolate).
+//
+// It returns the expanded value and the names of referenced variables that are unset without a default,
+// or an error if a reference has no variable name (e.g., "${}" or "${:-default}").
+func expandVars(value string) (string, []string, error) {
+	var (
+		out     strings.Builder
+		missing []string
+	)
+
+	for {
+		start := strings.Index(value, "${")
+		if start < 0 {
+			out.WriteString(value)
+
+			return out.String(), missing, nil
+		}
+
+		if start > 0 && value[start-1] == '$' {
+			out.WriteString(value[:start-1] + "${")
+			value = value[start+2:]
+
+			continue
+		}
+
+		end := strings.IndexByte(value[start:], '}')
+		if end < 0 {
+			out.WriteString(value)
+
+			return out.String(), missing, nil
+		}
+
+		out.WriteString(value[:start])
+
+		name, def, hasDefault := strings.Cut(value[start+2:start+end], ":-")
+		if name == "" {
+			return "", nil, fmt.Errorf("%q references no environment variable (use \"${VAR}\", "+
+				"or \"$${\" for a literal \"${\")", value[start:start+end+1])
+		}
+
+		resolved, ok := os.LookupEnv(name)
+
+		switch {
+		case ok && (resolved != "" || !hasDefault):
+			out.WriteString(resolved)
+		case hasDefault:
+			out.WriteString(def)
+		default:
+			missing = append(missing, name)
+		}
+
+		value = value[start+end+1:]
+	}
+}
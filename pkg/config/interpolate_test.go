@@ -0,0 +1,189 @@
+package config_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/it-timo/goboot/pkg/config"
+	"github.com/it-timo/goboot/pkg/goboottypes"
+)
+
+var _ = Describe("Config interpolation", func() {
+	const repoURL = "https://github.com/acme/mytool"
+
+	var tempDir string
+
+	BeforeEach(func() {
+		tempDir = GinkgoT().TempDir()
+
+		GinkgoT().Setenv("GOBOOT_TEST_AUTHOR", "Jane Doe")
+		GinkgoT().Setenv("GOBOOT_TEST_EMPTY", "")
+		GinkgoT().Setenv("GOBOOT_TEST_YEAR", "2031")
+		Expect(os.Unsetenv("GOBOOT_TEST_UNSET")).To(Succeed())
+		Expect(os.Unsetenv("GOBOOT_TEST_MISSING")).To(Succeed())
+	})
+
+	writeFile := func(name, content string) string {
+		path := filepath.Join(tempDir, name)
+		Expect(os.WriteFile(path, []byte(content), 0o644)).To(Succeed())
+
+		return path
+	}
+
+	projectConfig := func(extra string) string {
+		return `usedGoVersion: "1.25.5"
+usedNodeVersion: "20"
+releaseCurrentWindow: Q2 2026
+releaseUpcomingWindow: Q4 2026
+releaseLongTerm: "2028"
+` + extra
+	}
+
+	It("expands variables and defaults in opted-in configs before validation", func() {
+		path := writeFile("base_project.yml", projectConfig(`interpolate: true
+author: ${GOBOOT_TEST_AUTHOR}
+gitProvider: ${GOBOOT_TEST_UNSET:-github}
+gitUser: ${GOBOOT_TEST_EMPTY:-acme}
+currentYear: ${GOBOOT_TEST_YEAR}
+`))
+
+		cfg := &config.BaseProjectConfig{ProjectName: "mytool"}
+		Expect(cfg.ReadConfig(path, repoURL)).To(Succeed())
+
+		Expect(cfg.Author).To(Equal("Jane Doe"))
+		Expect(cfg.GitProvider).To(Equal(goboottypes.GitProviderGitHub))
+		Expect(cfg.GitUser).To(Equal("acme"))
+		Expect(cfg.CurrentYear).To(Equal(2031))
+	})
+
+	It("keeps set empty values without a default, escapes, and other dollar signs", func() {
+		path := writeFile("base_test.yml", `interpolate: true
+useStyle: go
+testCmd: echo "$HOME $(pwd) $${GOBOOT_TEST_AUTHOR} [${GOBOOT_TEST_EMPTY}] ${GOBOOT_TEST_AUTHOR}"
+`)
+
+		cfg := &config.BaseTestConfig{ProjectName: "mytool"}
+		Expect(cfg.ReadConfig(path, repoURL)).To(Succeed())
+
+		Expect(cfg.TestCMD).To(Equal(`echo "$HOME $(pwd) ${GOBOOT_TEST_AUTHOR} [] Jane Doe"`))
+	})
+
+	It("leaves configs without the opt-in untouched", func() {
+		for _, optIn := range []string{"", "interpolate: false\n"} {
+			path := writeFile("base_test.yml", optIn+"useStyle: go\ntestCmd: go test ${GOBOOT_TEST_UNSET}\n")
+
+			cfg := &config.BaseTestConfig{ProjectName: "mytool"}
+			Expect(cfg.ReadConfig(path, repoURL)).To(Succeed())
+			Expect(cfg.TestCMD).To(Equal("go test ${GOBOOT_TEST_UNSET}"))
+		}
+
+		err := (&config.BaseTestConfig{}).DecodeConfig([]byte("interpolate: maybe\nuseStyle: go\n"), repoURL)
+		Expect(err).To(MatchError("line 1: interpolate must be true or false"))
+	})
+
+	It("reports every unset variable with its file and line", func() {
+		path := writeFile("base_project.yml", projectConfig(`interpolate: true
+author: ${GOBOOT_TEST_UNSET}
+gitProvider: github
+gitUser: ${GOBOOT_TEST_MISSING}-${GOBOOT_TEST_UNSET}
+`))
+
+		err := (&config.BaseProjectConfig{ProjectName: "mytool"}).ReadConfig(path, repoURL)
+
+		var unset *config.UnsetVariablesError
+		Expect(errors.As(err, &unset)).To(BeTrue(), "expected unset variables, got: %v", err)
+		Expect(unset.File).To(Equal(path))
+		Expect(unset.Vars).To(Equal([]config.UnsetVariable{
+			{Name: "GOBOOT_TEST_UNSET", Line: 7},
+			{Name: "GOBOOT_TEST_MISSING", Line: 9},
+			{Name: "GOBOOT_TEST_UNSET", Line: 9},
+		}))
+		Expect(err.Error()).To(HavePrefix(path + ":7: environment variable GOBOOT_TEST_UNSET is not set " +
+			"(use ${GOBOOT_TEST_UNSET:-default} for a default); "))
+	})
+
+	It("expands goboot.yml with its inline configs, and service config files that opt in", func() {
+		servicePath := writeFile("base_project.yml", projectConfig("interpolate: true\nauthor: ${GOBOOT_TEST_AUTHOR}\n"))
+		configPath := writeFile("goboot.yml", `interpolate: true
+projectName: mytool
+repoUrl: ${GOBOOT_TEST_UNSET:-`+repoURL+`}
+targetPath: out
+services:
+  - id: base_project
+    confPath: `+servicePath+`
+    enabled: true
+  - id: base_test
+    enabled: true
+    config:
+      useStyle: go
+      testCmd: go test ${GOBOOT_TEST_YEAR}
+`)
+
+		gb := config.NewGoBoot(factories, configPath)
+		Expect(gb.Init()).To(Succeed())
+		Expect(gb.RepoURL).To(Equal(repoURL))
+
+		project, ok := gb.ConfManager.Get(goboottypes.ServiceNameBaseProject)
+		Expect(ok).To(BeTrue())
+		Expect(project.(*config.BaseProjectConfig).Author).To(Equal("Jane Doe"))
+
+		test, ok := gb.ConfManager.Get(goboottypes.ServiceNameBaseTest)
+		Expect(ok).To(BeTrue())
+		Expect(test.(*config.BaseTestConfig).TestCMD).To(Equal("go test 2031"))
+	})
+
+	It("expands inline configs once, keeping their escapes", func() {
+		for _, optIn := range []string{"interpolate: true\n", ""} {
+			configPath := writeFile("goboot.yml", optIn+`projectName: mytool
+repoUrl: `+repoURL+`
+targetPath: out
+services:
+  - id: base_test
+    enabled: true
+    config:
+      interpolate: true
+      useStyle: go
+      testCmd: go test $${GOBOOT_TEST_AUTHOR} ${GOBOOT_TEST_YEAR}
+`)
+
+			gb := config.NewGoBoot(factories, configPath)
+			Expect(gb.Init()).To(Succeed())
+
+			test, ok := gb.ConfManager.Get(goboottypes.ServiceNameBaseTest)
+			Expect(ok).To(BeTrue())
+			Expect(test.(*config.BaseTestConfig).TestCMD).To(Equal("go test ${GOBOOT_TEST_AUTHOR} 2031"), optIn)
+		}
+	})
+
+	It("rejects references without a variable name", func() {
+		for _, ref := range []string{"${}", "${:-go}"} {
+			data := []byte("interpolate: true\nuseStyle: go\ntestCmd: go " + ref + "\n")
+
+			err := (&config.BaseTestConfig{}).DecodeConfig(data, repoURL)
+			Expect(err).To(MatchError(`line 3: "` + ref + `" references no environment variable ` +
+				`(use "${VAR}", or "$${" for a literal "${")`))
+		}
+	})
+
+	It("lets inline configs opt in on their own", func() {
+		configPath := writeFile("goboot.yml", `projectName: mytool
+repoUrl: `+repoURL+`
+targetPath: out
+services:
+  - id: base_test
+    enabled: true
+    config:
+      interpolate: true
+      useStyle: go
+      testCmd: go test ${GOBOOT_TEST_MISSING}
+`)
+
+		err := config.NewGoBoot(factories, configPath).Init()
+		Expect(err).To(MatchError(ContainSubstring(`failed to read inline config for "base_test": ` + configPath +
+			`:10: environment variable GOBOOT_TEST_MISSING is not set (use ${GOBOOT_TEST_MISSING:-default} for a default)`)))
+	})
+})
@@ -0,0 +1,210 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// UnknownKey is a key in a YAML config that matches no field of the config it is decoded into.
+type UnknownKey struct {
+	// Path is the dotted path of the key (e.g., "linters.golang.cmdd").
+	Path string
+	// Line is the line of the key in the YAML source.
+	Line int
+	// Suggestion is the known key closest to the unknown one, or empty if none is close enough.
+	Suggestion string
+}
+
+// String returns the key with its line and suggestion (e.g., `line 3: unknown key "usedGoVerison"`).
+func (k UnknownKey) String() string {
+	return fmt.Sprintf("line %d: %s", k.Line, k.message())
+}
+
+// message returns the key with its suggestion, without the line.
+func (k UnknownKey) message() string {
+	msg := fmt.Sprintf("unknown key %q", k.Path)
+	if k.Suggestion != "" {
+		msg += fmt.Sprintf(" (did you mean %q?)", k.Suggestion)
+	}
+
+	return msg
+}
+
+// UnknownKeysError is returned when decoding a YAML config containing keys that match no config field.
+//
+// Such keys are usually typos (e.g., "usedGoVerison" or "linter"), which would otherwise be ignored silently.
+type UnknownKeysError struct {
+	// File is the path of the YAML file, or empty if the config was not read from a file.
+	File string
+	// Keys are the unknown keys in the order they appear in the source.
+	Keys []UnknownKey
+}
+
+// Error lists every unknown key, prefixed with the file if known.
+func (e *UnknownKeysError) Error() string {
+	msgs := make([]string, 0, len(e.Keys))
+
+	for _, key := range e.Keys {
+		msg := key.String()
+		if e.File != "" {
+			msg = e.File + ":" + strings.TrimPrefix(msg, "line ")
+		}
+
+		msgs = append(msgs, msg)
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+// withFile sets the file of an UnknownKeysError or UnsetVariablesError in err, if any, and returns err.
+func withFile(err error, file string) error {
+	var unknown *UnknownKeysError
+	if errors.As(err, &unknown) && unknown.File == "" {
+		unknown.File = file
+	}
+
+	var unset *UnsetVariablesError
+	if errors.As(err, &unset) && unset.File == "" {
+		unset.File = file
+	}
+
+	return err
+}
+
+// configKeys describes the keys a YAML mapping of a config may contain.
+//
+// Every config declares its keys explicitly next to its struct (e.g., baseProjectKeys),
+// matching the yaml tags of the struct fields.
+type configKeys struct {
+	// fields maps each known key to the keys of its value, or nil if the value is not checked (e.g., scalars).
+	// Sequence values are checked item by item.
+	fields map[string]*configKeys
+
+	// values, if set, allows free-form keys (e.g., linter names) and describes the keys of their values.
+	values *configKeys
+}
+
+// newConfigKeys returns the configKeys of a mapping with the given keys, none of which have nested keys.
+func newConfigKeys(keys ...string) *configKeys {
+	fields := make(map[string]*configKeys, len(keys))
+	for _, key := range keys {
+		fields[key] = nil
+	}
+
+	return &configKeys{fields: fields}
+}
+
+// with adds a key whose value has nested keys, and returns ck.
+func (ck *configKeys) with(key string, nested *configKeys) *configKeys {
+	ck.fields[key] = nested
+
+	return ck
+}
+
+// keyDeclarer is implemented by service configs declaring the keys of their YAML mapping.
+type keyDeclarer interface {
+	configKeys() *configKeys
+}
+
+// serviceKeys returns the keys declared by a service config, or nil if it declares none.
+func serviceKeys(cfg ServiceConfig) *configKeys {
+	declarer, ok := cfg.(keyDeclarer)
+	if !ok {
+		return nil
+	}
+
+	return declarer.configKeys()
+}
+
+// unknownKeys returns all mapping keys below node that are not declared in keys.
+func unknownKeys(node *yaml.Node, keys *configKeys, path string) []UnknownKey {
+	var unknown []UnknownKey
+
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, child := range node.Content {
+			unknown = append(unknown, unknownKeys(child, keys, path)...)
+		}
+	case yaml.SequenceNode:
+		for i, child := range node.Content {
+			unknown = append(unknown, unknownKeys(child, keys, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			keyPath := joinKey(path, key.Value)
+
+			if keys.values != nil {
+				unknown = append(unknown, unknownKeys(value, keys.values, keyPath)...)
+
+				continue
+			}
+
+			nested, ok := keys.fields[key.Value]
+			if !ok {
+				unknown = append(unknown, UnknownKey{Path: keyPath, Line: key.Line, Suggestion: keys.suggest(key.Value)})
+
+				continue
+			}
+
+			if nested != nil {
+				unknown = append(unknown, unknownKeys(value, nested, keyPath)...)
+			}
+		}
+	default:
+	}
+
+	return unknown
+}
+
+// suggest returns the known key closest to key, or an empty string if none is close enough to be a typo.
+func (ck *configKeys) suggest(key string) string {
+	best, bestDist := "", len(key)/3+2
+
+	for name := range ck.fields {
+		dist := editDistance(strings.ToLower(key), strings.ToLower(name))
+		if dist < bestDist || dist == bestDist && best != "" && name < best {
+			best, bestDist = name, dist
+		}
+	}
+
+	return best
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+
+		prev = cur
+	}
+
+	return prev[len(b)]
+}
+
+// joinKey appends key to a dotted key path.
+func joinKey(path, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
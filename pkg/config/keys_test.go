@@ -0,0 +1,182 @@
+package config_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/it-timo/goboot/pkg/config"
+	"github.com/it-timo/goboot/pkg/goboottypes"
+)
+
+var _ = Describe("Unknown config keys", func() {
+	var tempDir string
+
+	BeforeEach(func() {
+		tempDir = GinkgoT().TempDir()
+	})
+
+	writeFile := func(name, content string) string {
+		path := filepath.Join(tempDir, name)
+		Expect(os.WriteFile(path, []byte(content), 0o644)).To(Succeed())
+
+		return path
+	}
+
+	unknownKeys := func(err error) *config.UnknownKeysError {
+		var unknown *config.UnknownKeysError
+		Expect(errors.As(err, &unknown)).To(BeTrue(), "expected unknown keys, got: %v", err)
+
+		return unknown
+	}
+
+	// allFieldsSet fails unless every field encoded by yaml.v3 is set, so the round trips below cover new fields too.
+	allFieldsSet := func(v any) {
+		value := reflect.Indirect(reflect.ValueOf(v))
+		for i := range value.NumField() {
+			field := value.Type().Field(i)
+			if field.IsExported() && field.Tag.Get("yaml") != "-" {
+				Expect(value.Field(i).IsZero()).To(BeFalse(), "%s.%s must be set", value.Type().Name(), field.Name)
+			}
+		}
+	}
+
+	It("declares every field the configs encode", func() {
+		linter := &config.Linter{Cmd: "golangci-lint run", Enabled: true}
+		configs := []config.ServiceConfig{
+			&config.BaseProjectConfig{
+				Version: config.ConfigVersion, SourcePath: "templates/project_base", UsedGoVersion: "1.25.5",
+				UsedNodeVersion: "20", CurrentYear: 2026, ReleaseCurrentWindow: "Q2 2026",
+				ReleaseUpcomingWindow: "Q4 2026", ReleaseLongTerm: "2028", Author: "Jane", GitProvider: "github",
+				GitUser: "acme",
+			},
+			&config.BaseLintConfig{
+				Version: config.ConfigVersion, SourcePath: "templates/lint_base",
+				Linters: map[string]*config.Linter{"golang": linter}, AllowedPackages: []string{"github.com/acme"},
+			},
+			&config.BaseTestConfig{
+				Version: config.ConfigVersion, SourcePath: "templates/test_base", UseStyle: "go", TestCMD: "go test ./...",
+			},
+			&config.BaseLocalConfig{
+				Version: config.ConfigVersion, SourcePath: "templates/local_base", FileList: []string{"make"},
+			},
+		}
+
+		allFieldsSet(linter)
+
+		for _, cfg := range configs {
+			allFieldsSet(cfg)
+
+			data, err := yaml.Marshal(cfg)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.DecodeConfig(data, "github.com/acme/mytool")).To(Succeed(), "config of %s", cfg.ID())
+		}
+
+		inline := yaml.Node{}
+		Expect(inline.Encode(configs[2])).To(Succeed())
+
+		service := config.ServiceConfigMeta{
+			ID: "acme_license", ConfPath: "acme.yml", Config: inline, Enabled: true, Plugin: "./bin/goboot-acme",
+		}
+		gbCfg := &config.GoBoot{
+			Version: config.ConfigVersion, ProjectName: "mytool", RepoURL: "github.com/acme/mytool", TargetPath: "out",
+			ConflictPolicy: "fail", Profile: "minimal", Services: []config.ServiceConfigMeta{service},
+		}
+
+		allFieldsSet(service)
+		allFieldsSet(gbCfg)
+
+		data, err := yaml.Marshal(gbCfg)
+		Expect(err).NotTo(HaveOccurred())
+
+		err = config.NewGoBoot(factories, writeFile("goboot.yml", string(data))).Init()
+
+		var unknown *config.UnknownKeysError
+		Expect(errors.As(err, &unknown)).To(BeFalse(), "unexpected unknown keys: %v", err)
+	})
+
+	It("reports typos with their file, line, and the closest known key", func() {
+		path := writeFile("base_project.yml", "author: Jane\nusedGoVerison: \"1.25\"\nlicense: MIT\n")
+
+		err := (&config.BaseProjectConfig{}).ReadConfig(path, "https://github.com/acme/mytool")
+
+		unknown := unknownKeys(err)
+		Expect(unknown.File).To(Equal(path))
+		Expect(unknown.Keys).To(Equal([]config.UnknownKey{
+			{Path: "usedGoVerison", Line: 2, Suggestion: "usedGoVersion"},
+			{Path: "license", Line: 3},
+		}))
+		Expect(err.Error()).To(Equal(path + `:2: unknown key "usedGoVerison" (did you mean "usedGoVersion"?); ` +
+			path + `:3: unknown key "license"`))
+	})
+
+	It("checks nested structs and map values", func() {
+		path := writeFile("base_lint.yml", "linter:\n  golang:\n    enabled: true\n")
+
+		err := (&config.BaseLintConfig{}).ReadConfig(path, "github.com/acme/mytool")
+		Expect(unknownKeys(err).Keys).To(Equal([]config.UnknownKey{{Path: "linter", Line: 1, Suggestion: "linters"}}))
+
+		path = writeFile("base_lint.yml", "linters:\n  golang:\n    enabled: true\n    cmdd: golangci-lint run\n")
+
+		err = (&config.BaseLintConfig{}).ReadConfig(path, "github.com/acme/mytool")
+		Expect(unknownKeys(err).Keys).To(Equal([]config.UnknownKey{
+			{Path: "linters.golang.cmdd", Line: 4, Suggestion: "cmd"},
+		}))
+	})
+
+	It("rejects derived fields that are set in goboot.yml", func() {
+		err := (&config.BaseTestConfig{}).DecodeConfig([]byte("useStyle: go\nprojectName: mytool\n"), "")
+
+		unknown := unknownKeys(err)
+		Expect(unknown.File).To(BeEmpty())
+		Expect(err.Error()).To(Equal(`line 2: unknown key "projectName"`))
+	})
+
+	It("reports unknown keys of goboot.yml, service config files, and inline configs", func() {
+		configPath := writeFile("goboot.yml", `projectName: mytool
+repoUrl: https://github.com/acme/mytool
+targetPth: out
+services:
+  - id: base_test
+    enable: true
+`)
+
+		err := config.NewGoBoot(factories, configPath).Init()
+		Expect(err).To(MatchError(ContainSubstring(configPath + `:3: unknown key "targetPth" (did you mean "targetPath"?)`)))
+		Expect(err).To(MatchError(ContainSubstring(`:6: unknown key "services[0].enable" (did you mean "enabled"?)`)))
+
+		configPath = writeFile("goboot.yml", `projectName: mytool
+repoUrl: https://github.com/acme/mytool
+targetPath: out
+services:
+  - id: base_test
+    enabled: true
+    config:
+      useStlye: go
+`)
+
+		err = config.NewGoBoot(factories, configPath).Init()
+		Expect(err).To(MatchError(ContainSubstring(
+			configPath + `:8: unknown key "services[0].config.useStlye" (did you mean "useStyle"?)`)))
+
+		servicePath := writeFile("base_local.yml", "fileList: [make]\nfilelist: [task]\n")
+		configPath = writeFile("goboot.yml", `projectName: mytool
+repoUrl: https://github.com/acme/mytool
+targetPath: out
+services:
+  - id: `+goboottypes.ServiceNameBaseLocal+`
+    confPath: `+servicePath+`
+    enabled: true
+`)
+
+		err = config.NewGoBoot(factories, configPath).Init()
+		Expect(err).To(MatchError(ContainSubstring(
+			`base_local.filelist (` + servicePath + `:2): unknown key (did you mean "fileList"?)`)))
+	})
+})
@@ -0,0 +1,363 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// servicesKey is the key of the service declarations in goboot.yml, which are merged by their id.
+const servicesKey = "services"
+
+// errLayerNotMapping is returned when merging a goboot config layer that is not a YAML mapping.
+var errLayerNotMapping = errors.New("config layers must be mappings")
+
+// readLayer reads the goboot config file at path into a YAML node (see parseLayer).
+func (factories ServiceConfigFactories) readLayer(path string, warn warnFunc) (*yaml.Node, error) {
+	data, err := readYMLFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return factories.parseLayer(data, path, warn)
+}
+
+// parseLayer parses the data of the goboot config file at path into a YAML node, interpolating
+// (see interpolateLayer) and checking the keys of the config and its inline service configs.
+//
+// The path is only used in error messages; it may be empty for configs not read from a file.
+func (factories ServiceConfigFactories) parseLayer(data []byte, path string, warn warnFunc) (*yaml.Node, error) {
+	root, err := parseYMLConfig(data, SchemaGoBoot, gobootKeys, warn)
+	if err != nil {
+		return nil, withFile(err, path)
+	}
+
+	var keys []UnknownKey
+
+	for i, entry := range sequenceItems(mappingValue(root, servicesKey)) {
+		id := scalarValue(mappingValue(entry, "id"))
+
+		svcKeys := factories.keys(id)
+		inline := mappingValue(entry, "config")
+
+		if svcKeys == nil || inline == nil {
+			continue
+		}
+
+		// Inline configs without a version were migrated along with the file (see migrateConfig).
+		setVersion(inline)
+
+		keys = append(keys, unknownKeys(inline, svcKeys, fmt.Sprintf("services[%d].config", i))...)
+	}
+
+	if len(keys) > 0 {
+		return nil, &UnknownKeysError{File: path, Keys: keys}
+	}
+
+	return root, nil
+}
+
+// inlineConfigError is an error in the inline config of a service declared in a goboot config.
+//
+// Its message is built when read, so withFile can still add the file to the wrapped error.
+type inlineConfigError struct {
+	id  string
+	err error
+}
+
+// Error returns the error prefixed with the service.
+func (e *inlineConfigError) Error() string {
+	return fmt.Sprintf("failed to read inline config for %q: %v", e.id, e.err)
+}
+
+// Unwrap returns the wrapped error.
+func (e *inlineConfigError) Unwrap() error {
+	return e.err
+}
+
+// interpolateLayer interpolates a goboot config document and its inline service configs (see interpolate).
+//
+// Every value is expanded once: inline configs of a config that opts in are expanded along with it,
+// and inline configs that opt in on their own are expanded by themselves. The interpolate key
+// of inline configs is removed either way, so decoding them later does not expand them again.
+func interpolateLayer(doc *yaml.Node) error {
+	root := documentRoot(doc)
+	if root == nil {
+		return nil
+	}
+
+	enabled, err := optedIn(root)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range sequenceItems(mappingValue(root, servicesKey)) {
+		inline := mappingValue(entry, "config")
+		if inline == nil {
+			continue
+		}
+
+		inlineEnabled, err := optedIn(inline)
+		if err == nil && inlineEnabled && !enabled {
+			err = expand(inline)
+		}
+
+		if err != nil {
+			return &inlineConfigError{id: scalarValue(mappingValue(entry, "id")), err: err}
+		}
+	}
+
+	if !enabled {
+		return nil
+	}
+
+	return expand(root)
+}
+
+// inlineConfigFiles replaces the confPath of every service declaration in a config layer (built-in or plugin)
+// with the config read from that file, so later layers can merge into it.
+//
+// The files are recorded in sources by service ID, for error messages.
+func (factories ServiceConfigFactories) inlineConfigFiles(root *yaml.Node, sources map[string]string,
+	warn warnFunc,
+) error {
+	for _, entry := range sequenceItems(mappingValue(root, servicesKey)) {
+		id := scalarValue(mappingValue(entry, "id"))
+		confPath := scalarValue(mappingValue(entry, "confPath"))
+
+		known := isPluginEntry(entry) || factories.keys(id) != nil
+		if !known || strings.TrimSpace(confPath) == "" || mappingValue(entry, "config") != nil {
+			continue
+		}
+
+		err := factories.inlineConfigFile(entry, confPath, id, warn)
+		if err != nil {
+			return fmt.Errorf("failed to read config for %q: %w", id, err)
+		}
+
+		sources[id] = confPath
+	}
+
+	return nil
+}
+
+// inlineConfigFile replaces the confPath of a service declaration with the config read from that file.
+func (factories ServiceConfigFactories) inlineConfigFile(entry *yaml.Node, confPath, id string, warn warnFunc) error {
+	data, err := readYMLFile(confPath)
+	if err != nil {
+		return err
+	}
+
+	var cfg *yaml.Node
+	if isPluginEntry(entry) {
+		cfg, err = parsePluginConfig(data)
+	} else {
+		cfg, err = parseYMLConfig(data, id, factories.keys(id), warn)
+	}
+
+	if err != nil {
+		return withFile(err, confPath)
+	}
+
+	deleteMappingValue(entry, "confPath")
+	setMappingValue(entry, "config", cfg)
+
+	return nil
+}
+
+// mergeLayer deep-merges the goboot config layer src into dst:
+//   - mappings (e.g., linters) are merged key by key
+//   - services are merged by their id; services not declared in dst are appended
+//   - lists (e.g., fileList, allowedPackages) and scalars of src replace those of dst
+//
+// A service declared in dst without a config starts from its built-in defaults if src configures it.
+func (factories ServiceConfigFactories) mergeLayer(dst, src *yaml.Node) error {
+	if dst.Kind != yaml.MappingNode || src.Kind != yaml.MappingNode {
+		return errLayerNotMapping
+	}
+
+	for i := 0; i+1 < len(src.Content); i += 2 {
+		key, value := src.Content[i].Value, src.Content[i+1]
+
+		current := mappingValue(dst, key)
+		if key == servicesKey && current != nil && current.Kind == yaml.SequenceNode && value.Kind == yaml.SequenceNode {
+			err := factories.mergeServices(current, value)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		setMappingValue(dst, key, mergeValue(current, value))
+	}
+
+	return nil
+}
+
+// mergeServices merges the service declarations of src into those of dst by their id.
+func (factories ServiceConfigFactories) mergeServices(dst, src *yaml.Node) error {
+	for _, entry := range src.Content {
+		current := serviceEntry(dst, scalarValue(mappingValue(entry, "id")))
+		if current == nil {
+			dst.Content = append(dst.Content, entry)
+
+			continue
+		}
+
+		if mappingValue(entry, "config") != nil {
+			_, err := factories.serviceConfigNode(current)
+			if err != nil {
+				return err
+			}
+		}
+
+		mergeValue(current, entry)
+	}
+
+	return nil
+}
+
+// mergeValue returns src deep-merged into dst if both are mappings, and src otherwise.
+func mergeValue(dst, src *yaml.Node) *yaml.Node {
+	if dst == nil || dst.Kind != yaml.MappingNode || src.Kind != yaml.MappingNode {
+		return src
+	}
+
+	for i := 0; i+1 < len(src.Content); i += 2 {
+		key := src.Content[i].Value
+		setMappingValue(dst, key, mergeValue(mappingValue(dst, key), src.Content[i+1]))
+	}
+
+	return dst
+}
+
+// serviceEntry returns the declaration of the service with the given id, or nil if it is not declared.
+func serviceEntry(services *yaml.Node, id string) *yaml.Node {
+	if id == "" {
+		return nil
+	}
+
+	for _, entry := range sequenceItems(services) {
+		if scalarValue(mappingValue(entry, "id")) == id {
+			return entry
+		}
+	}
+
+	return nil
+}
+
+// serviceConfigNode returns the inline config of a service declaration,
+// setting it to the built-in defaults of the service if it has none, or to an empty config for plugins.
+func (factories ServiceConfigFactories) serviceConfigNode(entry *yaml.Node) (*yaml.Node, error) {
+	cfg := mappingValue(entry, "config")
+	if cfg != nil {
+		return cfg, nil
+	}
+
+	if isPluginEntry(entry) {
+		cfg = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		setMappingValue(entry, "config", cfg)
+
+		return cfg, nil
+	}
+
+	id := scalarValue(mappingValue(entry, "id"))
+
+	data, err := factories.defaults(id)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err = parseYMLConfig(data, id, factories.keys(id), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode default config for %q: %w", id, err)
+	}
+
+	setMappingValue(entry, "config", cfg)
+
+	return cfg, nil
+}
+
+// mappingValue returns the value of key in a mapping node, or nil if node is no mapping or lacks the key.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+
+	return nil
+}
+
+// mappingKey returns the key node of key in a mapping node, or nil if node is no mapping or lacks the key.
+func mappingKey(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i]
+		}
+	}
+
+	return nil
+}
+
+// documentRoot returns the top-level value of a document node, or nil if the document is empty.
+func documentRoot(doc *yaml.Node) *yaml.Node {
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return nil
+	}
+
+	return doc.Content[0]
+}
+
+// setMappingValue sets the value of key in a mapping node, appending the key if it is missing.
+func setMappingValue(node *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content[i+1] = value
+
+			return
+		}
+	}
+
+	node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+}
+
+// deleteMappingValue removes key and its value from a mapping node.
+func deleteMappingValue(node *yaml.Node, key string) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content = append(node.Content[:i], node.Content[i+2:]...)
+
+			return
+		}
+	}
+}
+
+// sequenceItems returns the items of a sequence node, or nil if node is no sequence.
+func sequenceItems(node *yaml.Node) []*yaml.Node {
+	if node == nil || node.Kind != yaml.SequenceNode {
+		return nil
+	}
+
+	return node.Content
+}
+
+// scalarValue returns the value of a scalar node, or an empty string if node is no scalar.
+func scalarValue(node *yaml.Node) string {
+	if node == nil || node.Kind != yaml.ScalarNode {
+		return ""
+	}
+
+	return node.Value
+}
@@ -0,0 +1,227 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/it-timo/goboot/pkg/config"
+	"github.com/it-timo/goboot/pkg/goboottypes"
+)
+
+var _ = Describe("Layered configs", func() {
+	var (
+		tempDir  string
+		basePath string
+	)
+
+	writeFile := func(name, content string) string {
+		path := filepath.Join(tempDir, name)
+		Expect(os.WriteFile(path, []byte(content), 0o644)).To(Succeed())
+
+		return path
+	}
+
+	BeforeEach(func() {
+		tempDir = GinkgoT().TempDir()
+
+		lintPath := writeFile("base_lint.yml", `linters:
+  golang:
+    cmd: golangci-lint run
+    enabled: true
+  yaml:
+    enabled: true
+allowedPackages: [github.com/acme/shared, github.com/acme/log]
+`)
+		basePath = writeFile("org.yml", `projectName: orgtool
+repoUrl: https://github.com/acme/orgtool
+targetPath: out
+conflictPolicy: skip-existing
+services:
+  - id: base_lint
+    confPath: `+lintPath+`
+    enabled: true
+  - id: base_local
+    enabled: true
+  - id: base_test
+    enabled: false
+    config:
+      useStyle: ginkgo
+`)
+	})
+
+	// load initializes the layered config and returns it with the registered service configs.
+	load := func(overlays []string, overrides ...string) (*config.GoBoot, error) {
+		gb := config.NewGoBoot(factories, basePath, overlays...)
+
+		err := gb.SetOverrides(overrides)
+		if err != nil {
+			return nil, err
+		}
+
+		return gb, gb.Init()
+	}
+
+	lintConfig := func(gb *config.GoBoot) *config.BaseLintConfig {
+		cfg, ok := gb.ConfManager.Get(goboottypes.ServiceNameBaseLint)
+		Expect(ok).To(BeTrue())
+
+		return cfg.(*config.BaseLintConfig)
+	}
+
+	localConfig := func(gb *config.GoBoot) *config.BaseLocalConfig {
+		cfg, ok := gb.ConfManager.Get(goboottypes.ServiceNameBaseLocal)
+		Expect(ok).To(BeTrue())
+
+		return cfg.(*config.BaseLocalConfig)
+	}
+
+	It("keeps a single config file as is", func() {
+		gb, err := load(nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(gb.Services[0].ConfPath).NotTo(BeEmpty())
+		Expect(lintConfig(gb).AllowedPackages).To(Equal([]string{"github.com/acme/shared", "github.com/acme/log"}))
+	})
+
+	It("replaces scalars and lists, merges maps, and merges services by id", func() {
+		overlay := writeFile("project.yml", `projectName: mytool
+services:
+  - id: base_lint
+    config:
+      linters:
+        golang:
+          enabled: false
+        markdown:
+          enabled: true
+      allowedPackages: [github.com/acme/mytool]
+  - id: base_local
+    config:
+      fileList: [task]
+  - id: base_test
+    enabled: true
+`)
+
+		gb, err := load([]string{overlay})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(gb.ProjectName).To(Equal("mytool"))
+		Expect(gb.RepoURL).To(Equal("https://github.com/acme/orgtool"))
+		Expect(gb.ConflictPolicy).To(Equal(goboottypes.ConflictPolicySkipExisting))
+		Expect(gb.Services).To(HaveLen(3))
+
+		lint := lintConfig(gb)
+		Expect(lint.Linters).To(HaveLen(3))
+		Expect(*lint.Linters[goboottypes.LinterGo]).To(Equal(config.Linter{Cmd: "golangci-lint run", Enabled: false}))
+		Expect(lint.Linters[goboottypes.LinterYAML].Enabled).To(BeTrue())
+		Expect(lint.Linters[goboottypes.LinterMD].Enabled).To(BeTrue())
+		Expect(lint.AllowedPackages).To(Equal([]string{"github.com/acme/mytool"}))
+
+		// base_local had no config, so the overlay is merged into its built-in defaults.
+		Expect(localConfig(gb).FileList).To(Equal([]string{"task"}))
+
+		test, ok := gb.ConfManager.Get(goboottypes.ServiceNameBaseTest)
+		Expect(ok).To(BeTrue())
+		Expect(test.(*config.BaseTestConfig).UseStyle).To(Equal("ginkgo"))
+	})
+
+	It("merges overlays in order and appends new services", func() {
+		first := writeFile("team.yml", "targetPath: team-out\nconflictPolicy: overwrite\n")
+		second := writeFile("project.yml", `targetPath: project-out
+services:
+  - id: base_project
+    enabled: false
+`)
+
+		gb, err := load([]string{first, second})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(gb.TargetPath).To(Equal("project-out"))
+		Expect(gb.ConflictPolicy).To(Equal(goboottypes.ConflictPolicyOverwrite))
+		Expect(gb.Services).To(HaveLen(4))
+		Expect(gb.Services[3].ID).To(Equal(goboottypes.ServiceNameBaseProject))
+	})
+
+	It("applies overrides after merging", func() {
+		gb, err := load(nil,
+			"services.base_lint.linters.golang.enabled=false",
+			"services.base_lint.linters.custom={cmd: echo custom, enabled: true}",
+			"services.base_local.fileList=[make, task]",
+			"services.base_test.enabled=true",
+			"services.base_test.config.useStyle=go",
+			"projectName=mytool",
+		)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(gb.ProjectName).To(Equal("mytool"))
+
+		lint := lintConfig(gb)
+		Expect(lint.Linters[goboottypes.LinterGo].Enabled).To(BeFalse())
+		Expect(*lint.Linters["custom"]).To(Equal(config.Linter{Cmd: "echo custom", Enabled: true}))
+		Expect(localConfig(gb).FileList).To(Equal([]string{"make", "task"}))
+
+		test, ok := gb.ConfManager.Get(goboottypes.ServiceNameBaseTest)
+		Expect(ok).To(BeTrue())
+		Expect(test.(*config.BaseTestConfig).UseStyle).To(Equal("go"))
+	})
+
+	It("rejects invalid overrides", func() {
+		_, err := load(nil, "projectName")
+		Expect(err).To(MatchError(`invalid override "projectName": must be key.path=value`))
+
+		_, err = load(nil, "services..enabled=true")
+		Expect(err).To(MatchError(ContainSubstring("empty key")))
+
+		_, err = load(nil, "services.base_lint.linterz.golang.enabled=false")
+		Expect(err).To(MatchError(ContainSubstring(`invalid override "services.base_lint.linterz.golang.enabled=false": ` +
+			`unknown key "services.base_lint.linterz" (did you mean "linters"?)`)))
+
+		_, err = load(nil, "services.base_lint.linters.golang.enabeld=false")
+		Expect(err).To(MatchError(ContainSubstring(`unknown key "services.base_lint.linters.golang.enabeld"`)))
+
+		_, err = load(nil, "services.base_lint.linters={golang: {cmdd: x}}")
+		Expect(err).To(MatchError(ContainSubstring(`unknown key "services.base_lint.linters.golang.cmdd"`)))
+
+		_, err = load(nil, "services.base_project.author=Jane")
+		Expect(err).To(MatchError(ContainSubstring(`service "base_project" is not declared`)))
+
+		_, err = load(nil, "services.docs.enabled=true")
+		Expect(err).To(MatchError(ContainSubstring(`unknown service "docs"`)))
+
+		_, err = load(nil, "projectName.short=x")
+		Expect(err).To(MatchError(ContainSubstring(`"projectName" has no nested keys`)))
+	})
+
+	It("reports unknown keys with the file of their layer", func() {
+		overlay := writeFile("project.yml", `services:
+  - id: base_lint
+    config:
+      linter:
+        golang:
+          enabled: false
+`)
+
+		_, err := load([]string{overlay})
+		Expect(err).To(MatchError(ContainSubstring(
+			overlay + `:4: unknown key "services[0].config.linter" (did you mean "linters"?)`)))
+
+		overlay = writeFile("project.yml", "targetPth: out\n")
+
+		_, err = load([]string{overlay})
+		Expect(err).To(MatchError(ContainSubstring(overlay + `:1: unknown key "targetPth"`)))
+	})
+
+	It("interpolates each layer on its own", func() {
+		GinkgoT().Setenv("GOBOOT_TEST_PROJECT", "envtool")
+
+		overlay := writeFile("project.yml", "interpolate: true\nprojectName: ${GOBOOT_TEST_PROJECT}\n")
+
+		gb, err := load([]string{overlay}, "targetPath=${GOBOOT_TEST_PROJECT}")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(gb.ProjectName).To(Equal("envtool"))
+		Expect(gb.TargetPath).To(Equal("${GOBOOT_TEST_PROJECT}"))
+	})
+})
@@ -2,8 +2,10 @@ package config
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 
-	"github.com/it-timo/goboot/pkg/goboottypes"
+	"gopkg.in/yaml.v3"
 )
 
 // ServiceConfig represents a modular configuration component used by goboot.
@@ -19,22 +21,66 @@ type ServiceConfig interface {
 	// ReadConfig loads the configuration from a source file.
 	ReadConfig(confPath string, repoURL string) error
 
+	// DecodeConfig loads the configuration from YAML data (e.g., a config recorded in a lock file).
+	DecodeConfig(data []byte, repoURL string) error
+
 	// Validate verifies that the configuration is complete and semantically correct.
 	//
 	// It returns an error if the configuration is invalid.
 	Validate() error
+
+	// Role returns the phase of a goboot run the service belongs to
+	// (e.g., RoleBootstrap for the service creating the target project).
+	Role() Role
+}
+
+// Role is the phase of a goboot run a service belongs to, declared by its ServiceConfig.
+//
+// Services run phase by phase in the order of Roles; within a phase, their dependencies decide.
+type Role int
+
+const (
+	// RoleBootstrap services create the target project the other services write into (e.g., base_project).
+	RoleBootstrap Role = iota
+	// RoleMain services add their features to the bootstrapped project (e.g., base_lint, base_test).
+	RoleMain
+	// RoleFinalizer services run last and collect the results of the others (e.g., base_local rendering scripts).
+	RoleFinalizer
+)
+
+// Roles returns all roles in the order of their phases.
+func Roles() []Role {
+	return []Role{RoleBootstrap, RoleMain, RoleFinalizer}
+}
+
+// String returns the name of the role (e.g., "bootstrap").
+func (r Role) String() string {
+	switch r {
+	case RoleBootstrap:
+		return "bootstrap"
+	case RoleMain:
+		return "main"
+	case RoleFinalizer:
+		return "finalizer"
+	default:
+		return fmt.Sprintf("Role(%d)", int(r))
+	}
 }
 
 // ServiceConfigMeta represents a declaration of a modular config block to load.
 //
-// It is not the config itself, but a registry of what to load from which file.
+// It is not the config itself, but a registry of where to load it from (see ConfigData).
 type ServiceConfigMeta struct {
 	// ID is the stable identifier for the config module.
 	ID string `yaml:"id"` // e.g., "base_project"
 	// ConfPath is the path to the config file to load.
 	ConfPath string `yaml:"confPath"` // e.g., "./configs/base_project.yml"
+	// Config is an inline config block, as an alternative to ConfPath.
+	Config yaml.Node `yaml:"config,omitempty"`
 	// Enabled indicates whether the service should be enabled.
 	Enabled bool `yaml:"enabled"`
+	// Plugin is the path to an executable implementing the service instead of a built-in service (see PluginConfig).
+	Plugin string `yaml:"plugin,omitempty"` // e.g., "./bin/goboot-acme"
 }
 
 // IsEnabled returns the enabled state.
@@ -42,72 +88,149 @@ func (scm *ServiceConfigMeta) IsEnabled() bool {
 	return scm.Enabled
 }
 
+// HasInlineConfig reports whether an inline config block is declared.
+func (scm *ServiceConfigMeta) HasInlineConfig() bool {
+	return scm.Config.Kind != 0
+}
+
+// IsPlugin reports whether the service is implemented by a plugin executable.
+func (scm *ServiceConfigMeta) IsPlugin() bool {
+	return strings.TrimSpace(scm.Plugin) != ""
+}
+
+// ConfigData returns the raw YAML config of the service, taken from (in this order):
+//   - the inline config block
+//   - the file at ConfPath
+//   - the built-in defaults of the service in factories, or no config for plugins
+func (scm *ServiceConfigMeta) ConfigData(factories ServiceConfigFactories) ([]byte, error) {
+	switch {
+	case scm.HasInlineConfig():
+		if scm.Config.Kind != yaml.MappingNode {
+			return nil, fmt.Errorf("inline config of %q must be a mapping", scm.ID)
+		}
+
+		data, err := yaml.Marshal(&scm.Config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode inline config of %q: %w", scm.ID, err)
+		}
+
+		return data, nil
+	case strings.TrimSpace(scm.ConfPath) != "":
+		return readYMLFile(scm.ConfPath)
+	case scm.IsPlugin():
+		return nil, nil
+	default:
+		return factories.defaults(scm.ID)
+	}
+}
+
+// source describes where ConfigData takes the config from (e.g., the path of the file), for error messages.
+func (scm *ServiceConfigMeta) source() string {
+	switch {
+	case scm.HasInlineConfig():
+		return "inline config"
+	case strings.TrimSpace(scm.ConfPath) != "":
+		return scm.ConfPath
+	case scm.IsPlugin():
+		return "no config"
+	default:
+		return "built-in defaults"
+	}
+}
+
+// newConfig returns an empty config of the declared service, or nil for services unknown to factories.
+func (scm *ServiceConfigMeta) newConfig(factories ServiceConfigFactories, projectName string) ServiceConfig {
+	if scm.IsPlugin() {
+		return newPluginConfig(scm.ID, scm.Plugin, projectName)
+	}
+
+	return factories.newConfig(scm.ID, projectName)
+}
+
 // Manager provides centralized registration and retrieval of modular ServiceConfig implementations.
 //
 // It allows goboot to dynamically register, validate, and access multiple configuration modules
-// without hard-coding their types or structure.
+// without hard-coding their types or structure: each config declares its Role, and the manager
+// iterates over them phase by phase (see ConfigsByRole).
 //
 // This supports future extensibility as new config types are introduced.
 type Manager struct {
-	services   map[string]ServiceConfig
-	registrars map[string]ServiceConfig
+	// configs maps service IDs to their registered config.
+	configs map[string]ServiceConfig
+
+	// order keeps the service IDs in registration order, to iterate deterministically.
+	order []string
 }
 
 // NewConfigManager returns a new instance of Manager with an initialized internal registry.
 func NewConfigManager() *Manager {
 	return &Manager{
-		services:   make(map[string]ServiceConfig),
-		registrars: make(map[string]ServiceConfig),
+		configs: make(map[string]ServiceConfig),
 	}
 }
 
-// Register adds a ServiceConfig to the manager after validating it.
+// Register adds a ServiceConfig to the manager after validating it and its role.
 //
+// A config registered again under the same ID replaces the previous one, keeping its position.
 // If validation fails, the configuration is not registered and an error is returned.
+// Configs built in code get the current version (see ConfigVersion), as configs read from files are migrated to it.
 func (cm *Manager) Register(cfg ServiceConfig) error {
+	if !slices.Contains(Roles(), cfg.Role()) {
+		return fmt.Errorf("failed to validate config: unknown role %s of %q", cfg.Role(), cfg.ID())
+	}
+
+	if stamper, ok := cfg.(versionStamper); ok {
+		stamper.stampVersion()
+	}
+
 	err := cfg.Validate()
 	if err != nil {
 		return fmt.Errorf("failed to validate config: %w", err)
 	}
 
-	switch cfg.ID() {
-	case goboottypes.ServiceNameBaseProject:
-		cm.registrars[cfg.ID()] = cfg
-	default:
-		cm.services[cfg.ID()] = cfg
+	_, exists := cm.configs[cfg.ID()]
+	if !exists {
+		cm.order = append(cm.order, cfg.ID())
 	}
 
+	cm.configs[cfg.ID()] = cfg
+
 	return nil
 }
 
-// UnregisterService removes a registered ServiceConfig by its ID.
+// Unregister removes a registered ServiceConfig by its ID.
 //
 // No-op if the ID is not found.
-func (cm *Manager) UnregisterService(id string) {
-	delete(cm.services, id)
-}
+func (cm *Manager) Unregister(id string) {
+	_, exists := cm.configs[id]
+	if !exists {
+		return
+	}
 
-// UnregisterRegistrar removes a registered ServiceConfig by its ID.
-//
-// No-op if the ID is not found.
-func (cm *Manager) UnregisterRegistrar(id string) {
-	delete(cm.registrars, id)
+	delete(cm.configs, id)
+	cm.order = slices.DeleteFunc(cm.order, func(cur string) bool { return cur == id })
 }
 
-// GetService retrieves a registered ServiceConfig by its ID.
+// Get retrieves a registered ServiceConfig by its ID, whatever its role.
 //
 // The second return value indicates whether the config was found.
-func (cm *Manager) GetService(id string) (ServiceConfig, bool) {
-	cfg, ok := cm.services[id]
+func (cm *Manager) Get(id string) (ServiceConfig, bool) {
+	cfg, ok := cm.configs[id]
 
 	return cfg, ok
 }
 
-// GetRegistrar retrieves a registered ServiceConfig by its ID.
+// ConfigsByRole returns the registered configs with the given role, in registration order.
 //
-// The second return value indicates whether the config was found.
-func (cm *Manager) GetRegistrar(id string) (ServiceConfig, bool) {
-	cfg, ok := cm.registrars[id]
+// Ranging over Roles and calling it per role visits the configs phase by phase, as the services run.
+func (cm *Manager) ConfigsByRole(role Role) []ServiceConfig {
+	var configs []ServiceConfig
+
+	for _, id := range cm.order {
+		if cm.configs[id].Role() == role {
+			configs = append(configs, cm.configs[id])
+		}
+	}
 
-	return cfg, ok
+	return configs
 }
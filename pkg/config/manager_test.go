@@ -13,6 +13,7 @@ import (
 // Mock ServiceConfig for testing.
 type mockServiceConfig struct {
 	id          string
+	role        config.Role
 	shouldError bool
 }
 
@@ -28,6 +29,14 @@ func (m *mockServiceConfig) ReadConfig(_, _ string) error {
 	return nil
 }
 
+func (m *mockServiceConfig) DecodeConfig(_ []byte, _ string) error {
+	if m.shouldError {
+		return errors.New("mock decode error")
+	}
+
+	return nil
+}
+
 func (m *mockServiceConfig) Validate() error {
 	if m.shouldError {
 		return errors.New("mock validation error")
@@ -36,6 +45,10 @@ func (m *mockServiceConfig) Validate() error {
 	return nil
 }
 
+func (m *mockServiceConfig) Role() config.Role {
+	return m.role
+}
+
 var _ = Describe("Config Manager", func() {
 	var manager *config.Manager
 
@@ -75,158 +88,115 @@ var _ = Describe("Config Manager", func() {
 			})
 		})
 
-		Context("when registering base_project service", func() {
-			It("stores it as a registrar", func() {
-				mockCfg := &mockServiceConfig{
-					id:          goboottypes.ServiceNameBaseProject,
-					shouldError: false,
-				}
+		Context("with an unknown role", func() {
+			It("returns an error and does not register", func() {
+				err := manager.Register(&mockServiceConfig{id: "odd_service", role: config.Role(7)})
+				Expect(err).To(MatchError(`failed to validate config: unknown role Role(7) of "odd_service"`))
 
-				err := manager.Register(mockCfg)
-				Expect(err).NotTo(HaveOccurred())
+				_, ok := manager.Get("odd_service")
+				Expect(ok).To(BeFalse())
+			})
+		})
 
-				// Should be retrievable as registrar
-				retrieved, ok := manager.GetRegistrar(goboottypes.ServiceNameBaseProject)
-				Expect(ok).To(BeTrue())
-				Expect(retrieved).To(Equal(mockCfg))
+		Context("with a config built in code", func() {
+			It("sets the current config version", func() {
+				cfg := &config.BaseLocalConfig{ProjectName: "demo", FileList: []string{"Makefile"}}
 
-				_, ok = manager.GetService(goboottypes.ServiceNameBaseProject)
-				Expect(ok).To(BeFalse())
+				Expect(manager.Register(cfg)).To(Succeed())
+				Expect(cfg.Version).To(Equal(config.ConfigVersion))
 			})
+		})
 
-			It("overwrites existing registrar entries on re-registration", func() {
+		Context("when re-registering a config", func() {
+			It("replaces the config and keeps its position", func() {
 				first := &mockServiceConfig{id: goboottypes.ServiceNameBaseProject}
 				second := &mockServiceConfig{id: goboottypes.ServiceNameBaseProject}
 
 				Expect(manager.Register(first)).To(Succeed())
+				Expect(manager.Register(&mockServiceConfig{id: "other_service"})).To(Succeed())
 				Expect(manager.Register(second)).To(Succeed())
 
-				retrieved, ok := manager.GetRegistrar(goboottypes.ServiceNameBaseProject)
-				Expect(ok).To(BeTrue())
-				Expect(retrieved).To(Equal(second))
-			})
-		})
-
-		Context("when registering other services", func() {
-			It("stores them as regular services", func() {
-				mockCfg := &mockServiceConfig{
-					id:          "other_service",
-					shouldError: false,
-				}
-
-				err := manager.Register(mockCfg)
-				Expect(err).NotTo(HaveOccurred())
-
-				// Should be retrievable as service
-				retrieved, ok := manager.GetService("other_service")
+				retrieved, ok := manager.Get(goboottypes.ServiceNameBaseProject)
 				Expect(ok).To(BeTrue())
-				Expect(retrieved).To(Equal(mockCfg))
+				Expect(retrieved).To(BeIdenticalTo(second))
+				Expect(manager.ConfigsByRole(config.RoleBootstrap)).To(
+					HaveExactElements(BeIdenticalTo(second), HaveField("ID()", "other_service")))
 			})
 		})
 	})
 
-	Describe("GetService", func() {
-		Context("when service exists", func() {
-			It("returns the service and true", func() {
-				mockCfg := &mockServiceConfig{
-					id:          "existing_service",
-					shouldError: false,
+	Describe("Get", func() {
+		Context("when the config exists", func() {
+			It("returns the config and true, whatever its role", func() {
+				for _, role := range config.Roles() {
+					id := "service_" + role.String()
+					Expect(manager.Register(&mockServiceConfig{id: id, role: role})).To(Succeed())
+
+					retrieved, ok := manager.Get(id)
+					Expect(ok).To(BeTrue())
+					Expect(retrieved.ID()).To(Equal(id))
+					Expect(retrieved.Role()).To(Equal(role))
 				}
-				Expect(manager.Register(mockCfg)).To(Succeed())
-
-				retrieved, ok := manager.GetService("existing_service")
-				Expect(ok).To(BeTrue())
-				Expect(retrieved).NotTo(BeNil())
-				Expect(retrieved.ID()).To(Equal("existing_service"))
 			})
 		})
 
-		Context("when service does not exist", func() {
+		Context("when the config does not exist", func() {
 			It("returns nil and false", func() {
-				retrieved, ok := manager.GetService("nonexistent")
+				retrieved, ok := manager.Get("nonexistent")
 				Expect(ok).To(BeFalse())
 				Expect(retrieved).To(BeNil())
 			})
 		})
 	})
 
-	Describe("GetRegistrar", func() {
-		Context("when registrar exists", func() {
-			It("returns the registrar and true", func() {
-				mockCfg := &mockServiceConfig{
-					id:          goboottypes.ServiceNameBaseProject,
-					shouldError: false,
-				}
-				Expect(manager.Register(mockCfg)).To(Succeed())
+	Describe("Unregister", func() {
+		It("removes the config from the registry", func() {
+			Expect(manager.Register(&mockServiceConfig{id: "service_to_remove"})).To(Succeed())
+			Expect(manager.Register(&mockServiceConfig{id: "service_to_keep"})).To(Succeed())
 
-				retrieved, ok := manager.GetRegistrar(goboottypes.ServiceNameBaseProject)
-				Expect(ok).To(BeTrue())
-				Expect(retrieved).NotTo(BeNil())
-			})
-		})
+			manager.Unregister("service_to_remove")
 
-		Context("when registrar does not exist", func() {
-			It("returns nil and false", func() {
-				retrieved, ok := manager.GetRegistrar("nonexistent")
-				Expect(ok).To(BeFalse())
-				Expect(retrieved).To(BeNil())
-			})
-		})
-	})
-
-	Describe("UnregisterService", func() {
-		It("removes the service from the registry", func() {
-			mockCfg := &mockServiceConfig{
-				id:          "service_to_remove",
-				shouldError: false,
-			}
-			Expect(manager.Register(mockCfg)).To(Succeed())
-
-			// Verify it exists
-			_, exist := manager.GetService("service_to_remove")
-			Expect(exist).To(BeTrue())
-
-			// Unregister it
-			manager.UnregisterService("service_to_remove")
-
-			// Verify it's gone
-			_, exist = manager.GetService("service_to_remove")
+			_, exist := manager.Get("service_to_remove")
 			Expect(exist).To(BeFalse())
+			Expect(manager.ConfigsByRole(config.RoleBootstrap)).To(HaveExactElements(HaveField("ID()", "service_to_keep")))
 		})
 
-		It("is a no-op if service doesn't exist", func() {
-			// Should not panic or error
+		It("is a no-op if the config doesn't exist", func() {
 			Expect(func() {
-				manager.UnregisterService("nonexistent")
+				manager.Unregister("nonexistent")
 			}).NotTo(Panic())
 		})
 	})
 
-	Describe("UnregisterRegistrar", func() {
-		It("removes the registrar from the registry", func() {
-			mockCfg := &mockServiceConfig{
-				id:          goboottypes.ServiceNameBaseProject,
-				shouldError: false,
+	Describe("ConfigsByRole", func() {
+		It("returns the configs of a phase in registration order", func() {
+			configs := []*mockServiceConfig{
+				{id: "local", role: config.RoleFinalizer},
+				{id: "lint", role: config.RoleMain},
+				{id: "project", role: config.RoleBootstrap},
+				{id: "test", role: config.RoleMain},
 			}
-			Expect(manager.Register(mockCfg)).To(Succeed())
-
-			// Verify it exists
-			_, exist := manager.GetRegistrar(goboottypes.ServiceNameBaseProject)
-			Expect(exist).To(BeTrue())
 
-			// Unregister it
-			manager.UnregisterRegistrar(goboottypes.ServiceNameBaseProject)
+			for _, cfg := range configs {
+				Expect(manager.Register(cfg)).To(Succeed())
+			}
 
-			// Verify it's gone
-			_, exist = manager.GetRegistrar(goboottypes.ServiceNameBaseProject)
-			Expect(exist).To(BeFalse())
+			Expect(manager.ConfigsByRole(config.RoleMain)).To(HaveExactElements(configs[1], configs[3]))
+			Expect(manager.ConfigsByRole(config.RoleBootstrap)).To(HaveExactElements(configs[2]))
+			Expect(manager.ConfigsByRole(config.RoleFinalizer)).To(HaveExactElements(configs[0]))
 		})
+	})
 
-		It("is a no-op if registrar doesn't exist", func() {
-			// Should not panic or error
-			Expect(func() {
-				manager.UnregisterRegistrar("nonexistent")
-			}).NotTo(Panic())
+	Describe("Role", func() {
+		It("names the roles of the built-in configs", func() {
+			Expect(config.Roles()).To(Equal([]config.Role{
+				config.RoleBootstrap, config.RoleMain, config.RoleFinalizer,
+			}))
+			Expect((&config.BaseProjectConfig{}).Role()).To(Equal(config.RoleBootstrap))
+			Expect((&config.BaseLintConfig{}).Role()).To(Equal(config.RoleMain))
+			Expect((&config.BaseTestConfig{}).Role()).To(Equal(config.RoleMain))
+			Expect((&config.BaseLocalConfig{}).Role()).To(Equal(config.RoleFinalizer))
+			Expect(config.RoleFinalizer.String()).To(Equal("finalizer"))
 		})
 	})
 
@@ -0,0 +1,406 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigVersion is the version of the config format read and written by this goboot version.
+//
+// Configs of older versions are migrated when read (see migrations); configs of newer versions are rejected.
+const ConfigVersion = 1
+
+// versionKey is the top-level key holding the version of a config. Configs without it have version 0.
+const versionKey = "version"
+
+// versionLinePattern matches the top-level version line of a config file, capturing everything before its value.
+var versionLinePattern = regexp.MustCompile(`^(` + versionKey + `:[ \t]*)[^\s#]+`)
+
+// versionStamper is implemented by service configs recording the version of their format.
+type versionStamper interface {
+	// stampVersion sets the version of a config built in code, which has none, to ConfigVersion.
+	stampVersion()
+}
+
+// migration upgrades a config from one version of the config format to the next.
+type migration struct {
+	// summary describes the changes of the version (e.g., "add the version field").
+	summary string
+
+	// apply upgrades a config mapping of the given kind (SchemaGoBoot or a service ID) in place.
+	// It is nil if the version changes nothing but the version field.
+	apply func(cfg *yaml.Node, kind string) error
+}
+
+// migrations upgrade configs of version i to version i+1, in order.
+//
+// A change to the shape of a config (e.g., a renamed key) increments ConfigVersion and adds a migration here,
+// so checked-in configs keep loading.
+var migrations = [ConfigVersion]migration{
+	{summary: "add the version field"},
+}
+
+// MigratedFile is a config file checked by MigrateConfigFiles.
+type MigratedFile struct {
+	// Path is the path of the file.
+	Path string
+	// From is the version of the file before the migration; ConfigVersion if the file was up to date.
+	From int
+}
+
+// Migrated reports whether the file was rewritten.
+func (f MigratedFile) Migrated() bool {
+	return f.From < ConfigVersion
+}
+
+// MigrateConfigFiles upgrades the goboot config at path and the service config files it references (confPath)
+// to ConfigVersion in place, keeping their comments.
+//
+// Files needing no more than a new version field are edited line by line, keeping their layout.
+// Other files are re-encoded, which keeps the comments but normalizes blank lines and indentation.
+func (factories ServiceConfigFactories) MigrateConfigFiles(path string) ([]MigratedFile, error) {
+	data, err := readYMLFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	confPaths, err := factories.serviceConfigPaths(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	files := make([]MigratedFile, 0, len(confPaths)+1)
+
+	from, err := migrateConfigFile(path, data, SchemaGoBoot)
+	if err != nil {
+		return nil, err
+	}
+
+	files = append(files, MigratedFile{Path: path, From: from})
+
+	for _, id := range slices.Sorted(maps.Keys(confPaths)) {
+		data, err = readYMLFile(confPaths[id])
+		if err != nil {
+			return nil, fmt.Errorf("failed to read config for %q: %w", id, err)
+		}
+
+		from, err = migrateConfigFile(confPaths[id], data, id)
+		if err != nil {
+			return nil, err
+		}
+
+		files = append(files, MigratedFile{Path: confPaths[id], From: from})
+	}
+
+	return files, nil
+}
+
+// serviceConfigPaths returns the confPath of every known service declared in goboot config data, by service ID,
+// with environment variables expanded if the config opts in (see interpolate).
+func (factories ServiceConfigFactories) serviceConfigPaths(data []byte) (map[string]string, error) {
+	var doc yaml.Node
+
+	err := yaml.Unmarshal(data, &doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+
+	err = interpolate(&doc)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make(map[string]string)
+
+	for _, entry := range sequenceItems(mappingValue(documentRoot(&doc), servicesKey)) {
+		id := scalarValue(mappingValue(entry, "id"))
+		confPath := scalarValue(mappingValue(entry, "confPath"))
+
+		if factories.keys(id) != nil && strings.TrimSpace(confPath) != "" {
+			paths[id] = confPath
+		}
+	}
+
+	return paths, nil
+}
+
+// migrateConfigFile upgrades the config file at path with the given data and kind in place,
+// and returns the version it had before. Up-to-date files are not written.
+func migrateConfigFile(path string, data []byte, kind string) (int, error) {
+	var doc yaml.Node
+
+	err := yaml.Unmarshal(data, &doc)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read %s: failed to unmarshal config: %w", path, err)
+	}
+
+	root := documentRoot(&doc)
+	if root == nil {
+		root = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	}
+
+	versionLine := 0
+	if key := mappingKey(root, versionKey); key != nil {
+		versionLine = key.Line
+	}
+
+	from, err := configVersion(root)
+	if err != nil {
+		return 0, fmt.Errorf("failed to migrate %s: %w", path, err)
+	}
+
+	oldest, err := migrateConfig(root, kind)
+	if err != nil {
+		return 0, fmt.Errorf("failed to migrate %s: %w", path, err)
+	}
+
+	if oldest == ConfigVersion {
+		return oldest, nil
+	}
+
+	out, edited := setVersionLine(data, versionLine)
+	if !edited || oldest < from || changesShape(from) {
+		out, err = encodeYMLDocument(&doc, root)
+		if err != nil {
+			return 0, fmt.Errorf("failed to migrate %s: %w", path, err)
+		}
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to migrate %s: %w", path, err)
+	}
+
+	err = os.WriteFile(path, out, info.Mode().Perm())
+	if err != nil {
+		return 0, fmt.Errorf("failed to migrate %s: %w", path, err)
+	}
+
+	return oldest, nil
+}
+
+// migrateConfig upgrades a config mapping of the given kind (SchemaGoBoot or a service ID) to ConfigVersion
+// and sets its version. It returns the oldest version found, which is ConfigVersion if nothing was migrated.
+//
+// The inline service configs of a goboot config are upgraded along with it; those without a version of their own
+// have the version of the goboot config and are left without a version field.
+func migrateConfig(cfg *yaml.Node, kind string) (int, error) {
+	if cfg.Kind != yaml.MappingNode {
+		return ConfigVersion, nil
+	}
+
+	from, err := configVersion(cfg)
+	if err != nil {
+		return 0, err
+	}
+
+	err = applyMigrations(cfg, kind, from)
+	if err != nil {
+		return 0, err
+	}
+
+	oldest := from
+
+	if kind == SchemaGoBoot {
+		oldest, err = migrateInlineConfigs(cfg, from)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if from < ConfigVersion {
+		setVersion(cfg)
+	}
+
+	return oldest, nil
+}
+
+// migrateInlineConfigs upgrades the inline service configs of a goboot config of version from,
+// and returns the oldest version found. The configs of plugins are passed to them as they are.
+func migrateInlineConfigs(cfg *yaml.Node, from int) (int, error) {
+	oldest := from
+
+	for _, entry := range sequenceItems(mappingValue(cfg, servicesKey)) {
+		id := scalarValue(mappingValue(entry, "id"))
+		inline := mappingValue(entry, "config")
+
+		if inline == nil || isPluginEntry(entry) {
+			continue
+		}
+
+		var err error
+
+		inlineFrom := from
+		if mappingValue(inline, versionKey) == nil {
+			err = applyMigrations(inline, id, from)
+		} else {
+			inlineFrom, err = migrateConfig(inline, id)
+		}
+
+		if err != nil {
+			return 0, fmt.Errorf("failed to migrate inline config for %q: %w", id, err)
+		}
+
+		oldest = min(oldest, inlineFrom)
+	}
+
+	return oldest, nil
+}
+
+// applyMigrations upgrades a config mapping of the given kind from version from to ConfigVersion,
+// without setting its version.
+func applyMigrations(cfg *yaml.Node, kind string, from int) error {
+	for version := from; version < ConfigVersion; version++ {
+		step := migrations[version]
+		if step.apply == nil {
+			continue
+		}
+
+		err := step.apply(cfg, kind)
+		if err != nil {
+			return fmt.Errorf("failed to migrate %s config to version %d (%s): %w", kind, version+1, step.summary, err)
+		}
+	}
+
+	return nil
+}
+
+// changesShape reports whether a migration from version from changes more than the version field.
+func changesShape(from int) bool {
+	return slices.ContainsFunc(migrations[from:], func(m migration) bool {
+		return m.apply != nil
+	})
+}
+
+// configVersion returns the version of a config mapping, which is 0 if it has none.
+func configVersion(cfg *yaml.Node) (int, error) {
+	value := mappingValue(cfg, versionKey)
+	if value == nil {
+		return 0, nil
+	}
+
+	var version int
+
+	err := value.Decode(&version)
+	if err != nil || version < 0 {
+		return 0, fmt.Errorf("line %d: %s must be a non-negative integer", value.Line, versionKey)
+	}
+
+	if version > ConfigVersion {
+		return 0, fmt.Errorf("line %d: config version %d is newer than the supported version %d (upgrade goboot)",
+			value.Line, version, ConfigVersion)
+	}
+
+	return version, nil
+}
+
+// setVersion sets the version of a config mapping to ConfigVersion, adding the version as first key if missing.
+func setVersion(cfg *yaml.Node) {
+	if cfg.Kind != yaml.MappingNode {
+		return
+	}
+
+	value := mappingValue(cfg, versionKey)
+	if value != nil {
+		value.Kind, value.Tag, value.Style, value.Value = yaml.ScalarNode, "!!int", 0, strconv.Itoa(ConfigVersion)
+
+		return
+	}
+
+	cfg.Content = append([]*yaml.Node{
+		{Kind: yaml.ScalarNode, Tag: "!!str", Value: versionKey},
+		{Kind: yaml.ScalarNode, Tag: "!!int", Value: strconv.Itoa(ConfigVersion)},
+	}, cfg.Content...)
+}
+
+// setVersionLine returns the config file data with its version set to ConfigVersion in the given line,
+// or, if line is 0, with a version line added after the header comment of the file.
+//
+// It reports false if the version line cannot be edited (e.g., in a flow mapping).
+func setVersionLine(data []byte, line int) ([]byte, bool) {
+	lines := strings.SplitAfter(string(data), "\n")
+	if line == 0 {
+		return []byte(strings.Join(insertVersionLine(lines), "")), true
+	}
+
+	if line > len(lines) || !versionLinePattern.MatchString(lines[line-1]) {
+		return nil, false
+	}
+
+	lines[line-1] = versionLinePattern.ReplaceAllString(lines[line-1], "${1}"+strconv.Itoa(ConfigVersion))
+
+	return []byte(strings.Join(lines, "")), true
+}
+
+// insertVersionLine returns the lines of a config file with a version line added after its header comment.
+func insertVersionLine(lines []string) []string {
+	// The header comment is the leading comment block followed by a blank line;
+	// comments directly above the first key belong to that key.
+	start := 0
+	if strings.TrimSpace(lines[0]) == "---" {
+		start = 1
+	}
+
+	header := start
+	for header < len(lines) && strings.HasPrefix(lines[header], "#") {
+		header++
+	}
+
+	if header == len(lines) || strings.TrimSpace(lines[header]) != "" {
+		header = start
+	} else {
+		header++
+	}
+
+	added := versionKey + ": " + strconv.Itoa(ConfigVersion) + "\n"
+	if header < len(lines) && strings.TrimSpace(lines[header]) != "" {
+		added += "\n"
+	}
+
+	return slices.Insert(lines, header, added)
+}
+
+// encodeYMLDocument encodes a YAML document with two-space indentation, keeping its comments.
+// An empty document is encoded as its root.
+func encodeYMLDocument(doc, root *yaml.Node) ([]byte, error) {
+	if len(doc.Content) == 0 {
+		doc = root
+	}
+
+	var buf bytes.Buffer
+
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+
+	err := enc.Encode(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode config: %w", err)
+	}
+
+	err = enc.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode config: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// warnMigrated warns that a config of the given kind was migrated from version from in memory only.
+//
+// Without a warn function, the warning is dropped.
+func warnMigrated(warn warnFunc, kind string, from int) {
+	if warn == nil {
+		return
+	}
+
+	warn("%s config has version %d and was migrated to version %d in memory; "+
+		"run \"goboot config migrate\" to update the file", kind, from, ConfigVersion)
+}
@@ -0,0 +1,159 @@
+package config_test
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/it-timo/goboot/pkg/config"
+	"github.com/it-timo/goboot/pkg/goboottypes"
+)
+
+var _ = Describe("Config versions", func() {
+	var tempDir string
+
+	BeforeEach(func() {
+		tempDir = GinkgoT().TempDir()
+	})
+
+	writeFile := func(name, content string) string {
+		path := filepath.Join(tempDir, name)
+		Expect(os.WriteFile(path, []byte(content), 0o644)).To(Succeed())
+
+		return path
+	}
+
+	readFile := func(path string) string {
+		data, err := os.ReadFile(path)
+		Expect(err).NotTo(HaveOccurred())
+
+		return string(data)
+	}
+
+	// writeConfigs writes a goboot.yml without versions, with a service config file and an inline config.
+	writeConfigs := func() (string, string) {
+		localPath := writeFile("base_local.yml", `#  Local tooling.
+
+#  Scripts to generate.
+fileList:
+  - make  #  Makefile only
+`)
+
+		gobootPath := writeFile("goboot.yml", `###############################################################################
+#  Root config
+###############################################################################
+
+#  Project identity
+projectName: mytool
+repoUrl: github.com/acme/mytool
+targetPath: out
+services:
+  - id: base_local
+    confPath: `+localPath+`
+    enabled: true
+  - id: base_test
+    enabled: true
+    config:
+      useStyle: go
+`)
+
+		return gobootPath, localPath
+	}
+
+	It("migrates configs without a version in memory", func() {
+		gobootPath, _ := writeConfigs()
+
+		var logs bytes.Buffer
+
+		gb := config.NewGoBoot(factories, gobootPath)
+		gb.SetLogger(log.New(&logs, "", 0))
+		Expect(gb.Init()).To(Succeed())
+		Expect(gb.Version).To(Equal(config.ConfigVersion))
+
+		Expect(gb.Warnings()).To(Equal([]string{
+			`goboot config has version 0 and was migrated to version 1 in memory; ` +
+				`run "goboot config migrate" to update the file`,
+			`base_local config has version 0 and was migrated to version 1 in memory; ` +
+				`run "goboot config migrate" to update the file`,
+		}))
+		Expect(logs.String()).To(ContainSubstring("[WARN] base_local config has version 0"))
+		Expect(logs.String()).To(ContainSubstring(`loading service config for "base_test"`))
+
+		for _, id := range []string{goboottypes.ServiceNameBaseLocal, goboottypes.ServiceNameBaseTest} {
+			cfg, ok := gb.ConfManager.Get(id)
+			Expect(ok).To(BeTrue(), "service %s", id)
+
+			data, err := yaml.Marshal(cfg)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(HavePrefix("version: " + strconv.Itoa(config.ConfigVersion) + "\n"))
+		}
+	})
+
+	It("rejects newer and malformed versions", func() {
+		newer := strconv.Itoa(config.ConfigVersion + 1)
+
+		cfg := &config.BaseTestConfig{}
+		Expect(cfg.DecodeConfig([]byte("version: "+newer+"\nuseStyle: go\n"), "")).To(MatchError(
+			"line 1: config version " + newer + " is newer than the supported version " +
+				strconv.Itoa(config.ConfigVersion) + " (upgrade goboot)"))
+		Expect(cfg.DecodeConfig([]byte("useStyle: go\nversion: one\n"), "")).To(MatchError(
+			"line 2: version must be a non-negative integer"))
+
+		_, err := factories.MigrateConfigFiles(writeFile("goboot.yml", "version: "+newer+"\nprojectName: mytool\n"))
+		Expect(err).To(MatchError(ContainSubstring("is newer than the supported version")))
+	})
+
+	It("rewrites config files in place, keeping their comments and layout", func() {
+		gobootPath, localPath := writeConfigs()
+		version := "version: " + strconv.Itoa(config.ConfigVersion) + "\n"
+
+		files, err := factories.MigrateConfigFiles(gobootPath)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(files).To(Equal([]config.MigratedFile{{Path: gobootPath, From: 0}, {Path: localPath, From: 0}}))
+		Expect(files[0].Migrated()).To(BeTrue())
+
+		Expect(readFile(gobootPath)).To(HavePrefix(`###############################################################################
+#  Root config
+###############################################################################
+
+` + version + `
+#  Project identity
+projectName: mytool
+`))
+		Expect(readFile(gobootPath)).To(HaveSuffix(`    config:
+      useStyle: go
+`))
+		Expect(readFile(localPath)).To(Equal(`#  Local tooling.
+
+` + version + `
+#  Scripts to generate.
+fileList:
+  - make  #  Makefile only
+`))
+
+		migrated := readFile(gobootPath)
+
+		files, err = factories.MigrateConfigFiles(gobootPath)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(files[0].Migrated()).To(BeFalse())
+		Expect(readFile(gobootPath)).To(Equal(migrated))
+		Expect(config.NewGoBoot(factories, gobootPath).Init()).To(Succeed())
+	})
+
+	It("updates an existing version line, keeping its comment", func() {
+		path := writeFile("goboot.yml", "projectName: mytool\nversion: 0  #  before versioning\ntargetPath: out\n")
+
+		files, err := factories.MigrateConfigFiles(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(files).To(Equal([]config.MigratedFile{{Path: path, From: 0}}))
+		Expect(readFile(path)).To(Equal("projectName: mytool\nversion: " + strconv.Itoa(config.ConfigVersion) +
+			"  #  before versioning\ntargetPath: out\n"))
+	})
+})
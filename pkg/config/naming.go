@@ -0,0 +1,143 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"slices"
+	"strings"
+	"unicode"
+
+	"golang.org/x/mod/module"
+)
+
+// reservedProjectNames are the package names imported next to the project package by the generated code;
+// a project package of the same name would not compile.
+var reservedProjectNames = []string{
+	"config", "main", "errors", "flag", "fmt", "io", "os", "filepath", "strings", "testing", "yaml",
+}
+
+// errInvalidProjectName is returned for project names that cannot be used as Go identifier and package name.
+var errInvalidProjectName = errors.New("must start with a letter and contain only letters and digits")
+
+// ValidateProjectName returns an error if name cannot be used as a Go identifier and package name.
+//
+// The name is rendered into identifiers (e.g., "New{{.PascalProjectName}}Config") and, lowercased,
+// into package and directory names (e.g., "pkg/{{.LowerProjectName}}").
+func ValidateProjectName(name string) error {
+	if !isProjectName(name) {
+		suggestion := pascalCase(projectNameWords(name))
+		if isProjectName(suggestion) {
+			return fmt.Errorf("invalid projectName %q: %w (e.g., %q)", name, errInvalidProjectName, suggestion)
+		}
+
+		return fmt.Errorf("invalid projectName %q: %w", name, errInvalidProjectName)
+	}
+
+	lower := strings.ToLower(name)
+	if token.IsKeyword(lower) || slices.Contains(reservedProjectNames, lower) {
+		return fmt.Errorf("invalid projectName %q: %q is reserved in the generated project", name, lower)
+	}
+
+	return nil
+}
+
+// isProjectName reports whether name is a non-empty ASCII letter followed by ASCII letters and digits.
+func isProjectName(name string) bool {
+	if name == "" {
+		return false
+	}
+
+	for i, r := range name {
+		allowed := unicode.IsLetter(r) || (i > 0 && unicode.IsDigit(r))
+		if r > unicode.MaxASCII || !allowed {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ValidateRepoURL returns an error if the repository path of repoURL (see repoPath) is no valid Go module path.
+func ValidateRepoURL(repoURL string) error {
+	err := module.CheckImportPath(repoPath(repoURL))
+	if err != nil {
+		return fmt.Errorf("invalid repoUrl %q: must be a Go module path: %w", repoURL, err)
+	}
+
+	return nil
+}
+
+// repoPath returns repoURL without its http(s) scheme, the module path of the generated project
+// (e.g., "github.com/user/project" for "https://github.com/user/project").
+func repoPath(repoURL string) string {
+	path := strings.TrimPrefix(repoURL, "https://")
+
+	return strings.TrimPrefix(path, "http://")
+}
+
+// projectNameWords splits a project name into its words, at non-alphanumeric characters
+// and at case changes (e.g., "HTTPServer" into "HTTP" and "Server", "my-tool2" into "my" and "tool2").
+func projectNameWords(name string) []string {
+	var (
+		words []string
+		word  []rune
+	)
+
+	runes := []rune(name)
+
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if len(word) > 0 {
+				words, word = append(words, string(word)), nil
+			}
+
+			continue
+		}
+
+		if len(word) > 0 && unicode.IsUpper(r) {
+			prev := word[len(word)-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+
+			if !unicode.IsUpper(prev) || nextLower {
+				words, word = append(words, string(word)), nil
+			}
+		}
+
+		word = append(word, r)
+	}
+
+	if len(word) > 0 {
+		words = append(words, string(word))
+	}
+
+	return words
+}
+
+// pascalCase joins words with their first letter uppercased (e.g., "MyTool").
+//
+// Leading digits are dropped, as identifiers must start with a letter.
+func pascalCase(words []string) string {
+	var b strings.Builder
+
+	for _, word := range words {
+		if b.Len() == 0 {
+			word = strings.TrimLeftFunc(word, unicode.IsDigit)
+		}
+
+		runes := []rune(word)
+		if len(runes) == 0 {
+			continue
+		}
+
+		runes[0] = unicode.ToUpper(runes[0])
+		b.WriteString(string(runes))
+	}
+
+	return b.String()
+}
+
+// joinLower joins the lowercased words with sep (e.g., "my_tool" for "_").
+func joinLower(words []string, sep string) string {
+	return strings.ToLower(strings.Join(words, sep))
+}
@@ -0,0 +1,67 @@
+package config_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/it-timo/goboot/pkg/config"
+)
+
+var _ = Describe("Project naming", func() {
+	DescribeTable("accepts project names usable as Go identifier and package name",
+		func(name string) {
+			Expect(config.ValidateProjectName(name)).To(Succeed())
+		},
+		Entry("lowercase", "goboot"),
+		Entry("mixed case", "IntroProject"),
+		Entry("digits", "app2go"),
+	)
+
+	DescribeTable("rejects other project names",
+		func(name, message string) {
+			Expect(config.ValidateProjectName(name)).To(MatchError(ContainSubstring(message)))
+		},
+		Entry("empty", "", `invalid projectName "": must start with a letter and contain only letters and digits`),
+		Entry("spaces", "My Project", `(e.g., "MyProject")`),
+		Entry("dashes", "foo-bar", `(e.g., "FooBar")`),
+		Entry("underscores", "foo_bar", `(e.g., "FooBar")`),
+		Entry("leading digit", "2fa", `(e.g., "Fa")`),
+		Entry("non-ASCII letters", "café", "contain only letters and digits"),
+		Entry("Go keywords", "Type", `invalid projectName "Type": "type" is reserved in the generated project`),
+		Entry("generated packages", "Config", `"config" is reserved`),
+		Entry("imported packages", "fmt", `"fmt" is reserved`),
+	)
+
+	DescribeTable("validates the module path of repoUrl",
+		func(repoURL string, valid bool) {
+			err := config.ValidateRepoURL(repoURL)
+			if valid {
+				Expect(err).NotTo(HaveOccurred())
+
+				return
+			}
+
+			Expect(err).To(MatchError(ContainSubstring("invalid repoUrl %q: must be a Go module path", repoURL)))
+		},
+		Entry("https URL", "https://github.com/acme/mytool", true),
+		Entry("http URL", "http://example.com/mytool", true),
+		Entry("module path", "github.com/acme/mytool", true),
+		Entry("local module", "mytool", true),
+		Entry("spaces", "https://github.com/acme/my tool", false),
+		Entry("trailing slash", "https://github.com/acme/mytool/", false),
+		Entry("other scheme", "ssh://git@github.com/acme/mytool", false),
+		Entry("empty element", "github.com//mytool", false),
+	)
+
+	It("validates the project name and repoUrl of the goboot config", func() {
+		gb := config.NewGoBoot(factories, "")
+		gb.ProjectName, gb.RepoURL, gb.TargetPath = "my-tool", "github.com/acme/mytool", "out"
+		Expect(gb.Validate()).To(MatchError(ContainSubstring(`invalid projectName "my-tool"`)))
+
+		gb.ProjectName, gb.RepoURL = "mytool", "github.com/acme/my tool"
+		Expect(gb.Validate()).To(MatchError(ContainSubstring(`invalid repoUrl "github.com/acme/my tool"`)))
+
+		gb.RepoURL = ""
+		Expect(gb.Validate()).To(Succeed())
+	})
+})
@@ -0,0 +1,171 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// override sets a single value of the merged goboot config, addressed by its dotted key path
+// (e.g., "services.base_lint.linters.golang.enabled=false").
+type override struct {
+	// raw is the override as given, for error messages.
+	raw string
+	// path are the keys leading to the value.
+	path []string
+	// value is the value, parsed as YAML (e.g., false, 2026, or [make, task]).
+	value *yaml.Node
+}
+
+// pluginConfigKeys accept any key at any depth, as plugins check their configs themselves (see PluginConfig).
+var pluginConfigKeys = func() *configKeys {
+	keys := &configKeys{}
+	keys.values = keys
+
+	return keys
+}()
+
+// serviceEntryKeys are the keys of a service declaration an override may set directly;
+// all other keys below "services.<id>" address the config of the service.
+var serviceEntryKeys = []string{"enabled"}
+
+// SetOverrides sets values to apply after reading and merging the config files, each as "key.path=value".
+//
+// Keys of service declarations and configs are addressed by the service id,
+// e.g., "services.base_lint.enabled=false" or "services.base_lint.linters.golang.enabled=false".
+// The config keys of plugins are not checked, as the plugins check their configs themselves.
+// Values are parsed as YAML.
+func (gb *GoBoot) SetOverrides(overrides []string) error {
+	parsed := make([]override, 0, len(overrides))
+
+	for _, raw := range overrides {
+		o, err := parseOverride(raw)
+		if err != nil {
+			return err
+		}
+
+		parsed = append(parsed, o)
+	}
+
+	gb.overrides = parsed
+
+	return nil
+}
+
+// parseOverride parses a "key.path=value" override.
+func parseOverride(raw string) (override, error) {
+	keyPath, value, ok := strings.Cut(raw, "=")
+	if !ok {
+		return override{}, fmt.Errorf("invalid override %q: must be key.path=value", raw)
+	}
+
+	path := strings.Split(strings.TrimSpace(keyPath), ".")
+	if slices.Contains(path, "") {
+		return override{}, fmt.Errorf("invalid override %q: empty key in %q", raw, keyPath)
+	}
+
+	var doc yaml.Node
+
+	err := yaml.Unmarshal([]byte(value), &doc)
+	if err != nil {
+		return override{}, fmt.Errorf("invalid override %q: %w", raw, err)
+	}
+
+	node := &yaml.Node{Kind: yaml.ScalarNode}
+	if len(doc.Content) > 0 {
+		node = doc.Content[0]
+	}
+
+	return override{raw: raw, path: path, value: node}, nil
+}
+
+// apply sets the value of the override in the merged goboot config root,
+// checking the keys of service configs against the given factories.
+func (o override) apply(root *yaml.Node, factories ServiceConfigFactories) error {
+	if o.path[0] != servicesKey || len(o.path) == 1 {
+		return o.set(root, gobootKeys, o.path, "")
+	}
+
+	id := o.path[1]
+	entry := serviceEntry(mappingValue(root, servicesKey), id)
+
+	svcKeys := factories.keys(id)
+	if isPluginEntry(entry) {
+		svcKeys = pluginConfigKeys
+	}
+
+	switch {
+	case svcKeys == nil:
+		return fmt.Errorf("invalid override %q: unknown service %q", o.raw, id)
+	case entry == nil:
+		return fmt.Errorf("invalid override %q: service %q is not declared", o.raw, id)
+	}
+
+	path, prefix := o.path[2:], servicesKey+"."+id
+
+	switch {
+	case len(path) == 0:
+		return fmt.Errorf("invalid override %q: missing key below %q", o.raw, prefix)
+	case len(path) == 1 && slices.Contains(serviceEntryKeys, path[0]):
+		return o.set(entry, gobootKeys.fields[servicesKey], path, prefix)
+	}
+
+	if path[0] == "config" {
+		path, prefix = path[1:], prefix+".config"
+	}
+
+	cfg, err := factories.serviceConfigNode(entry)
+	if err != nil {
+		return fmt.Errorf("invalid override %q: %w", o.raw, err)
+	}
+
+	return o.set(cfg, svcKeys, path, prefix)
+}
+
+// set sets the value at path below node, creating missing mappings on the way.
+//
+// Every key must be declared in keys; prefix is the path of node, for error messages.
+func (o override) set(node *yaml.Node, keys *configKeys, path []string, prefix string) error {
+	for i, key := range path {
+		keyPath := joinKey(prefix, strings.Join(path[:i+1], "."))
+
+		nested, known := keys.fields[key]
+		if keys.values != nil {
+			nested, known = keys.values, true
+		}
+
+		if !known {
+			return fmt.Errorf("invalid override %q: %s", o.raw,
+				UnknownKey{Path: keyPath, Suggestion: keys.suggest(key)}.message())
+		}
+
+		if i == len(path)-1 {
+			if nested != nil {
+				unknown := unknownKeys(o.value, nested, keyPath)
+				if len(unknown) > 0 {
+					return fmt.Errorf("invalid override %q: %s", o.raw, unknown[0].message())
+				}
+			}
+
+			setMappingValue(node, key, o.value)
+
+			return nil
+		}
+
+		if nested == nil {
+			return fmt.Errorf("invalid override %q: %q has no nested keys", o.raw, keyPath)
+		}
+
+		child := mappingValue(node, key)
+		if child == nil || child.Kind != yaml.MappingNode {
+			child = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			setMappingValue(node, key, child)
+		}
+
+		node, keys = child, nested
+	}
+
+	return nil
+}
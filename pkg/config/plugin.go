@@ -0,0 +1,147 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// PluginConfig is the config of a service implemented by an external executable (see ServiceConfigMeta.Plugin).
+//
+// goboot does not know the shape of plugin configs: the values are passed to the plugin as they are,
+// and the plugin validates them. They are neither checked for unknown keys nor migrated (see ConfigVersion),
+// but interpolated like every other config (see interpolate).
+type PluginConfig struct {
+	// Plugin is the path to the plugin executable (e.g., "./bin/goboot-acme"), relative to the working directory.
+	// A name without a slash is looked up in PATH.
+	Plugin string `yaml:"-"`
+
+	// Values are the config values passed to the plugin.
+	Values map[string]any `yaml:",inline"`
+
+	// ProjectName is the project name from goboot.yml, passed to the plugin.
+	ProjectName string `yaml:"-"`
+
+	// RepoURL is the repository URL from goboot.yml, passed to the plugin.
+	RepoURL string `yaml:"-"`
+
+	// id is the service ID declared in goboot.yml.
+	id string
+}
+
+// newPluginConfig returns a newly initialized PluginConfig of the given service, plugin, and project name.
+func newPluginConfig(id, plugin, projectName string) *PluginConfig {
+	return &PluginConfig{
+		Plugin:      plugin,
+		Values:      map[string]any{},
+		ProjectName: projectName,
+		id:          id,
+	}
+}
+
+// ID returns the service ID declared in goboot.yml.
+func (pc *PluginConfig) ID() string {
+	return pc.id
+}
+
+// Role returns RoleMain, as plugins add their files to the bootstrapped project.
+func (pc *PluginConfig) Role() Role {
+	return RoleMain
+}
+
+// ReadConfig loads the plugin configuration from the provided YAML file path.
+//
+// It overwrites the current config values with the file contents.
+func (pc *PluginConfig) ReadConfig(confPath string, repoURL string) error {
+	data, err := readYMLFile(confPath)
+	if err != nil {
+		return err
+	}
+
+	return withFile(pc.DecodeConfig(data, repoURL), confPath)
+}
+
+// DecodeConfig loads the plugin configuration from YAML data, which must be a mapping.
+//
+// It overwrites the current config values with the decoded values.
+func (pc *PluginConfig) DecodeConfig(data []byte, repoURL string) error {
+	pc.RepoURL = repoURL
+
+	root, err := parsePluginConfig(data)
+	if err != nil {
+		return err
+	}
+
+	values := map[string]any{}
+
+	err = root.Decode(&values)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+
+	pc.Values = values
+
+	return nil
+}
+
+// parsePluginConfig parses the YAML data of a plugin config into the node of its top-level mapping,
+// which is empty for empty documents. Environment variables are expanded if the config opts in (see interpolate).
+func parsePluginConfig(data []byte) (*yaml.Node, error) {
+	var doc yaml.Node
+
+	err := yaml.Unmarshal(data, &doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+
+	err = interpolate(&doc)
+	if err != nil {
+		return nil, err
+	}
+
+	root := documentRoot(&doc)
+	if root == nil {
+		return &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}, nil
+	}
+
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("line %d: plugin config must be a mapping", root.Line)
+	}
+
+	return root, nil
+}
+
+// isPluginEntry reports whether a service declaration of a goboot config names a plugin executable
+// (see ServiceConfigMeta.IsPlugin).
+func isPluginEntry(entry *yaml.Node) bool {
+	return strings.TrimSpace(scalarValue(mappingValue(entry, "plugin"))) != ""
+}
+
+// Validate verifies that the plugin is executable and its values can be passed to it as JSON.
+func (pc *PluginConfig) Validate() error {
+	var missing []string
+
+	if strings.TrimSpace(pc.ProjectName) == "" {
+		missing = append(missing, "projectName")
+	}
+
+	if strings.TrimSpace(pc.Plugin) == "" {
+		missing = append(missing, "plugin")
+	}
+
+	var pluginErr error
+	if len(missing) == 0 {
+		_, pluginErr = exec.LookPath(pc.Plugin)
+	}
+
+	_, valuesErr := json.Marshal(pc.Values)
+	if valuesErr != nil {
+		valuesErr = fmt.Errorf("must be representable as JSON: %w", valuesErr)
+	}
+
+	return errors.Join(missingFields(missing), fieldErr("plugin", pluginErr), fieldErr("config", valuesErr))
+}
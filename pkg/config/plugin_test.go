@@ -0,0 +1,156 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/it-timo/goboot/pkg/config"
+)
+
+var _ = Describe("PluginConfig", func() {
+	var (
+		tempDir    string
+		configPath string
+	)
+
+	BeforeEach(func() {
+		tempDir = GinkgoT().TempDir()
+		configPath = filepath.Join(tempDir, "goboot.yml")
+	})
+
+	// writeConfig writes a goboot.yml with the given service entries.
+	writeConfig := func(services string) {
+		Expect(os.WriteFile(configPath, []byte(`projectName: mytool
+repoUrl: github.com/acme/mytool
+targetPath: `+tempDir+`
+services:
+`+services), 0o644)).To(Succeed())
+	}
+
+	It("loads inline and confPath configs of plugins", func() {
+		Expect(os.WriteFile(filepath.Join(tempDir, "acme.yml"), []byte("holder: ACME\nyears: [2025, 2026]\n"),
+			0o644)).To(Succeed())
+		writeConfig(`  - id: acme_license
+    plugin: sh
+    enabled: true
+    confPath: ` + filepath.Join(tempDir, "acme.yml") + `
+  - id: acme_ci
+    plugin: sh
+    enabled: true
+    config:
+      provider: github
+  - id: acme_empty
+    plugin: sh
+    enabled: true
+`)
+
+		gb := config.NewGoBoot(factories, configPath)
+		Expect(gb.Init()).To(Succeed())
+
+		cfg, ok := gb.ConfManager.Get("acme_license")
+		Expect(ok).To(BeTrue())
+		Expect(cfg.Role()).To(Equal(config.RoleMain))
+
+		pluginCfg, ok := cfg.(*config.PluginConfig)
+		Expect(ok).To(BeTrue())
+		Expect(pluginCfg.Plugin).To(Equal("sh"))
+		Expect(pluginCfg.ProjectName).To(Equal("mytool"))
+		Expect(pluginCfg.RepoURL).To(Equal("github.com/acme/mytool"))
+		Expect(pluginCfg.Values).To(Equal(map[string]any{"holder": "ACME", "years": []any{2025, 2026}}))
+
+		cfg, ok = gb.ConfManager.Get("acme_ci")
+		Expect(ok).To(BeTrue())
+		Expect(cfg.(*config.PluginConfig).Values).To(Equal(map[string]any{"provider": "github"}))
+
+		cfg, ok = gb.ConfManager.Get("acme_empty")
+		Expect(ok).To(BeTrue())
+		Expect(cfg.(*config.PluginConfig).Values).To(BeEmpty())
+	})
+
+	It("applies overrides to plugin configs without checking their keys", func() {
+		Expect(os.WriteFile(filepath.Join(tempDir, "acme.yml"), []byte("holder: ACME\nyears: [2025]\n"),
+			0o644)).To(Succeed())
+		writeConfig(`  - id: acme_license
+    plugin: sh
+    enabled: true
+    confPath: ` + filepath.Join(tempDir, "acme.yml") + `
+  - id: acme_ci
+    plugin: sh
+    enabled: true
+`)
+
+		gb := config.NewGoBoot(factories, configPath)
+		Expect(gb.SetOverrides([]string{
+			"services.acme_license.years=[2026]",
+			"services.acme_ci.config.provider.name=github",
+		})).To(Succeed())
+		Expect(gb.Init()).To(Succeed())
+
+		cfg, ok := gb.ConfManager.Get("acme_license")
+		Expect(ok).To(BeTrue())
+		Expect(cfg.(*config.PluginConfig).Values).To(Equal(map[string]any{"holder": "ACME", "years": []any{2026}}))
+
+		cfg, ok = gb.ConfManager.Get("acme_ci")
+		Expect(ok).To(BeTrue())
+		Expect(cfg.(*config.PluginConfig).Values).To(Equal(map[string]any{"provider": map[string]any{"name": "github"}}))
+
+		gb = config.NewGoBoot(factories, configPath)
+		Expect(gb.SetOverrides([]string{"services.acme_ci.enabled=false"})).To(Succeed())
+		Expect(gb.Init()).To(Succeed())
+
+		_, ok = gb.ConfManager.Get("acme_ci")
+		Expect(ok).To(BeFalse())
+	})
+
+	It("returns error when the plugin is not executable", func() {
+		writeConfig(`  - id: acme_license
+    plugin: ` + filepath.Join(tempDir, "missing") + `
+    enabled: true
+`)
+
+		err := config.NewGoBoot(factories, configPath).Init()
+		Expect(err).To(MatchError(ContainSubstring("acme_license.plugin (no config):")))
+		Expect(err).To(MatchError(ContainSubstring("no such file or directory")))
+	})
+
+	It("returns error when the config is not a mapping", func() {
+		writeConfig(`  - id: acme_license
+    plugin: sh
+    enabled: true
+    config: [holder]
+`)
+
+		Expect(config.NewGoBoot(factories, configPath).Init()).To(MatchError(ContainSubstring("must be a mapping")))
+	})
+
+	It("rejects plugins using the ID of a built-in service", func() {
+		writeConfig(`  - id: base_lint
+    plugin: sh
+    enabled: true
+`)
+
+		Expect(config.NewGoBoot(factories, configPath).Init()).To(MatchError(ContainSubstring(
+			"plugin services must not use the ID of a built-in service: base_lint")))
+	})
+
+	It("loads recorded plugin configs and declares the plugin", func() {
+		gb := config.NewGoBoot(factories, configPath)
+		gb.ProjectName = "recorded"
+
+		Expect(gb.LoadPluginConfig("acme_license", "sh", []byte("holder: ACME\n"))).To(Succeed())
+		Expect(gb.Services).To(Equal([]config.ServiceConfigMeta{
+			{ID: "acme_license", Plugin: "sh", Enabled: true},
+		}))
+		Expect(gb.Services[0].IsPlugin()).To(BeTrue())
+
+		cfg, ok := gb.ConfManager.Get("acme_license")
+		Expect(ok).To(BeTrue())
+		Expect(cfg.(*config.PluginConfig).ProjectName).To(Equal("recorded"))
+
+		Expect(gb.LoadPluginConfig("acme_ci", "sh", []byte("[oops"))).To(MatchError(ContainSubstring(
+			`failed to decode config for "acme_ci"`)))
+	})
+})
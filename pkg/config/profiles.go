@@ -0,0 +1,77 @@
+package config
+
+import (
+	"embed"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/it-timo/goboot/pkg/goboottypes"
+
+	"gopkg.in/yaml.v3"
+)
+
+// profileKey is the key of goboot.yml selecting a config profile (see GoBoot.Profile).
+const profileKey = "profile"
+
+// profileConfigs holds the goboot config layer of every built-in profile, named after the profile (e.g., "oss.yml").
+//
+//go:embed profiles/*.yml
+var profileConfigs embed.FS
+
+// profileLayer returns the goboot config layer of the named profile,
+// with the service configs merged onto the built-in defaults of their service.
+func (factories ServiceConfigFactories) profileLayer(name string) (*yaml.Node, error) {
+	if !slices.Contains(goboottypes.Profiles(), name) {
+		return nil, fmt.Errorf("unknown profile %q (must be one of: %s)", name, strings.Join(goboottypes.Profiles(), ", "))
+	}
+
+	data, err := profileConfigs.ReadFile(path.Join("profiles", name+".yml"))
+	if err != nil {
+		return nil, fmt.Errorf("no built-in config for profile %q: %w", name, err)
+	}
+
+	root, err := parseYMLConfig(data, SchemaGoBoot, gobootKeys, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode profile %q: %w", name, err)
+	}
+
+	for _, entry := range sequenceItems(mappingValue(root, servicesKey)) {
+		cfg := mappingValue(entry, "config")
+		if cfg == nil {
+			continue
+		}
+
+		deleteMappingValue(entry, "config")
+
+		defaults, err := factories.serviceConfigNode(entry)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode profile %q: %w", name, err)
+		}
+
+		mergeValue(defaults, cfg)
+	}
+
+	return root, nil
+}
+
+// selectedProfile returns the profile selected by the last layer or override setting it, or an empty string.
+func selectedProfile(layers []*yaml.Node, overrides []override) string {
+	profile := ""
+
+	for _, layer := range layers {
+		value := mappingValue(layer, profileKey)
+		if value != nil {
+			profile = scalarValue(value)
+		}
+	}
+
+	for _, o := range overrides {
+		if len(o.path) == 1 && o.path[0] == profileKey {
+			profile = scalarValue(o.value)
+		}
+	}
+
+	return strings.TrimSpace(profile)
+}
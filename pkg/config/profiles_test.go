@@ -0,0 +1,131 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/it-timo/goboot/pkg/config"
+	"github.com/it-timo/goboot/pkg/goboottypes"
+)
+
+var _ = Describe("Config profiles", func() {
+	var tempDir string
+
+	BeforeEach(func() {
+		tempDir = GinkgoT().TempDir()
+	})
+
+	// load initializes a goboot.yml with the given profile and extra content.
+	load := func(profile, extra string, overrides ...string) (*config.GoBoot, error) {
+		path := filepath.Join(tempDir, "goboot.yml")
+		Expect(os.WriteFile(path, []byte(`projectName: mytool
+repoUrl: https://github.com/acme/mytool
+targetPath: out
+profile: `+profile+"\n"+extra), 0o644)).To(Succeed())
+
+		gb := config.NewGoBoot(factories, path)
+		Expect(gb.SetOverrides(overrides)).To(Succeed())
+
+		return gb, gb.Init()
+	}
+
+	service := func(gb *config.GoBoot, id string) config.ServiceConfig {
+		cfg, ok := gb.ConfManager.Get(id)
+		Expect(ok).To(BeTrue(), "service %s", id)
+
+		return cfg
+	}
+
+	enabledLinters := func(gb *config.GoBoot) []string {
+		var names []string
+
+		for name, linter := range service(gb, goboottypes.ServiceNameBaseLint).(*config.BaseLintConfig).Linters {
+			if linter.Enabled {
+				names = append(names, name)
+			}
+		}
+
+		return names
+	}
+
+	It("loads and validates every profile with all services enabled", func() {
+		for _, profile := range goboottypes.Profiles() {
+			gb, err := load(profile, "")
+			Expect(err).NotTo(HaveOccurred(), "profile %s", profile)
+			Expect(gb.Profile).To(Equal(profile))
+
+			for _, id := range []string{
+				goboottypes.ServiceNameBaseProject, goboottypes.ServiceNameBaseLint,
+				goboottypes.ServiceNameBaseTest, goboottypes.ServiceNameBaseLocal,
+			} {
+				service(gb, id)
+			}
+		}
+	})
+
+	It("bundles the service configs and template overlays of each profile", func() {
+		gb, err := load(goboottypes.ProfileMinimal, "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(enabledLinters(gb)).To(ConsistOf(goboottypes.LinterGo))
+		Expect(service(gb, goboottypes.ServiceNameBaseTest).(*config.BaseTestConfig).UseStyle).To(Equal("go"))
+		Expect(service(gb, goboottypes.ServiceNameBaseLocal).(*config.BaseLocalConfig).FileList).To(
+			Equal([]string{goboottypes.ScriptNameMake}))
+
+		gb, err = load(goboottypes.ProfileStandard, "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(enabledLinters(gb)).To(HaveLen(len(config.LinterNames())))
+		Expect(service(gb, goboottypes.ServiceNameBaseTest).(*config.BaseTestConfig).UseStyle).To(Equal("ginkgo"))
+
+		gb, err = load(goboottypes.ProfileEnterprise, "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(service(gb, goboottypes.ServiceNameBaseProject).(*config.BaseProjectConfig).SourcePath).To(
+			Equal(goboottypes.BuiltinPrefix + goboottypes.TemplateSetProjectEnterprise))
+
+		gb, err = load(goboottypes.ProfileOSS, "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(service(gb, goboottypes.ServiceNameBaseProject).(*config.BaseProjectConfig).SourcePath).To(
+			Equal(goboottypes.BuiltinPrefix + goboottypes.TemplateSetProjectOSS))
+	})
+
+	It("merges goboot.yml and overrides onto the profile", func() {
+		gb, err := load(goboottypes.ProfileMinimal, `services:
+  - id: base_local
+    enabled: false
+  - id: base_lint
+    config:
+      linters:
+        yaml:
+          enabled: true
+  - id: base_project
+    config:
+      author: Jane
+`, "services.base_test.useStyle=ginkgo")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(enabledLinters(gb)).To(ConsistOf(goboottypes.LinterGo, goboottypes.LinterYAML))
+		Expect(service(gb, goboottypes.ServiceNameBaseTest).(*config.BaseTestConfig).UseStyle).To(Equal("ginkgo"))
+		Expect(service(gb, goboottypes.ServiceNameBaseProject).(*config.BaseProjectConfig).Author).To(Equal("Jane"))
+
+		_, ok := gb.ConfManager.Get(goboottypes.ServiceNameBaseLocal)
+		Expect(ok).To(BeFalse())
+	})
+
+	It("selects the profile with an override", func() {
+		gb, err := load("", "", "profile=minimal")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(gb.Profile).To(Equal(goboottypes.ProfileMinimal))
+		Expect(enabledLinters(gb)).To(ConsistOf(goboottypes.LinterGo))
+	})
+
+	It("rejects unknown profiles", func() {
+		_, err := load("huge", "")
+		Expect(err).To(MatchError(ContainSubstring(`unknown profile "huge" (must be one of: minimal, standard, enterprise, oss)`)))
+
+		gb := config.NewGoBoot(factories, "")
+		gb.ProjectName, gb.TargetPath, gb.Profile = "mytool", "out", "huge"
+		Expect(gb.Validate()).To(MatchError(ContainSubstring(`unknown profile "huge"`)))
+	})
+})
@@ -0,0 +1,310 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/it-timo/goboot/pkg/goboottypes"
+)
+
+// SchemaGoBoot is the schema name of the goboot config (goboot.yml).
+// The schemas of the service configs are named after their service IDs (see ServiceConfigFactories.SchemaNames).
+const SchemaGoBoot = "goboot"
+
+// jsonSchemaDialect is the JSON Schema dialect of all schemas.
+const jsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// nonBlank is the pattern of required strings, which must not be empty or whitespace only.
+const nonBlank = `\S`
+
+// Patterns of the versions and release windows of the base project config (see validateVersions).
+const (
+	goVersionSchema     = `^(auto|[1-9]\d*\.\d+(\.\d+)?((rc|beta)\d+)?)$`
+	releaseWindowSchema = `^(Q[1-4]|H[12]) \d{4}$`
+	releaseYearSchema   = `^\d{4}$`
+	nodeVersionSchema   = `^(v?\d+(\.\d+){0,2}|lts/(\*|[a-z]+)|node)$`
+)
+
+// Schema is a JSON Schema document, limited to the keywords used for the goboot configs.
+//
+// The schemas are built explicitly per config (see ADR-002) and kept in sync with the
+// Validate methods and the accepted config keys by tests.
+type Schema struct {
+	Dialect              string              `json:"$schema,omitempty"`
+	Title                string              `json:"title,omitempty"`
+	Description          string              `json:"description,omitempty"`
+	Type                 string              `json:"type,omitempty"`
+	Const                string              `json:"const,omitempty"`
+	Enum                 []string            `json:"enum,omitempty"`
+	Pattern              string              `json:"pattern,omitempty"`
+	Default              any                 `json:"default,omitempty"`
+	Properties           map[string]*Schema  `json:"properties,omitempty"`
+	AdditionalProperties any                 `json:"additionalProperties,omitempty"`
+	Required             []string            `json:"required,omitempty"`
+	DependentRequired    map[string][]string `json:"dependentRequired,omitempty"`
+	Items                *Schema             `json:"items,omitempty"`
+	Minimum              *int                `json:"minimum,omitempty"`
+	Maximum              *int                `json:"maximum,omitempty"`
+	MinItems             int                 `json:"minItems,omitempty"`
+	UniqueItems          bool                `json:"uniqueItems,omitempty"`
+	AllOf                []*Schema           `json:"allOf,omitempty"`
+	If                   *Schema             `json:"if,omitempty"`
+	Then                 *Schema             `json:"then,omitempty"`
+	Else                 *Schema             `json:"else,omitempty"`
+	Not                  *Schema             `json:"not,omitempty"`
+}
+
+// SchemaNames returns the names of all config schemas: SchemaGoBoot and the service IDs.
+func (factories ServiceConfigFactories) SchemaNames() []string {
+	names := []string{SchemaGoBoot}
+	for _, factory := range factories {
+		names = append(names, factory.ID)
+	}
+
+	return names
+}
+
+// ConfigSchema returns the JSON Schema document of the config with the given name (see SchemaNames).
+func (factories ServiceConfigFactories) ConfigSchema(name string) (*Schema, error) {
+	var schema *Schema
+
+	if name == SchemaGoBoot {
+		schema = factories.gobootSchema()
+	} else {
+		schema = factories.serviceConfigSchema(name)
+	}
+
+	if schema == nil {
+		return nil, fmt.Errorf("unknown config schema %q (must be one of: %s)",
+			name, strings.Join(factories.SchemaNames(), ", "))
+	}
+
+	schema.Dialect = jsonSchemaDialect
+
+	return schema, nil
+}
+
+// serviceConfigSchema returns the schema of the config of the given service, or nil for unknown services.
+func (factories ServiceConfigFactories) serviceConfigSchema(id string) *Schema {
+	factory, ok := factories.factory(id)
+	if !ok {
+		return nil
+	}
+
+	return withVersion(withInterpolate(factory.Schema()))
+}
+
+// gobootSchema returns the schema of GoBoot.
+//
+// Inline service configs are checked against the schema of their service.
+func (factories ServiceConfigFactories) gobootSchema() *Schema {
+	serviceIDs := factories.SchemaNames()[1:]
+	inlineConfigs := make([]*Schema, 0, len(serviceIDs))
+
+	for _, id := range serviceIDs {
+		inlineConfigs = append(inlineConfigs, &Schema{
+			If:   &Schema{Properties: map[string]*Schema{"id": {Const: id}}, Required: []string{"id"}},
+			Then: &Schema{Properties: map[string]*Schema{"config": factories.serviceConfigSchema(id)}},
+		})
+	}
+
+	return withVersion(withInterpolate(object("goboot config",
+		"Root generation config: where to generate the project and which services to run.",
+		map[string]*Schema{
+			"projectName": requiredString("Project name (used in CLI, directory names, package names, ...)."),
+			"repoUrl": text("Repository URL (e.g., \"https://github.com/user/project\"), used by go.mod and lint. " +
+				"Required by services other than base_project, base_lint, and base_test."),
+			"targetPath": requiredString("Directory the project directory is created in."),
+			"conflictPolicy": {
+				Description: "What to do with files that already exist in the target project.",
+				Type:        "string",
+				Enum:        goboottypes.ConflictPolicies(),
+				Default:     goboottypes.DefaultConflictPolicy,
+			},
+			"profile": {
+				Description: "Built-in bundle of services, service configs, and template overlays the config is merged onto.",
+				Type:        "string",
+				Enum:        goboottypes.Profiles(),
+			},
+			"services": {
+				Description: "Services to run, each taking its config from confPath, config, or its built-in defaults.",
+				Type:        "array",
+				Items: &Schema{
+					Type: "object",
+					Properties: map[string]*Schema{
+						"id": {
+							Description: "Service ID; one of the built-in services, or any other ID for a plugin.",
+							Type:        "string",
+						},
+						"confPath": text("Path to the config file of the service."),
+						"config": {
+							Description: "Inline config of the service, replacing its built-in defaults.",
+							Type:        "object",
+						},
+						"enabled": {Description: "Whether the service runs.", Type: "boolean"},
+						"plugin": text("Path to an executable implementing the service, " +
+							"speaking the goboot plugin protocol (see doc/plugins.md)."),
+					},
+					AdditionalProperties: false,
+					Required:             []string{"id"},
+					Not:                  &Schema{Required: []string{"confPath", "config"}},
+					If:                   &Schema{Required: []string{"plugin"}},
+					Then:                 &Schema{Properties: map[string]*Schema{"id": {Not: &Schema{Enum: serviceIDs}}}},
+					Else:                 &Schema{Properties: map[string]*Schema{"id": {Enum: serviceIDs}}},
+					AllOf:                inlineConfigs,
+				},
+			},
+		},
+		"projectName", "targetPath")))
+}
+
+// BaseProjectSchema returns the schema of BaseProjectConfig.
+func BaseProjectSchema() *Schema {
+	schema := object("base_project config", "Project metadata injected into the base project templates.",
+		map[string]*Schema{
+			"sourcePath": sourcePath(goboottypes.TemplateSetProjectBase),
+			"usedGoVersion": matching(goVersionSchema,
+				"Go release (e.g., \"1.25.5\"), or \"auto\" for the local Go toolchain (go env GOVERSION)."),
+			"usedNodeVersion": matching(nodeVersionSchema,
+				"Node.js version for optional tooling, as in .nvmrc (e.g., \"20\" or \"lts/iron\")."),
+			"currentYear": {Description: "Year in LICENSE and NOTICE; defaults to the current year.", Type: "integer"},
+			"releaseCurrentWindow": matching(releaseWindowSchema,
+				"Current roadmap target, a quarter or half of a year (e.g., \"Q2 2026\" or \"H1 2026\")."),
+			"releaseUpcomingWindow": matching(releaseWindowSchema,
+				"Next milestone window, not before releaseCurrentWindow (e.g., \"Q4 2026\")."),
+			"releaseLongTerm": matching(releaseYearSchema,
+				"Long-term goal year, not before releaseUpcomingWindow (e.g., \"2028\")."),
+			"author": requiredString("Project creator or owner, used in LICENSE and NOTICE."),
+			"gitProvider": text("Git provider for badges and links (templates know: " +
+				strings.Join(goboottypes.GitProviders(), ", ") + ")."),
+			"gitUser": text("Git user or organization; required if gitProvider is set."),
+		},
+		"usedGoVersion", "usedNodeVersion", "releaseCurrentWindow", "releaseUpcomingWindow", "releaseLongTerm", "author")
+
+	schema.DependentRequired = map[string][]string{"gitProvider": {"gitUser"}}
+
+	return schema
+}
+
+// BaseLintSchema returns the schema of BaseLintConfig.
+//
+// Linters without a default command are allowed, but need a cmd to run.
+func BaseLintSchema() *Schema {
+	linter := func(description string) *Schema {
+		return object("", description, map[string]*Schema{
+			"cmd":     text("Command to run the linter; defaults to the built-in command of known linters."),
+			"enabled": {Description: "Whether the linter runs.", Type: "boolean"},
+		})
+	}
+
+	linters := make(map[string]*Schema)
+	for _, name := range LinterNames() {
+		linters[name] = linter(fmt.Sprintf("Linter %q with the default command: %s", name, lintCmds[name]))
+	}
+
+	return object("base_lint config", "Linter setup of the project.",
+		map[string]*Schema{
+			"sourcePath": sourcePath(goboottypes.TemplateSetLintBase),
+			"linters": {
+				Description:          "Linters by name.",
+				Type:                 "object",
+				Properties:           linters,
+				AdditionalProperties: linter("Custom linter."),
+			},
+			"allowedPackages": {
+				Description: "Packages allowed to be imported (used by depguard).",
+				Type:        "array",
+				Items:       &Schema{Type: "string"},
+			},
+		})
+}
+
+// BaseTestSchema returns the schema of BaseTestConfig.
+func BaseTestSchema() *Schema {
+	return object("base_test config", "Test setup of the project.",
+		map[string]*Schema{
+			"sourcePath": sourcePath(goboottypes.TemplateSetTestBase),
+			"useStyle": {
+				Description: "Test style.",
+				Type:        "string",
+				Enum:        goboottypes.TestStyles(),
+			},
+			"testCmd": text("Command to run the tests; defaults to: " + goboottypes.DefaultGoTestCMD),
+		},
+		"useStyle")
+}
+
+// BaseLocalSchema returns the schema of BaseLocalConfig.
+func BaseLocalSchema() *Schema {
+	return object("base_local config", "Local tooling of the project.",
+		map[string]*Schema{
+			"sourcePath": sourcePath(goboottypes.TemplateSetLocalBase),
+			"fileList": {
+				Description: "Local tooling to generate.",
+				Type:        "array",
+				Items:       &Schema{Type: "string", Enum: goboottypes.ScriptNames()},
+				MinItems:    1,
+				UniqueItems: true,
+			},
+		},
+		"fileList")
+}
+
+// withInterpolate adds the interpolate switch (see interpolate) to the schema of a config, and returns schema.
+func withInterpolate(schema *Schema) *Schema {
+	schema.Properties[interpolateKey] = &Schema{
+		Description: "Whether ${VAR} and ${VAR:-default} in the values of this config are expanded " +
+			"from environment variables.",
+		Type: "boolean",
+	}
+
+	return schema
+}
+
+// withVersion adds the version of the config format (see ConfigVersion) to the schema of a config, and returns schema.
+func withVersion(schema *Schema) *Schema {
+	minVersion, maxVersion := 0, ConfigVersion
+
+	schema.Properties[versionKey] = &Schema{
+		Description: fmt.Sprintf("Version of the config format; older versions are migrated when read "+
+			"(update the file with \"goboot config migrate\"). Current version: %d.", ConfigVersion),
+		Type:    "integer",
+		Minimum: &minVersion,
+		Maximum: &maxVersion,
+	}
+
+	return schema
+}
+
+// object returns the schema of a mapping with the given properties, rejecting all other keys.
+func object(title, description string, properties map[string]*Schema, required ...string) *Schema {
+	return &Schema{
+		Title:                title,
+		Description:          description,
+		Type:                 "object",
+		Properties:           properties,
+		AdditionalProperties: false,
+		Required:             required,
+	}
+}
+
+// text returns the schema of an optional string.
+func text(description string) *Schema {
+	return &Schema{Description: description, Type: "string"}
+}
+
+// requiredString returns the schema of a string that must not be blank.
+func requiredString(description string) *Schema {
+	return &Schema{Description: description, Type: "string", Pattern: nonBlank}
+}
+
+// matching returns the schema of a required string matching pattern.
+func matching(pattern, description string) *Schema {
+	return &Schema{Description: description, Type: "string", Pattern: pattern}
+}
+
+// sourcePath returns the schema of the sourcePath field of a service config with the given template set.
+func sourcePath(templateSet string) *Schema {
+	return text(fmt.Sprintf("Template source directory, or %q (the default) for the embedded templates.",
+		goboottypes.BuiltinPrefix+templateSet))
+}
@@ -0,0 +1,242 @@
+package config_test
+
+import (
+	"encoding/json"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/it-timo/goboot/pkg/config"
+	"github.com/it-timo/goboot/pkg/goboottypes"
+)
+
+var _ = Describe("Config schemas", func() {
+	const repoURL = "https://github.com/acme/mytool"
+
+	// validateService decodes and validates a fresh service config like GoBoot.Init does.
+	validateService := func(newConfig func() config.ServiceConfig) func(data []byte) error {
+		return func(data []byte) error {
+			cfg := newConfig()
+
+			err := cfg.DecodeConfig(data, repoURL)
+			if err != nil {
+				return err
+			}
+
+			return cfg.Validate()
+		}
+	}
+
+	// samples are valid configs setting every field, encoded from their structs.
+	type sample struct {
+		config   any
+		validate func(data []byte) error
+	}
+
+	samples := map[string]func() sample{
+		config.SchemaGoBoot: func() sample {
+			path := filepath.Join(GinkgoT().TempDir(), "goboot.yml")
+
+			return sample{
+				config: &config.GoBoot{
+					ProjectName: "mytool", RepoURL: repoURL, TargetPath: "out",
+					ConflictPolicy: goboottypes.ConflictPolicyBackup, Services: []config.ServiceConfigMeta{},
+				},
+				validate: func(data []byte) error {
+					Expect(os.WriteFile(path, data, 0o644)).To(Succeed())
+
+					return config.NewGoBoot(factories, path).Init()
+				},
+			}
+		},
+		goboottypes.ServiceNameBaseProject: func() sample {
+			return sample{
+				config: &config.BaseProjectConfig{
+					SourcePath: "builtin:project_base", UsedGoVersion: "1.25.5", UsedNodeVersion: "20", CurrentYear: 2026,
+					ReleaseCurrentWindow: "Q2 2026", ReleaseUpcomingWindow: "Q4 2026", ReleaseLongTerm: "2028",
+					Author: "Jane", GitProvider: goboottypes.GitProviderGitHub, GitUser: "acme",
+				},
+				validate: validateService(func() config.ServiceConfig {
+					return &config.BaseProjectConfig{ProjectName: "mytool"}
+				}),
+			}
+		},
+		goboottypes.ServiceNameBaseLint: func() sample {
+			return sample{
+				config: &config.BaseLintConfig{
+					SourcePath:      "builtin:lint_base",
+					Linters:         map[string]*config.Linter{goboottypes.LinterGo: {Cmd: "golangci-lint run", Enabled: true}},
+					AllowedPackages: []string{"github.com/acme"},
+				},
+				validate: validateService(func() config.ServiceConfig {
+					return &config.BaseLintConfig{ProjectName: "mytool"}
+				}),
+			}
+		},
+		goboottypes.ServiceNameBaseTest: func() sample {
+			return sample{
+				config: &config.BaseTestConfig{SourcePath: "builtin:test_base", UseStyle: "go", TestCMD: "go test ./..."},
+				validate: validateService(func() config.ServiceConfig {
+					return &config.BaseTestConfig{ProjectName: "mytool"}
+				}),
+			}
+		},
+		goboottypes.ServiceNameBaseLocal: func() sample {
+			return sample{
+				config: &config.BaseLocalConfig{SourcePath: "builtin:local_base", FileList: goboottypes.ScriptNames()},
+				validate: validateService(func() config.ServiceConfig {
+					return &config.BaseLocalConfig{ProjectName: "mytool"}
+				}),
+			}
+		},
+	}
+
+	// encode returns the sample config as a YAML mapping opting in to interpolation,
+	// with the given keys replaced or removed (nil value).
+	encode := func(cfg any, changes map[string]any) []byte {
+		data, err := yaml.Marshal(cfg)
+		Expect(err).NotTo(HaveOccurred())
+
+		fields := map[string]any{"interpolate": true}
+		Expect(yaml.Unmarshal(data, &fields)).To(Succeed())
+
+		for key, value := range changes {
+			if value == nil {
+				delete(fields, key)
+			} else {
+				fields[key] = value
+			}
+		}
+
+		data, err = yaml.Marshal(fields)
+		Expect(err).NotTo(HaveOccurred())
+
+		return data
+	}
+
+	It("has a schema for every config", func() {
+		Expect(slices.Sorted(maps.Keys(samples))).To(Equal(slices.Sorted(slices.Values(factories.SchemaNames()))))
+
+		_, err := factories.ConfigSchema("docker")
+		Expect(err).To(MatchError(ContainSubstring(`unknown config schema "docker"`)))
+	})
+
+	for _, name := range factories.SchemaNames() {
+		Describe(name, func() {
+			var (
+				schema *config.Schema
+				cfg    sample
+			)
+
+			BeforeEach(func() {
+				var err error
+
+				schema, err = factories.ConfigSchema(name)
+				Expect(err).NotTo(HaveOccurred())
+
+				cfg = samples[name]()
+			})
+
+			It("is a strict JSON Schema object", func() {
+				data, err := json.Marshal(schema)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(data)).To(HavePrefix(`{"$schema":"https://json-schema.org/draft/2020-12/schema"`))
+
+				Expect(schema.Type).To(Equal("object"))
+				Expect(schema.AdditionalProperties).To(BeFalse())
+			})
+
+			It("has exactly the keys of the config", func() {
+				fields := map[string]any{}
+				Expect(yaml.Unmarshal(encode(cfg.config, nil), &fields)).To(Succeed())
+
+				Expect(slices.Sorted(maps.Keys(schema.Properties))).To(Equal(slices.Sorted(maps.Keys(fields))))
+				Expect(cfg.validate(encode(cfg.config, nil))).To(Succeed())
+			})
+
+			It("requires the fields Validate requires", func() {
+				for key := range schema.Properties {
+					err := cfg.validate(encode(cfg.config, map[string]any{key: nil}))
+
+					dependent := false
+					for _, keys := range schema.DependentRequired {
+						dependent = dependent || slices.Contains(keys, key)
+					}
+
+					if slices.Contains(schema.Required, key) || dependent {
+						Expect(err).To(MatchError(ContainSubstring(key)), "removing %q", key)
+					} else {
+						Expect(err).NotTo(HaveOccurred(), "removing %q", key)
+					}
+				}
+			})
+
+			It("rejects blank required strings like Validate", func() {
+				for key, property := range schema.Properties {
+					if property.Pattern == "" {
+						continue
+					}
+
+					Expect(slices.Contains(schema.Required, key)).To(BeTrue(), "pattern of %q", key)
+					Expect(cfg.validate(encode(cfg.config, map[string]any{key: "  "}))).To(
+						MatchError(ContainSubstring(key)), "blank %q", key)
+				}
+			})
+
+			It("accepts the enum values Validate accepts", func() {
+				for key, property := range schema.Properties {
+					for _, value := range property.Enum {
+						Expect(cfg.validate(encode(cfg.config, map[string]any{key: value}))).To(
+							Succeed(), "%s: %s", key, value)
+					}
+
+					if len(property.Enum) > 0 {
+						Expect(cfg.validate(encode(cfg.config, map[string]any{key: "unknown"}))).To(
+							MatchError(ContainSubstring(key)), "%s: unknown", key)
+					}
+
+					if property.Items != nil && len(property.Items.Enum) > 0 {
+						Expect(cfg.validate(encode(cfg.config, map[string]any{key: property.Items.Enum}))).To(
+							Succeed(), "%s: %v", key, property.Items.Enum)
+					}
+				}
+			})
+		})
+	}
+
+	It("checks inline service configs against the schema of their service", func() {
+		schema, err := factories.ConfigSchema(config.SchemaGoBoot)
+		Expect(err).NotTo(HaveOccurred())
+
+		services := schema.Properties["services"].Items
+		Expect(services.Else.Properties["id"].Enum).To(Equal(factories.SchemaNames()[1:]))
+		Expect(services.Then.Properties["id"].Not.Enum).To(Equal(factories.SchemaNames()[1:]))
+		Expect(services.AllOf).To(HaveLen(len(factories.SchemaNames()) - 1))
+
+		for _, inline := range services.AllOf {
+			id := inline.If.Properties["id"].Const
+
+			serviceSchema, err := factories.ConfigSchema(id)
+			Expect(err).NotTo(HaveOccurred())
+
+			serviceSchema.Dialect = ""
+			Expect(inline.Then.Properties["config"]).To(Equal(serviceSchema))
+		}
+	})
+
+	It("lists the known linters with their default commands", func() {
+		schema, err := factories.ConfigSchema(goboottypes.ServiceNameBaseLint)
+		Expect(err).NotTo(HaveOccurred())
+
+		linters := schema.Properties["linters"]
+		Expect(slices.Sorted(maps.Keys(linters.Properties))).To(Equal(config.LinterNames()))
+		Expect(linters.Properties[goboottypes.LinterGo].Description).To(ContainSubstring(goboottypes.DefaultGoLintCmd))
+		Expect(linters.AdditionalProperties).To(BeAssignableToTypeOf(&config.Schema{}))
+	})
+})
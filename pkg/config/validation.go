@@ -0,0 +1,128 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// errMissingField is the error of a required config field that is missing or blank.
+var errMissingField = errors.New("missing required field")
+
+// FieldError is an invalid value of a config, with the dotted path of its field.
+type FieldError struct {
+	// Path is the dotted path of the field (e.g., "usedGoVersion"). In a ValidationError,
+	// it is prefixed with the service ID (e.g., "base_project.usedGoVersion"),
+	// or the service ID alone if the error concerns no single field.
+	Path string
+	// File is the file the config was read from, or a description of its source (e.g., "inline config").
+	File string
+	// Line is the line of the field in File, or 0 if unknown.
+	Line int
+	// Err is the error of the field.
+	Err error
+}
+
+// Error returns the path with its source, followed by the error
+// (e.g., `base_project.usedGoVersion (configs/base_project.yml): invalid Go version "go1.25"`).
+func (e *FieldError) Error() string {
+	location := e.File
+	if location != "" && e.Line > 0 {
+		location += ":" + strconv.Itoa(e.Line)
+	}
+
+	if location == "" {
+		return e.Path + ": " + e.Err.Error()
+	}
+
+	return fmt.Sprintf("%s (%s): %s", e.Path, location, e.Err)
+}
+
+// Unwrap returns the error of the field.
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// fieldErr returns a FieldError for the field, or nil if err is nil.
+func fieldErr(field string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &FieldError{Path: field, Err: err}
+}
+
+// missingFields returns the joined FieldErrors of the missing required fields, or nil if none are missing.
+func missingFields(fields []string) error {
+	errs := make([]error, 0, len(fields))
+	for _, field := range fields {
+		errs = append(errs, fieldErr(field, errMissingField))
+	}
+
+	return errors.Join(errs...)
+}
+
+// ValidationError aggregates the errors of all invalid service configs, so they can be fixed in one pass.
+type ValidationError struct {
+	// Errors are the errors of all services, in the order the services are declared.
+	Errors []*FieldError
+}
+
+// Error lists every error on its own line.
+func (e *ValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		msgs = append(msgs, "  "+err.Error())
+	}
+
+	return fmt.Sprintf("invalid service configs (%d errors):\n%s", len(e.Errors), strings.Join(msgs, "\n"))
+}
+
+// Unwrap returns the errors of all services.
+func (e *ValidationError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		errs = append(errs, err)
+	}
+
+	return errs
+}
+
+// serviceErrors splits the error of a service config into FieldErrors with paths below the service ID:
+//   - joined errors (see errors.Join) are split up
+//   - FieldErrors and the keys of an UnknownKeysError keep their field path
+//   - any other error is reported for the service as a whole
+func serviceErrors(id, source string, err error) []*FieldError {
+	var joined interface{ Unwrap() []error }
+	if errors.As(err, &joined) {
+		var errs []*FieldError
+		for _, inner := range joined.Unwrap() {
+			errs = append(errs, serviceErrors(id, source, inner)...)
+		}
+
+		return errs
+	}
+
+	var unknown *UnknownKeysError
+	if errors.As(err, &unknown) {
+		errs := make([]*FieldError, 0, len(unknown.Keys))
+		for _, key := range unknown.Keys {
+			msg := "unknown key"
+			if key.Suggestion != "" {
+				msg += fmt.Sprintf(" (did you mean %q?)", key.Suggestion)
+			}
+
+			errs = append(errs, &FieldError{Path: id + "." + key.Path, File: source, Line: key.Line, Err: errors.New(msg)})
+		}
+
+		return errs
+	}
+
+	var field *FieldError
+	if errors.As(err, &field) {
+		return []*FieldError{{Path: id + "." + field.Path, File: source, Line: field.Line, Err: field.Err}}
+	}
+
+	return []*FieldError{{Path: id, File: source, Err: err}}
+}
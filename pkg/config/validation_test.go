@@ -0,0 +1,88 @@
+package config_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/it-timo/goboot/pkg/config"
+)
+
+var _ = Describe("Aggregated validation errors", func() {
+	var tempDir string
+
+	BeforeEach(func() {
+		tempDir = GinkgoT().TempDir()
+	})
+
+	writeFile := func(name, content string) string {
+		path := filepath.Join(tempDir, name)
+		Expect(os.WriteFile(path, []byte(content), 0o644)).To(Succeed())
+
+		return path
+	}
+
+	It("reports the errors of all services at once, with field paths and sources", func() {
+		projectPath := writeFile("base_project.yml", `sourcePath: templates/project_base
+usedGoVersion: "1.25.5"
+usedNodeVersion: "20"
+releaseCurrentWindow: Q5 2026
+releaseUpcomingWindow: Q4 2026
+releaseLongTerm: "2028"
+gitProvider: github
+gitUser: acme
+`)
+
+		err := config.NewGoBoot(factories, writeFile("goboot.yml", `projectName: mytool
+repoUrl: github.com/acme/mytool
+targetPath: out
+services:
+  - id: base_project
+    enabled: true
+    confPath: `+projectPath+`
+  - id: base_test
+    enabled: true
+    config:
+      sourcePath: templates/test_base
+      useStyle: spock
+      testCmd: go test ./...
+  - id: base_local
+    enabled: true
+    config:
+      sourcePath: templates/local_base
+      fileList: [make, make]
+`)).Init()
+
+		var validation *config.ValidationError
+		Expect(errors.As(err, &validation)).To(BeTrue(), "expected validation errors, got: %v", err)
+
+		paths := make([]string, 0, len(validation.Errors))
+		for _, fieldErr := range validation.Errors {
+			paths = append(paths, fieldErr.Path)
+		}
+
+		Expect(paths).To(Equal([]string{
+			"base_project.author", "base_project.releaseCurrentWindow", "base_test.useStyle", "base_local.fileList",
+		}))
+		Expect(validation.Errors[0].File).To(Equal(projectPath))
+		Expect(validation.Errors[2].File).To(Equal("inline config"))
+
+		Expect(err).To(MatchError(ContainSubstring("invalid service configs (4 errors):\n" +
+			"  base_project.author (" + projectPath + "): missing required field\n" +
+			"  base_project.releaseCurrentWindow (" + projectPath + "): invalid release window \"Q5 2026\"")))
+		Expect(err).To(MatchError(ContainSubstring(
+			"  base_local.fileList (inline config): contains duplicates")))
+	})
+
+	It("formats field errors with and without their source", func() {
+		err := &config.FieldError{Path: "base_local.filelist", File: "base_local.yml", Line: 2, Err: errors.New("unknown key")}
+		Expect(err.Error()).To(Equal("base_local.filelist (base_local.yml:2): unknown key"))
+
+		err = &config.FieldError{Path: "usedGoVersion", Err: errors.New("missing required field")}
+		Expect(err.Error()).To(Equal("usedGoVersion: missing required field"))
+		Expect(errors.Unwrap(err)).To(MatchError("missing required field"))
+	})
+})
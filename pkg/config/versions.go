@@ -0,0 +1,166 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go/version"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/it-timo/goboot/pkg/goboottypes"
+)
+
+var (
+	// nodeVersionPattern matches the versions nvm accepts in .nvmrc (e.g., "20", "v20.11.1", "lts/iron", "node").
+	nodeVersionPattern = regexp.MustCompile(`^(v?\d+(\.\d+){0,2}|lts/(\*|[a-z]+)|node)$`)
+
+	// releaseWindowPattern matches a quarter or half of a year (e.g., "Q2 2026", "H1 2027").
+	releaseWindowPattern = regexp.MustCompile(`^([QH])(\d) (\d{4})$`)
+
+	// releaseYearPattern matches a year (e.g., "2028").
+	releaseYearPattern = regexp.MustCompile(`^\d{4}$`)
+
+	// releaseWindowMonths are the months of a quarter ("Q") and a half ("H") of a year.
+	releaseWindowMonths = map[string]int{"Q": 3, "H": 6}
+)
+
+// ValidateGoVersion returns an error if v is neither goboottypes.GoVersionAuto nor a Go release
+// usable in the go directive of go.mod (e.g., "1.25.5", "1.25", or "1.26rc1").
+func ValidateGoVersion(v string) error {
+	if v == goboottypes.GoVersionAuto {
+		return nil
+	}
+
+	if strings.HasPrefix(v, "go") || !version.IsValid("go"+v) {
+		return fmt.Errorf("invalid Go version %q: must be a Go release (e.g., \"1.25.5\") or %q",
+			v, goboottypes.GoVersionAuto)
+	}
+
+	return nil
+}
+
+// ValidateNodeVersion returns an error if v is no Node.js version accepted in .nvmrc
+// (e.g., "20", "v20.11.1", "lts/*", or "lts/iron").
+func ValidateNodeVersion(v string) error {
+	if !nodeVersionPattern.MatchString(v) {
+		return fmt.Errorf("invalid Node.js version %q: must be a version (e.g., \"20\" or \"20.11.1\") or an lts alias", v)
+	}
+
+	return nil
+}
+
+// ValidateReleaseWindow returns an error if window is neither a quarter nor a half of a year
+// (e.g., "Q2 2026" or "H1 2027").
+func ValidateReleaseWindow(window string) error {
+	_, err := releaseWindowStart(window)
+
+	return err
+}
+
+// ValidateReleaseYear returns an error if year is no four-digit year (e.g., "2028").
+func ValidateReleaseYear(year string) error {
+	if !releaseYearPattern.MatchString(year) {
+		return fmt.Errorf("invalid release year %q: must be a year (e.g., \"2028\")", year)
+	}
+
+	return nil
+}
+
+// releaseWindowStart returns the first month of a release window, counted from year 0
+// (e.g., 2026*12+3 for "Q2 2026"), to order windows.
+func releaseWindowStart(window string) (int, error) {
+	match := releaseWindowPattern.FindStringSubmatch(window)
+
+	part := 0
+	if match != nil {
+		part, _ = strconv.Atoi(match[2])
+	}
+
+	if match == nil || part < 1 || part > 12/releaseWindowMonths[match[1]] {
+		return 0, fmt.Errorf("invalid release window %q: must be a quarter or half of a year "+
+			"(e.g., \"Q2 2026\" or \"H1 2027\")", window)
+	}
+
+	year, _ := strconv.Atoi(match[3])
+
+	return year*12 + (part-1)*releaseWindowMonths[match[1]], nil
+}
+
+// DefaultReleaseWindows returns release windows derived from the given time, which goboot init offers as answers:
+// the current quarter, the quarter two quarters later, and the year two years later
+// (e.g., "Q4 2026", "Q2 2027", and "2028" in October 2026).
+//
+// Configs never derive their windows from the date, so the same config always renders the same project.
+func DefaultReleaseWindows(now time.Time) (string, string, string) {
+	month := now.Year()*12 + int(now.Month()) - 1
+
+	return quarterWindow(month), quarterWindow(month + 6), strconv.Itoa(now.Year() + 2)
+}
+
+// quarterWindow returns the quarter holding a month counted from year 0 (see releaseWindowStart).
+func quarterWindow(month int) string {
+	return fmt.Sprintf("Q%d %d", month%12/3+1, month/12)
+}
+
+// validateVersions checks the versions and release windows of the base project config,
+// which must follow each other in time.
+//
+// Blank values are skipped, as they are reported as missing.
+func (bp *BaseProjectConfig) validateVersions() error {
+	current, currentErr := releaseWindowStart(bp.ReleaseCurrentWindow)
+	upcoming, upcomingErr := releaseWindowStart(bp.ReleaseUpcomingWindow)
+	longTermErr := ValidateReleaseYear(bp.ReleaseLongTerm)
+
+	errs := []error{
+		invalidField("usedGoVersion", bp.UsedGoVersion, ValidateGoVersion(bp.UsedGoVersion)),
+		invalidField("usedNodeVersion", bp.UsedNodeVersion, ValidateNodeVersion(bp.UsedNodeVersion)),
+		invalidField("releaseCurrentWindow", bp.ReleaseCurrentWindow, currentErr),
+		invalidField("releaseUpcomingWindow", bp.ReleaseUpcomingWindow, upcomingErr),
+		invalidField("releaseLongTerm", bp.ReleaseLongTerm, longTermErr),
+	}
+
+	if currentErr == nil && upcomingErr == nil && upcoming < current {
+		errs = append(errs, fieldErr("releaseUpcomingWindow", fmt.Errorf("%q must not be before releaseCurrentWindow %q",
+			bp.ReleaseUpcomingWindow, bp.ReleaseCurrentWindow)))
+	}
+
+	longTerm, _ := strconv.Atoi(bp.ReleaseLongTerm)
+	if upcomingErr == nil && longTermErr == nil && longTerm < upcoming/12 {
+		errs = append(errs, fieldErr("releaseLongTerm", fmt.Errorf("%q must not be before releaseUpcomingWindow %q",
+			bp.ReleaseLongTerm, bp.ReleaseUpcomingWindow)))
+	}
+
+	return errors.Join(errs...)
+}
+
+// invalidField returns a FieldError for the field, or nil if err is nil or the value is blank.
+func invalidField(field, value string, err error) error {
+	if strings.TrimSpace(value) == "" {
+		return nil
+	}
+
+	return fieldErr(field, err)
+}
+
+// localGoVersion returns the version of the local Go toolchain (e.g., "1.25.5" for "go1.25.5"),
+// used for usedGoVersion goboottypes.GoVersionAuto.
+func localGoVersion() (string, error) {
+	out, err := exec.CommandContext(context.Background(), "go", "env", "GOVERSION").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve usedGoVersion %q with go env GOVERSION: %w",
+			goboottypes.GoVersionAuto, err)
+	}
+
+	// GOVERSION may carry experiments after the version (e.g., "go1.25.5 X:nodwarf5").
+	fields := strings.Fields(string(out))
+	if len(fields) == 0 || !version.IsValid(fields[0]) {
+		return "", fmt.Errorf("failed to resolve usedGoVersion %q: local Go version %q is no release",
+			goboottypes.GoVersionAuto, strings.TrimSpace(string(out)))
+	}
+
+	return strings.TrimPrefix(fields[0], "go"), nil
+}
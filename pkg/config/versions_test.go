@@ -0,0 +1,116 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/it-timo/goboot/pkg/config"
+	"github.com/it-timo/goboot/pkg/goboottypes"
+)
+
+var _ = Describe("Versions and release windows", func() {
+	DescribeTable("validates values",
+		func(validate func(string) error, value string, valid bool) {
+			err := validate(value)
+			if valid {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(MatchError(ContainSubstring("%q", value)))
+			}
+		},
+		Entry("Go release", config.ValidateGoVersion, "1.25.5", true),
+		Entry("Go language version", config.ValidateGoVersion, "1.25", true),
+		Entry("Go release candidate", config.ValidateGoVersion, "1.26rc1", true),
+		Entry("auto Go version", config.ValidateGoVersion, goboottypes.GoVersionAuto, true),
+		Entry("Go version with prefix", config.ValidateGoVersion, "go1.25.5", false),
+		Entry("Go version with v", config.ValidateGoVersion, "v1.25.5", false),
+		Entry("latest Go version", config.ValidateGoVersion, "latest", false),
+		Entry("Node.js major", config.ValidateNodeVersion, "20", true),
+		Entry("Node.js release", config.ValidateNodeVersion, "v20.11.1", true),
+		Entry("Node.js lts alias", config.ValidateNodeVersion, "lts/iron", true),
+		Entry("Node.js range", config.ValidateNodeVersion, ">=20", false),
+		Entry("quarter", config.ValidateReleaseWindow, "Q2 2026", true),
+		Entry("half", config.ValidateReleaseWindow, "H2 2026", true),
+		Entry("fifth quarter", config.ValidateReleaseWindow, "Q5 2026", false),
+		Entry("third half", config.ValidateReleaseWindow, "H3 2026", false),
+		Entry("month", config.ValidateReleaseWindow, "June 2026", false),
+		Entry("year", config.ValidateReleaseYear, "2028", true),
+		Entry("short year", config.ValidateReleaseYear, "28", false),
+	)
+
+	DescribeTable("derives release windows from the date",
+		func(now time.Time, current, upcoming, longTerm string) {
+			gotCurrent, gotUpcoming, gotLongTerm := config.DefaultReleaseWindows(now)
+			Expect([]string{gotCurrent, gotUpcoming, gotLongTerm}).To(Equal([]string{current, upcoming, longTerm}))
+		},
+		Entry("January", time.Date(2026, time.January, 5, 0, 0, 0, 0, time.UTC), "Q1 2026", "Q3 2026", "2028"),
+		Entry("October", time.Date(2026, time.October, 16, 0, 0, 0, 0, time.UTC), "Q4 2026", "Q2 2027", "2028"),
+		Entry("December", time.Date(2027, time.December, 31, 0, 0, 0, 0, time.UTC), "Q4 2027", "Q2 2028", "2029"),
+	)
+
+	Describe("BaseProjectConfig.Validate", func() {
+		var baseProject *config.BaseProjectConfig
+
+		BeforeEach(func() {
+			baseProject = &config.BaseProjectConfig{
+				ProjectURL:            "https://github.com/acme/mytool",
+				ProjectName:           "mytool",
+				UsedGoVersion:         "1.25.5",
+				UsedNodeVersion:       "20",
+				ReleaseCurrentWindow:  "Q2 2026",
+				ReleaseUpcomingWindow: "H2 2026",
+				ReleaseLongTerm:       "2026",
+				Author:                "Jane",
+			}
+		})
+
+		It("reports every invalid value with its field", func() {
+			baseProject.UsedGoVersion = "go1.25"
+			baseProject.UsedNodeVersion = "latest-lts"
+			baseProject.ReleaseCurrentWindow = "2026"
+
+			err := baseProject.Validate()
+			Expect(err).To(MatchError(ContainSubstring(`usedGoVersion: invalid Go version "go1.25"`)))
+			Expect(err).To(MatchError(ContainSubstring(`usedNodeVersion: invalid Node.js version "latest-lts"`)))
+			Expect(err).To(MatchError(ContainSubstring(`releaseCurrentWindow: invalid release window "2026"`)))
+		})
+
+		It("requires the release windows to follow each other", func() {
+			baseProject.ReleaseUpcomingWindow = "Q1 2026"
+			Expect(baseProject.Validate()).To(MatchError(
+				`releaseUpcomingWindow: "Q1 2026" must not be before releaseCurrentWindow "Q2 2026"`))
+
+			baseProject.ReleaseUpcomingWindow, baseProject.ReleaseLongTerm = "Q1 2027", "2026"
+			Expect(baseProject.Validate()).To(MatchError(
+				`releaseLongTerm: "2026" must not be before releaseUpcomingWindow "Q1 2027"`))
+		})
+
+		It("resolves usedGoVersion auto with the local Go toolchain", func() {
+			binDir := GinkgoT().TempDir()
+			GinkgoT().Setenv("PATH", binDir)
+
+			writeGo := func(goVersion string) {
+				Expect(os.WriteFile(filepath.Join(binDir, "go"),
+					[]byte("#!/bin/sh\necho '"+goVersion+"'\n"), 0o755)).To(Succeed())
+			}
+
+			writeGo("go1.24.3 X:nodwarf5")
+			baseProject.UsedGoVersion = goboottypes.GoVersionAuto
+			Expect(baseProject.Validate()).To(Succeed())
+			Expect(baseProject.UsedGoVersion).To(Equal("1.24.3"))
+
+			writeGo("devel go1.26-abcdef")
+			baseProject.UsedGoVersion = goboottypes.GoVersionAuto
+			Expect(baseProject.Validate()).To(MatchError(
+				`failed to resolve usedGoVersion "auto": local Go version "devel go1.26-abcdef" is no release`))
+
+			Expect(os.Remove(filepath.Join(binDir, "go"))).To(Succeed())
+			Expect(baseProject.Validate()).To(MatchError(ContainSubstring(
+				`failed to resolve usedGoVersion "auto" with go env GOVERSION`)))
+		})
+	})
+})
@@ -0,0 +1,103 @@
+package goboot
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"slices"
+
+	"github.com/it-timo/goboot/pkg/config"
+	"github.com/it-timo/goboot/pkg/goboottypes"
+	"github.com/it-timo/goboot/pkg/gobootutils"
+)
+
+// Add adds a single service, built-in or plugin, to the generated project in projectDir.
+//
+// The project is rendered with the service configs recorded in the lock file (goboottypes.LockFileName)
+// plus the config of the declared service (see config.ServiceConfigMeta.ConfigData).
+// Only the files affected by the new service are written:
+//   - files the new service generates
+//   - files of other services whose render changes with it (e.g., the base_local Makefile collecting its scripts)
+//
+// Affected files are three-way merged like in Upgrade, so edits made since generation
+// (e.g., additional Makefile targets) are kept. All other files are left alone.
+//
+// Files whose templates changed since generation are not touched; a warning suggests running Upgrade instead.
+//
+// Plugin services recorded in the lock are only run if allowPlugins is set (see ErrPluginsNotAllowed).
+func Add(projectDir string, service config.ServiceConfigMeta, style string, allowPlugins bool) (*UpgradeReport, error) {
+	upg, release, err := openUpgrade(projectDir, style)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	if slices.ContainsFunc(upg.lock.Services, func(recorded LockService) bool { return recorded.ID == service.ID }) {
+		return nil, fmt.Errorf("service %q is already part of the project (use goboot upgrade to change it)", service.ID)
+	}
+
+	cfg, err := upg.lock.Config(filepath.Dir(upg.projectDir), allowPlugins)
+	if err != nil {
+		return nil, err
+	}
+
+	err = loadAddedService(cfg, service)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config of %q: %w", service.ID, err)
+	}
+
+	next, _, err := render(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render the project with %q: %w", service.ID, err)
+	}
+
+	if upg.base == nil {
+		upg.renderBase(allowPlugins)
+	}
+
+	err = upg.apply(next, upg.affected)
+	if err != nil {
+		return nil, err
+	}
+
+	return upg.finish(next)
+}
+
+// loadAddedService loads the config of the declared service into cfg, as a plugin if it names one.
+//
+// Declared plugins run like in a generation; allowPlugins only concerns the plugins recorded in the lock.
+func loadAddedService(cfg *config.GoBoot, service config.ServiceConfigMeta) error {
+	data, err := service.ConfigData(ServiceConfigFactories())
+	if err != nil {
+		return err
+	}
+
+	if service.IsPlugin() {
+		return cfg.LoadPluginConfig(service.ID, service.Plugin, data)
+	}
+
+	return cfg.LoadServiceConfig(service.ID, data)
+}
+
+// affected reports whether a file of the new render is affected by the added service.
+//
+// Files not recorded in the lock are new. Recorded files are affected if their render changed,
+// which can only be told for files with a trusted base; the others are reported as a warning.
+func (u *upgrade) affected(file goboottypes.OutputFile) bool {
+	recorded, inLock := u.recorded[file.Path]
+	if !inLock {
+		return true
+	}
+
+	base, ok := u.trustedBase(file.Path)
+	if ok {
+		return !bytes.Equal(base, file.Content)
+	}
+
+	if gobootutils.HashContent(file.Content) != recorded.Rendered() {
+		u.report.Warnings = append(u.report.Warnings,
+			fmt.Sprintf("%s left alone: its templates changed since generation (run goboot upgrade)", file.Path))
+	}
+
+	return false
+}
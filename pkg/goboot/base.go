@@ -0,0 +1,109 @@
+package goboot
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/it-timo/goboot/pkg/gobootfs"
+	"github.com/it-timo/goboot/pkg/goboottypes"
+)
+
+// baseHeader is written on top of every base file.
+const baseHeader = "# Generated by goboot. Do not edit by hand.\n" +
+	"# It holds the rendered content of every generated file and is the merge base of goboot upgrade.\n"
+
+// Base holds the rendered content of every generated file, written into the project root as goboottypes.BaseFileName.
+//
+// Upgrade and Add three-way merge against it, so files whose templates changed since generation
+// are still merged with local edits line by line. The lock file records the checksums of the same renders.
+type Base struct {
+	// Files lists the rendered content of every generated file, sorted by path.
+	Files []BaseFile `yaml:"files"`
+}
+
+// BaseFile holds the rendered content of a single generated file.
+type BaseFile struct {
+	// Path is the slash-separated path relative to the project root.
+	Path string `yaml:"path"`
+
+	// Content is the rendered content, before any command rewrote the file (see LockFile.Rendered).
+	Content string `yaml:"content"`
+}
+
+// ParseBase decodes a base file.
+func ParseBase(data []byte) (*Base, error) {
+	base := &Base{}
+
+	err := yaml.Unmarshal(data, base)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse base file: %w", err)
+	}
+
+	return base, nil
+}
+
+// Marshal encodes the base file including its header.
+func (b *Base) Marshal() ([]byte, error) {
+	return marshalWithHeader("base file", baseHeader, b)
+}
+
+// newBase returns the base of the files generated during a run.
+func newBase(generated []gobootfs.GeneratedFile) *Base {
+	base := &Base{Files: make([]BaseFile, 0, len(generated))}
+
+	for _, file := range generated {
+		base.Files = append(base.Files, BaseFile{Path: file.Path, Content: string(file.Content)})
+	}
+
+	return base
+}
+
+// readBase reads the base file of the project in out.
+//
+// It returns nil without an error if the project has none (e.g., it was generated by an older goboot version).
+func readBase(out goboottypes.OutputFS) (*Base, error) {
+	exists, err := out.Exists(goboottypes.BaseFileName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check %s: %w", goboottypes.BaseFileName, err)
+	}
+
+	if !exists {
+		return nil, nil
+	}
+
+	data, err := out.ReadFile(goboottypes.BaseFileName)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to read %s: %w", goboottypes.BaseFileName, err)
+	}
+
+	return ParseBase(data)
+}
+
+// writeBase writes the base file into the project root.
+//
+// Like the lock, the base is owned by goboot, so it is written directly into the output and bypasses
+// the conflict policy.
+func writeBase(out goboottypes.OutputFS, base *Base) error {
+	data, err := base.Marshal()
+	if err != nil {
+		return err
+	}
+
+	err = out.WriteFile(goboottypes.OutputFile{
+		Path:    goboottypes.BaseFileName,
+		Content: data,
+		Perm:    goboottypes.FilePerm,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", goboottypes.BaseFileName, err)
+	}
+
+	return nil
+}
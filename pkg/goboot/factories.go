@@ -0,0 +1,114 @@
+package goboot
+
+import (
+	"github.com/it-timo/goboot/pkg/baselint"
+	"github.com/it-timo/goboot/pkg/baselocal"
+	"github.com/it-timo/goboot/pkg/baseproject"
+	"github.com/it-timo/goboot/pkg/basetest"
+	"github.com/it-timo/goboot/pkg/config"
+	"github.com/it-timo/goboot/pkg/goboottypes"
+)
+
+// ServiceFactory describes a built-in service: how its config and service are created, its built-in default config,
+// and the schema of its config.
+type ServiceFactory struct {
+	// ID is the ID of the service (e.g., goboottypes.ServiceNameBaseLint).
+	ID string
+
+	// NewConfig creates an empty config of the service for the given project name.
+	NewConfig func(projectName string) config.ServiceConfig
+
+	// NewService creates the service writing into the given target path.
+	NewService func(targetPath string) Service
+
+	// Defaults is the built-in default config of the service, used if goboot.yml declares neither confPath nor config.
+	Defaults []byte
+
+	// Schema returns the JSON Schema of the config, without the fields shared by all service configs.
+	Schema func() *config.Schema
+}
+
+// Phase returns the role of the service config, which decides the phase the service runs in.
+func (factory ServiceFactory) Phase() config.Role {
+	return factory.NewConfig("").Role()
+}
+
+// ServiceFactories returns the factories of all built-in services, in the order they are listed by goboot.
+//
+// This table is the central mapping point for services (see ADR-002): each built-in service is listed here
+// explicitly, and the service names, config factories, and schemas are derived from it.
+func ServiceFactories() []ServiceFactory {
+	return []ServiceFactory{
+		{
+			ID:         goboottypes.ServiceNameBaseProject,
+			NewConfig:  func(projectName string) config.ServiceConfig { return config.NewBaseProjectConfig(projectName) },
+			NewService: func(targetPath string) Service { return baseproject.NewBaseProject(targetPath) },
+			Defaults:   config.BaseProjectDefaults(),
+			Schema:     config.BaseProjectSchema,
+		},
+		{
+			ID:         goboottypes.ServiceNameBaseLint,
+			NewConfig:  func(projectName string) config.ServiceConfig { return config.NewBaseLintConfig(projectName) },
+			NewService: func(targetPath string) Service { return baselint.NewBaseLint(targetPath) },
+			Defaults:   config.BaseLintDefaults(),
+			Schema:     config.BaseLintSchema,
+		},
+		{
+			ID:         goboottypes.ServiceNameBaseTest,
+			NewConfig:  func(projectName string) config.ServiceConfig { return config.NewBaseTestConfig(projectName) },
+			NewService: func(targetPath string) Service { return basetest.NewBaseTest(targetPath) },
+			Defaults:   config.BaseTestDefaults(),
+			Schema:     config.BaseTestSchema,
+		},
+		{
+			ID:         goboottypes.ServiceNameBaseLocal,
+			NewConfig:  func(projectName string) config.ServiceConfig { return config.NewBaseLocalConfig(projectName) },
+			NewService: func(targetPath string) Service { return baselocal.NewBaseLocal(targetPath) },
+			Defaults:   config.BaseLocalDefaults(),
+			Schema:     config.BaseLocalSchema,
+		},
+	}
+}
+
+// ServiceNames returns the IDs of all built-in services, in the order of ServiceFactories.
+func ServiceNames() []string {
+	factories := ServiceFactories()
+	names := make([]string, 0, len(factories))
+
+	for _, factory := range factories {
+		names = append(names, factory.ID)
+	}
+
+	return names
+}
+
+// ServiceConfigFactories returns the config side of ServiceFactories, which the config package resolves
+// the built-in services through (e.g., config.NewGoBoot).
+func ServiceConfigFactories() config.ServiceConfigFactories {
+	factories := ServiceFactories()
+	cfgFactories := make(config.ServiceConfigFactories, 0, len(factories))
+
+	for _, factory := range factories {
+		cfgFactories = append(cfgFactories, config.ServiceConfigFactory{
+			ID:        factory.ID,
+			NewConfig: factory.NewConfig,
+			Defaults:  factory.Defaults,
+			Schema:    factory.Schema,
+		})
+	}
+
+	return cfgFactories
+}
+
+// serviceFactory returns the factory of the given service.
+//
+// The second return value indicates whether the service is known.
+func serviceFactory(id string) (ServiceFactory, bool) {
+	for _, factory := range ServiceFactories() {
+		if factory.ID == id {
+			return factory, true
+		}
+	}
+
+	return ServiceFactory{}, false
+}
@@ -0,0 +1,58 @@
+package goboot
+
+import (
+	"slices"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/it-timo/goboot/pkg/config"
+	"github.com/it-timo/goboot/pkg/goboottypes"
+)
+
+var _ = Describe("Service factories", func() {
+	It("has a config, a service, defaults, and a schema for every service ID", func() {
+		factories := ServiceFactories()
+		ids := ServiceNames()
+
+		Expect(ids).To(HaveLen(len(factories)))
+		Expect(slices.Compact(slices.Sorted(slices.Values(ids)))).To(HaveLen(len(ids)), "service IDs must be unique")
+
+		for i, factory := range factories {
+			Expect(factory.ID).To(Equal(ids[i]))
+			Expect(factory.NewConfig).NotTo(BeNil(), "config constructor of %s", factory.ID)
+			Expect(factory.NewService).NotTo(BeNil(), "service constructor of %s", factory.ID)
+			Expect(factory.Defaults).NotTo(BeEmpty(), "defaults of %s", factory.ID)
+			Expect(factory.Schema).NotTo(BeNil(), "schema of %s", factory.ID)
+
+			cfg := factory.NewConfig("mytool")
+			Expect(cfg.ID()).To(Equal(factory.ID))
+			Expect(cfg.Role()).To(Equal(factory.Phase()))
+			Expect(config.Roles()).To(ContainElement(factory.Phase()))
+			Expect(cfg.DecodeConfig(factory.Defaults, "github.com/acme/mytool")).To(Succeed())
+			Expect(factory.Schema().Title).To(Equal(factory.ID + " config"))
+			Expect(factory.NewService(GinkgoT().TempDir()).ID()).To(Equal(factory.ID))
+		}
+	})
+
+	It("derives the config factories and schemas from the service table", func() {
+		cfgFactories := ServiceConfigFactories()
+
+		ids := make([]string, 0, len(cfgFactories))
+		for _, factory := range cfgFactories {
+			ids = append(ids, factory.ID)
+		}
+
+		Expect(ids).To(Equal(ServiceNames()))
+		Expect(cfgFactories.SchemaNames()).To(Equal(append([]string{config.SchemaGoBoot}, ServiceNames()...)))
+	})
+
+	It("rejects unknown service IDs", func() {
+		_, ok := serviceFactory("unknown_service")
+		Expect(ok).To(BeFalse())
+
+		factory, ok := serviceFactory(goboottypes.ServiceNameBaseProject)
+		Expect(ok).To(BeTrue())
+		Expect(factory.Phase()).To(Equal(config.RoleBootstrap))
+	})
+})
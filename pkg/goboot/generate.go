@@ -0,0 +1,75 @@
+package goboot
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+
+	"github.com/it-timo/goboot/pkg/config"
+	"github.com/it-timo/goboot/pkg/goboottypes"
+)
+
+// Options configures a Generate run.
+type Options struct {
+	// Output receives the generated files and the lock file instead of the project directory on disk
+	// (e.g., a gobootfs.MemoryFS, or an OutputFS writing into an archive); nil writes to disk.
+	Output goboottypes.OutputFS
+
+	// Logger receives the progress messages of the run and of the config (see config.GoBoot.SetLogger);
+	// nil discards them.
+	//
+	// Generate sets it on the config, so the messages of loading the config before go to the logger set on it then.
+	Logger goboottypes.Logger
+
+	// DryRun writes into memory on top of the existing project and runs no command but plugins (see GoBoot.SetDryRun).
+	DryRun bool
+
+	// GoModTidy runs go mod tidy in the generated project if it has a go.mod file, as the CLI does.
+	// It requires the project directory on disk as output.
+	GoModTidy bool
+}
+
+// Generate generates the project described by the loaded and validated config
+// and returns the report of the run: the files written, the scripts registered, the commands run,
+// and the warnings of loading the config.
+//
+// It is the entry point for programs embedding goboot; the CLI runs the same steps. The config is loaded
+// from a file (config.GoBoot.Init), from YAML data (config.GoBoot.DecodeConfig), or built in code
+// (config.GoBoot.RegisterServiceConfig and config.GoBoot.Validate).
+//
+// On failure, the report describes the run up to the failing step.
+func Generate(cfg *config.GoBoot, opts Options) (RunReport, error) {
+	if opts.GoModTidy && opts.Output != nil {
+		return RunReport{}, errors.New("go mod tidy requires the project directory on disk as output")
+	}
+
+	logger := opts.Logger
+	if logger == nil {
+		logger = log.New(io.Discard, "", 0)
+	}
+
+	cfg.SetLogger(logger)
+
+	app := NewGoBoot(cfg)
+	app.SetLogger(logger)
+	app.SetDryRun(opts.DryRun)
+	app.SetOutput(opts.Output)
+
+	err := app.RegisterServices()
+	if err != nil {
+		return app.Report(), fmt.Errorf("service registration failed: %w", err)
+	}
+
+	err = app.RunServices()
+	if err != nil {
+		return app.Report(), fmt.Errorf("service execution failed: %w", err)
+	}
+
+	err = app.RunGoModTidy(opts.GoModTidy)
+	if err != nil {
+		return app.Report(), err
+	}
+
+	return app.Report(), nil
+}
@@ -0,0 +1,107 @@
+package goboot_test
+
+import (
+	"bytes"
+	"log"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/it-timo/goboot/pkg/config"
+	"github.com/it-timo/goboot/pkg/goboot"
+	"github.com/it-timo/goboot/pkg/gobootfs"
+	"github.com/it-timo/goboot/pkg/goboottypes"
+)
+
+var _ = Describe("Generate", func() {
+	var (
+		targetPath string
+		cfg        *config.GoBoot
+		logs       *bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		targetPath = filepath.Join(GinkgoT().TempDir(), "out")
+		logs = &bytes.Buffer{}
+
+		// The config has no version, so loading it warns about the migration.
+		cfg = config.NewGoBoot(goboot.ServiceConfigFactories(), "")
+		cfg.SetLogger(log.New(logs, "", 0))
+		Expect(cfg.DecodeConfig([]byte(`projectName: portal
+repoUrl: github.com/acme/portal
+targetPath: ` + targetPath + `
+services:
+  - {id: base_project, enabled: true}
+  - {id: base_lint, enabled: true}
+  - {id: base_local, enabled: true, config: {fileList: [make]}}
+`))).To(Succeed())
+	})
+
+	It("writes into the given output and returns the files, scripts, and warnings", func() {
+		memory := gobootfs.NewMemoryFS(nil)
+
+		report, err := goboot.Generate(cfg, goboot.Options{Output: memory, Logger: log.New(logs, "", 0)})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(report.DryRun).To(BeFalse())
+		Expect(report.Files).To(ContainElements(
+			gobootfs.Change{Path: "go.mod", Action: gobootfs.ActionCreate},
+			gobootfs.Change{Path: "Makefile", Action: gobootfs.ActionCreate},
+		))
+		Expect(report.Scripts).To(ContainElement(And(
+			HaveField("Service", goboottypes.ServiceNameBaseLint),
+			HaveField("Kind", goboot.ScriptKindLines),
+		)))
+		Expect(report.Commands).To(BeEmpty())
+		Expect(report.Warnings).To(ConsistOf(ContainSubstring("goboot config has version 0")))
+
+		var buf strings.Builder
+		Expect(report.Print(&buf)).To(Succeed())
+		Expect(buf.String()).To(ContainSubstring("\nWarnings (1):\n  goboot config has version 0"))
+
+		Expect(memory.Exists(goboottypes.LockFileName)).To(BeTrue())
+		Expect(targetPath).NotTo(BeAnExistingFile())
+
+		Expect(logs.String()).To(ContainSubstring("[WARN] goboot config has version 0"))
+		Expect(logs.String()).To(ContainSubstring("loaded bootstrap service base_project"))
+		Expect(logs.String()).To(ContainSubstring("Resolved execution plan: base_project -> base_lint -> base_local"))
+	})
+
+	It("discards the messages of the run without a logger", func() {
+		logs.Reset()
+
+		_, err := goboot.Generate(cfg, goboot.Options{Output: gobootfs.NewMemoryFS(nil)})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(logs.String()).To(BeEmpty())
+	})
+
+	It("writes into the project directory on disk without an output", func() {
+		report, err := goboot.Generate(cfg, goboot.Options{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Files).NotTo(BeEmpty())
+
+		for _, change := range report.Files {
+			Expect(filepath.Join(targetPath, "portal", change.Path)).To(BeAnExistingFile())
+		}
+	})
+
+	It("rejects go mod tidy with an output", func() {
+		_, err := goboot.Generate(cfg, goboot.Options{Output: gobootfs.NewMemoryFS(nil), GoModTidy: true})
+		Expect(err).To(MatchError("go mod tidy requires the project directory on disk as output"))
+	})
+
+	It("returns the report up to the failing step", func() {
+		memory := gobootfs.NewMemoryFS(nil)
+		Expect(memory.WriteFile(goboottypes.OutputFile{Path: "go.mod", Content: []byte("module custom\n")})).To(Succeed())
+
+		report, err := goboot.Generate(cfg, goboot.Options{Output: memory})
+		Expect(err).To(MatchError(ContainSubstring("service execution failed")))
+		Expect(report.Warnings).To(HaveLen(1))
+
+		content, err := memory.ReadFile("go.mod")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(content)).To(Equal("module custom\n"))
+	})
+})
@@ -5,6 +5,9 @@ This package coordinates the loading, registration, and execution of modular gen
 It acts as the top-level entry point, delegating control to a service manager that runs logic
 based on the configuration declared in a YAML config file (see config.GoBoot).
 
+Programs embedding goboot (e.g., a portal service or tests) call Generate with a loaded config,
+an optional output, and a logger, and get the RunReport of the run back.
+
 Does not implement generation logic — instead, it dynamically wires together service modules (e.g., baseProject)
 that encapsulate feature-specific behavior.
 */
@@ -14,15 +17,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"os/exec"
 	"path/filepath"
 
-	"github.com/it-timo/goboot/pkg/baselint"
-	"github.com/it-timo/goboot/pkg/baselocal"
-	"github.com/it-timo/goboot/pkg/baseproject"
-	"github.com/it-timo/goboot/pkg/basetest"
 	"github.com/it-timo/goboot/pkg/config"
+	"github.com/it-timo/goboot/pkg/gobootfs"
+	"github.com/it-timo/goboot/pkg/gobootplugin"
 	"github.com/it-timo/goboot/pkg/goboottypes"
 )
 
@@ -36,31 +38,85 @@ type GoBoot struct {
 
 	// ServiceMgr manages the lifecycle and execution of registered service modules.
 	ServiceMgr *serviceManager
+
+	// dryRun makes services write into memory and skips all commands.
+	dryRun bool
+
+	// memory holds the in-memory output of a dry run.
+	memory *gobootfs.MemoryFS
+
+	// output replaces the project directory on disk and the in-memory output of a dry run (see SetOutput); may be nil.
+	output goboottypes.OutputFS
+
+	// log receives the progress messages of the run (see SetLogger).
+	log goboottypes.Logger
+
+	// report collects the effects of the run.
+	report RunReport
 }
 
 // NewGoBoot creates and returns a new GoBoot instance bound to the provided configuration.
 //
-// It wires the internal service manager to the configuration's ConfManager.
+// It wires the internal service manager to the configuration's ConfManager,
+// starts the Report with the warnings of loading the configuration, and logs to stdout.
 //
 // Note: This does not yet load services — use RegisterServices() afterward.
 func NewGoBoot(config *config.GoBoot) *GoBoot {
 	return &GoBoot{
 		cfg:        config,
 		ServiceMgr: newServiceManager(config.ConfManager),
+		log:        goboottypes.NewStdoutLogger(),
+		report:     RunReport{Warnings: config.Warnings()},
 	}
 }
 
+// SetLogger sets the logger for the progress messages of the run and of the services (stdout by default).
+func (gb *GoBoot) SetLogger(logger goboottypes.Logger) {
+	gb.log = logger
+	gb.ServiceMgr.log = logger
+}
+
+// SetOutput sets the output all services write into instead of the project directory on disk
+// (e.g., a gobootfs.MemoryFS); nil restores the default.
+//
+// The lock file is written into the output as well. Nothing is written to disk, and go mod tidy cannot run.
+//
+// It must be called before RegisterServices.
+func (gb *GoBoot) SetOutput(out goboottypes.OutputFS) {
+	gb.output = out
+}
+
+// SetDryRun enables or disables the dry-run mode.
+//
+// In dry-run mode, services write into an in-memory filesystem seeded with the existing project (if any),
+// and no command is executed. Plugins still run, as their files are part of the run; Report lists them.
+// Nothing is written to disk; Report describes what a real run would do.
+//
+// It must be called before RegisterServices.
+func (gb *GoBoot) SetDryRun(enabled bool) {
+	gb.dryRun = enabled
+	gb.report.DryRun = enabled
+}
+
+// Report returns the summary of the files, scripts, and commands of the run so far.
+func (gb *GoBoot) Report() RunReport {
+	return gb.report
+}
+
 // RegisterServices evaluates all declared services in the config and registers only those marked as enabled.
 //
-// Each service must be explicitly handled here by matching its ID.
+// Each service is created from its entry in the explicit factory table (see ServiceFactories),
+// or runs an external executable if it is declared with a plugin (see gobootplugin).
 //
 // This avoids runtime registration logic and keeps service orchestration predictable.
 //
-// It performs the following for each declared and enabled service:
+// It performs the following for each declared and enabled service, in declaration order:
 //   - Checks the service ID
 //   - Instantiates the appropriate service implementation
 //   - Registers the service with the service manager
 //
+// The execution order is resolved later from the service phases and dependencies (see serviceManager.resolvePlan).
+//
 // Returns an error if any declared service is unknown or registration fails.
 func (gb *GoBoot) RegisterServices() error {
 	if gb.cfg.Services == nil {
@@ -68,135 +124,232 @@ func (gb *GoBoot) RegisterServices() error {
 	}
 
 	// creates the target dir if not exist.
-	err := os.MkdirAll(gb.cfg.TargetPath, goboottypes.DirPerm)
-	if err != nil {
-		return fmt.Errorf("failed to create target directory: %w", err)
+	if !gb.dryRun && gb.output == nil {
+		err := os.MkdirAll(gb.cfg.TargetPath, goboottypes.DirPerm)
+		if err != nil {
+			return fmt.Errorf("failed to create target directory: %w", err)
+		}
 	}
 
-	err = gb.registerPreServices()
-	if err != nil {
-		return fmt.Errorf("failed to register pre services: %w", err)
+	for _, meta := range gb.cfg.Services {
+		if !meta.IsEnabled() {
+			continue
+		}
+
+		err := gb.registerService(meta)
+		if err != nil {
+			return fmt.Errorf("failed to register services: %w", err)
+		}
 	}
 
-	err = gb.registerMainServices()
+	return nil
+}
+
+// ResolvePlan returns the order the registered services would run in, without running them
+// (see serviceManager.resolvePlan).
+//
+// It fails like RunServices would, e.g., for missing dependencies or dependency cycles.
+func (gb *GoBoot) ResolvePlan() ([]string, error) {
+	plan, err := gb.ServiceMgr.resolvePlan()
 	if err != nil {
-		return fmt.Errorf("failed to register main services: %w", err)
+		return nil, fmt.Errorf("failed to resolve execution plan: %w", err)
 	}
 
-	return nil
+	return plan, nil
 }
 
-// RunServices executes all registered services in the order they were registered.
+// RunServices executes all registered services in their resolved order.
 //
-// It delegates to the internal service manager, which pulls the appropriate config
+// It opens the shared project output (on disk, or in memory for a dry run) and delegates
+// to the internal service manager, which pulls the appropriate config
 // for each service and invokes its logic.
 //
 // If a service has no config, it is skipped.
+//
+// The files are written once all services succeeded, and existing files are handled according to
+// the configured conflict policy; a failing service or conflict leaves the project untouched.
+// The written files, resolved conflicts, registered scripts, and plugins run are recorded in the Report.
+//
+// After all services succeeded, the generation manifest (goboottypes.LockFileName) and the rendered content
+// of the generated files (goboottypes.BaseFileName) are written into the project root.
 func (gb *GoBoot) RunServices() error {
-	return gb.ServiceMgr.runAll()
+	if len(gb.ServiceMgr.order) == 0 {
+		return gb.ServiceMgr.runAll()
+	}
+
+	out, release, err := gb.openOutput()
+	if err != nil {
+		return fmt.Errorf("failed to open output: %w", err)
+	}
+	defer release()
+
+	recorder := gobootfs.NewRecorder(out, gb.cfg.ConflictPolicy)
+	gb.ServiceMgr.output = recorder
+
+	err = gb.ServiceMgr.runAll()
+	gb.report.Scripts = gb.ServiceMgr.scripts
+	gb.report.Commands = append(gb.report.Commands, gb.ServiceMgr.commands...)
+
+	if err != nil {
+		return err
+	}
+
+	err = recorder.Commit()
+	if err != nil {
+		return fmt.Errorf("failed to write generated files: %w", err)
+	}
+
+	gb.report.Files = recorder.Changes()
+
+	err = gb.writeLock(out, recorder.Generated())
+	if err != nil {
+		return fmt.Errorf("failed to write lock file: %w", err)
+	}
+
+	err = writeBase(out, newBase(recorder.Generated()))
+	if err != nil {
+		return fmt.Errorf("failed to write base file: %w", err)
+	}
+
+	return nil
 }
 
 // RunGoModTidy runs go mod tidy if the go.mod file exists.
+//
+// In dry-run mode, the command is only added to the Report if the run would have produced a go.mod file.
+// It fails if an output replaces the project directory on disk (see SetOutput).
+//
+// Afterward, the go.mod checksum in the lock file is updated to the tidied content.
 func (gb *GoBoot) RunGoModTidy(execute bool) error {
 	if !execute {
 		return nil
 	}
 
+	if gb.output != nil {
+		return errors.New("go mod tidy requires the project directory on disk as output")
+	}
+
 	projectRoot := filepath.Join(gb.cfg.TargetPath, gb.cfg.ProjectName)
-	goModPath := filepath.Join(projectRoot, "go.mod")
 
-	_, err := os.Stat(goModPath)
-	if err != nil {
-		if errors.Is(err, os.ErrNotExist) {
-			return nil
-		}
+	exists, err := gb.goModExists(projectRoot)
+	if err != nil || !exists {
+		return err
+	}
+
+	tidy := Command{Dir: projectRoot, Args: []string{"go", "mod", "tidy"}}
+	gb.report.Commands = append(gb.report.Commands, tidy)
 
-		return fmt.Errorf("failed to stat go.mod: %w", err)
+	if gb.dryRun {
+		return nil
 	}
 
 	// cd to target path and make go mod tidy.
-	cmd := exec.CommandContext(context.Background(), "go", "mod", "tidy")
-	cmd.Dir = projectRoot
+	cmd := exec.CommandContext(context.Background(), tidy.Args[0], tidy.Args[1:]...)
+	cmd.Dir = tidy.Dir
 
 	err = cmd.Run()
 	if err != nil {
 		return fmt.Errorf("failed to run go mod tidy: %w", err)
 	}
 
+	// go mod tidy may rewrite go.mod; keep the lock in line with the file goboot leaves behind.
+	err = refreshLock(projectRoot, "go.mod")
+	if err != nil {
+		return fmt.Errorf("failed to update lock file: %w", err)
+	}
+
 	return nil
 }
 
-// registerPreServices registers foundational services that need to exist before other services can be used.
-//
-// This typically includes internal infrastructure providers (e.g., script registrars).
-// These services must be registered early to allow other services to hook into them.
+// goModExists reports whether the project contains a go.mod file.
 //
-// Returns an error if any registration fails.
-func (gb *GoBoot) registerPreServices() error {
-	for _, meta := range gb.cfg.Services {
-		if !meta.IsEnabled() {
-			continue
+// In dry-run mode, the in-memory output of the run is checked instead of the disk.
+func (gb *GoBoot) goModExists(projectRoot string) (bool, error) {
+	if gb.dryRun {
+		if gb.memory == nil {
+			return false, nil
 		}
 
-		switch meta.ID {
-		case goboottypes.ServiceNameBaseLocal:
-			baseLocal := baselocal.NewBaseLocal(gb.cfg.TargetPath)
+		exists, err := gb.memory.Exists("go.mod")
+		if err != nil {
+			return false, fmt.Errorf("failed to check go.mod: %w", err)
+		}
 
-			err := gb.ServiceMgr.register(baseLocal)
-			if err != nil {
-				return fmt.Errorf("failed to register %s service: %w", goboottypes.ServiceNameBaseLocal, err)
-			}
-		default:
-			// skip all services that are not explicitly defined.
-			continue
+		return exists, nil
+	}
+
+	_, err := os.Stat(filepath.Join(projectRoot, "go.mod"))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
 		}
 
-		fmt.Printf("loaded pre-service %s\n", meta.ID)
+		return false, fmt.Errorf("failed to stat go.mod: %w", err)
 	}
 
-	return nil
+	return true, nil
 }
 
-// registerMainServices registers the primary generation services defined in the config.
-//
-// Each enabled service is instantiated and registered using its unique service ID.
-// Registration fails if the service is unknown or if instantiation fails.
+// openOutput opens the output all services write into.
 //
-// Returns an error if any service fails to register.
+// A run with an output set (see SetOutput) writes into that output.
+// A real run writes into the project directory on disk.
+// A dry run writes into memory on top of a read-only view of the existing project directory (if any),
+// unless a memory output was prepared before (see Verify).
 //
-//nolint:cyclop // Flat switch is preferred for explicit control and traceability.
-func (gb *GoBoot) registerMainServices() error {
-	for _, meta := range gb.cfg.Services {
-		if !meta.IsEnabled() {
-			continue
+// The returned release function must always be called.
+func (gb *GoBoot) openOutput() (goboottypes.OutputFS, func(), error) {
+	projectRoot := filepath.Join(gb.cfg.TargetPath, gb.cfg.ProjectName)
+
+	if gb.output != nil {
+		return gb.output, func() {}, nil
+	}
+
+	if !gb.dryRun {
+		return gobootfs.Open(nil, gb.cfg.TargetPath, gb.cfg.ProjectName)
+	}
+
+	if gb.memory == nil {
+		var base fs.FS
+
+		info, err := os.Stat(projectRoot)
+		if err == nil && info.IsDir() {
+			base = os.DirFS(projectRoot)
 		}
 
-		switch meta.ID {
-		case goboottypes.ServiceNameBaseProject:
-			err := gb.ServiceMgr.register(baseproject.NewBaseProject(gb.cfg.TargetPath))
-			if err != nil {
-				return fmt.Errorf("failed to register %s service: %w", goboottypes.ServiceNameBaseProject, err)
-			}
-		case goboottypes.ServiceNameBaseLint:
-			err := gb.ServiceMgr.register(baselint.NewBaseLint(gb.cfg.TargetPath))
-			if err != nil {
-				return fmt.Errorf("failed to register %s service: %w", goboottypes.ServiceNameBaseLint, err)
-			}
-		case goboottypes.ServiceNameBaseLocal:
-			// skip it, because it's registered in pre-service.
-			continue
-		case goboottypes.ServiceNameBaseTest:
-			err := gb.ServiceMgr.register(basetest.NewBaseTest(gb.cfg.TargetPath))
-			if err != nil {
-				return fmt.Errorf("failed to register %s service: %w", goboottypes.ServiceNameBaseTest, err)
-			}
-		// Future services can be added here.
-		default:
-			return fmt.Errorf("unknown service ID: %s", meta.ID)
+		gb.memory = gobootfs.NewMemoryFS(base)
+	}
+
+	return gb.memory, func() {}, nil
+}
+
+// registerService creates the declared service from its factory (see ServiceFactories),
+// or as a plugin if the declaration names a plugin executable, and registers it with the service manager.
+//
+// Returns an error if the service is unknown or registration fails.
+func (gb *GoBoot) registerService(meta config.ServiceConfigMeta) error {
+	if meta.IsPlugin() {
+		err := gb.ServiceMgr.register(gobootplugin.NewPlugin(meta.ID, gb.cfg.TargetPath))
+		if err != nil {
+			return fmt.Errorf("failed to register %s plugin: %w", meta.ID, err)
 		}
 
-		fmt.Printf("loaded service %s\n", meta.ID)
+		gb.log.Printf("loaded plugin service %s (%s)\n", meta.ID, meta.Plugin)
+
+		return nil
 	}
 
+	factory, ok := serviceFactory(meta.ID)
+	if !ok {
+		return fmt.Errorf("unknown service ID: %s", meta.ID)
+	}
+
+	err := gb.ServiceMgr.register(factory.NewService(gb.cfg.TargetPath))
+	if err != nil {
+		return fmt.Errorf("failed to register %s service: %w", meta.ID, err)
+	}
+
+	gb.log.Printf("loaded %s service %s\n", factory.Phase(), meta.ID)
+
 	return nil
 }
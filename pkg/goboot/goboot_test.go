@@ -1,6 +1,7 @@
 package goboot_test
 
 import (
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
@@ -10,7 +11,9 @@ import (
 
 	"github.com/it-timo/goboot/pkg/config"
 	"github.com/it-timo/goboot/pkg/goboot"
+	"github.com/it-timo/goboot/pkg/gobootfs"
 	"github.com/it-timo/goboot/pkg/goboottypes"
+	"github.com/it-timo/goboot/pkg/gobootutils"
 )
 
 func writeGoMod(dir string) {
@@ -287,7 +290,7 @@ var _ = Describe("GoBoot Core Orchestration", func() {
 	})
 
 	Describe("Service Registration Flow", func() {
-		It("follows the correct order: pre-services, main services", func() {
+		It("registers the enabled services in declaration order, whatever their phase", func() {
 			cfg.Services = []config.ServiceConfigMeta{
 				{
 					ID:      goboottypes.ServiceNameBaseLocal,
@@ -328,6 +331,443 @@ var _ = Describe("GoBoot Core Orchestration", func() {
 		})
 	})
 
+	Describe("Dry run", func() {
+		var sourceDir string
+
+		BeforeEach(func() {
+			sourceDir = GinkgoT().TempDir()
+			Expect(os.WriteFile(filepath.Join(sourceDir, "go.mod.tmpl"),
+				[]byte("module {{.RepoPath}}\n"), 0o644)).To(Succeed())
+
+			cfg.TargetPath = filepath.Join(tempDir, "out")
+			cfg.ProjectName = "dryproj"
+			Expect(cfg.ConfManager.Register(&config.BaseProjectConfig{
+				SourcePath:            sourceDir,
+				ProjectURL:            "https://github.com/example/dryproj",
+				RepoPath:              "github.com/example/dryproj",
+				ProjectName:           "dryproj",
+				UsedGoVersion:         "1.25.0",
+				UsedNodeVersion:       "20.0.0",
+				ReleaseCurrentWindow:  "Q1 2026",
+				ReleaseUpcomingWindow: "Q3 2026",
+				ReleaseLongTerm:       "2029",
+				Author:                "Dry Author",
+			})).To(Succeed())
+
+			goBoot = goboot.NewGoBoot(cfg)
+			goBoot.SetDryRun(true)
+		})
+
+		It("does not create the target directory", func() {
+			Expect(goBoot.RegisterServices()).To(Succeed())
+			Expect(cfg.TargetPath).NotTo(BeADirectory())
+		})
+
+		It("reports files and commands without writing or executing", func() {
+			Expect(goBoot.RegisterServices()).To(Succeed())
+			Expect(goBoot.RunServices()).To(Succeed())
+			Expect(goBoot.RunGoModTidy(true)).To(Succeed())
+
+			Expect(cfg.TargetPath).NotTo(BeADirectory())
+
+			report := goBoot.Report()
+			Expect(report.DryRun).To(BeTrue())
+			Expect(report.Files).To(ConsistOf(gobootfs.Change{Path: "go.mod", Action: gobootfs.ActionCreate}))
+			Expect(report.Commands).To(ConsistOf(goboot.Command{
+				Dir:  filepath.Join(cfg.TargetPath, cfg.ProjectName),
+				Args: []string{"go", "mod", "tidy"},
+			}))
+
+			var buf strings.Builder
+			Expect(report.Print(&buf)).To(Succeed())
+			Expect(buf.String()).To(ContainSubstring("Dry run: no files were written"))
+			Expect(buf.String()).To(ContainSubstring("create     go.mod"))
+			Expect(buf.String()).To(ContainSubstring("go mod tidy (in "))
+		})
+	})
+
+	Describe("Lock file", func() {
+		var sourceDir string
+
+		BeforeEach(func() {
+			sourceDir = GinkgoT().TempDir()
+			Expect(os.WriteFile(filepath.Join(sourceDir, "go.mod.tmpl"),
+				[]byte("module {{.RepoPath}}\n"), 0o644)).To(Succeed())
+
+			cfg.ProjectName = "lockproj"
+			cfg.RepoURL = "https://github.com/example/lockproj"
+			Expect(cfg.ConfManager.Register(&config.BaseProjectConfig{
+				SourcePath:            sourceDir,
+				ProjectURL:            "https://github.com/example/lockproj",
+				RepoPath:              "github.com/example/lockproj",
+				ProjectName:           "lockproj",
+				UsedGoVersion:         "1.25.0",
+				UsedNodeVersion:       "20.0.0",
+				ReleaseCurrentWindow:  "Q1 2026",
+				ReleaseUpcomingWindow: "Q3 2026",
+				ReleaseLongTerm:       "2029",
+				Author:                "Lock Author",
+			})).To(Succeed())
+
+			goBoot = goboot.NewGoBoot(cfg)
+			Expect(goBoot.RegisterServices()).To(Succeed())
+		})
+
+		It("records the version, service configs, and generated files", func() {
+			Expect(goBoot.RunServices()).To(Succeed())
+
+			projectRoot := filepath.Join(tempDir, cfg.ProjectName)
+			data, err := os.ReadFile(filepath.Join(projectRoot, goboottypes.LockFileName))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(HavePrefix("# Generated by goboot."))
+
+			lock, err := goboot.ParseLock(data)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lock.GobootVersion).To(Equal(goboottypes.Version))
+			Expect(lock.ProjectName).To(Equal("lockproj"))
+			Expect(lock.RepoURL).To(Equal(cfg.RepoURL))
+
+			Expect(lock.Services).To(HaveLen(1))
+			Expect(lock.Services[0].ID).To(Equal(goboottypes.ServiceNameBaseProject))
+			Expect(lock.Services[0].ConfigHash).To(HaveLen(64))
+
+			// Absolute template paths are kept as given, so the lock resolves from any working directory.
+			var recorded config.BaseProjectConfig
+			Expect(lock.Services[0].Config.Decode(&recorded)).To(Succeed())
+			Expect(recorded.SourcePath).To(Equal(sourceDir))
+			Expect(recorded.Author).To(Equal("Lock Author"))
+			Expect(recorded.Version).To(Equal(config.ConfigVersion), "configs built in code are in the current format")
+
+			goMod, err := os.ReadFile(filepath.Join(projectRoot, "go.mod"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lock.Files).To(ConsistOf(goboot.LockFile{
+				Path:     "go.mod",
+				SHA256:   gobootutils.HashContent(goMod),
+				Template: filepath.ToSlash(filepath.Join(sourceDir, "go.mod.tmpl")),
+			}))
+		})
+
+		It("produces the same config hash for the same config", func() {
+			Expect(goBoot.RunServices()).To(Succeed())
+
+			lockPath := filepath.Join(tempDir, cfg.ProjectName, goboottypes.LockFileName)
+			first, err := os.ReadFile(lockPath)
+			Expect(err).NotTo(HaveOccurred())
+
+			cfg.ConflictPolicy = goboottypes.ConflictPolicyOverwrite
+			goBoot = goboot.NewGoBoot(cfg)
+			Expect(goBoot.RegisterServices()).To(Succeed())
+			Expect(goBoot.RunServices()).To(Succeed())
+
+			second, err := os.ReadFile(lockPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(second)).To(Equal(string(first)))
+		})
+
+		It("verifies the generated project against the lock", func() {
+			Expect(goBoot.RunServices()).To(Succeed())
+
+			projectRoot := filepath.Join(tempDir, cfg.ProjectName)
+			report, err := goboot.Verify(projectRoot, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(report.HasDrift()).To(BeFalse())
+			Expect(report.Files).To(Equal([]goboot.FileDrift{{Path: "go.mod", Status: goboot.FileUnchanged}}))
+
+			Expect(os.WriteFile(filepath.Join(projectRoot, "go.mod"), []byte("module edited\n"), 0o644)).To(Succeed())
+
+			report, err = goboot.Verify(projectRoot, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(report.HasDrift()).To(BeTrue())
+			Expect(report.Count(goboot.FileModified)).To(Equal(1))
+
+			var buf strings.Builder
+			Expect(report.Print(&buf)).To(Succeed())
+			Expect(buf.String()).To(Equal("  modified   go.mod\n" +
+				"Verified 1 files: 0 unchanged, 1 modified, 0 deleted, 0 extra\n"))
+		})
+
+		It("names the missing template directory when verifying", func() {
+			Expect(goBoot.RunServices()).To(Succeed())
+			Expect(os.RemoveAll(sourceDir)).To(Succeed())
+
+			_, err := goboot.Verify(filepath.Join(tempDir, cfg.ProjectName), false)
+			Expect(err).To(MatchError(fs.ErrNotExist))
+			Expect(err.Error()).To(ContainSubstring(sourceDir))
+			Expect(err.Error()).To(ContainSubstring("resolve against the working directory"))
+		})
+
+		It("rejects lock files without a version", func() {
+			_, err := goboot.ParseLock([]byte("projectName: x\n"))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("missing gobootVersion"))
+
+			_, err = goboot.ParseLock([]byte("files: [\n"))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("failed to parse lock file"))
+		})
+	})
+
+	Describe("Upgrade", func() {
+		var (
+			sourceDir   string
+			projectRoot string
+		)
+
+		newConfig := func(goVersion string) *config.GoBoot {
+			upgradeCfg := &config.GoBoot{
+				ProjectName: "upgradeproj",
+				RepoURL:     "https://github.com/example/upgradeproj",
+				TargetPath:  tempDir,
+				ConfManager: config.NewConfigManager(),
+				Services: []config.ServiceConfigMeta{
+					{ID: goboottypes.ServiceNameBaseProject, Enabled: true},
+				},
+			}
+
+			Expect(upgradeCfg.ConfManager.Register(&config.BaseProjectConfig{
+				SourcePath:            sourceDir,
+				ProjectURL:            upgradeCfg.RepoURL,
+				ProjectName:           upgradeCfg.ProjectName,
+				UsedGoVersion:         goVersion,
+				UsedNodeVersion:       "20.0.0",
+				CurrentYear:           2026,
+				ReleaseCurrentWindow:  "Q1 2026",
+				ReleaseUpcomingWindow: "Q3 2026",
+				ReleaseLongTerm:       "2029",
+				Author:                "Upgrade Author",
+			})).To(Succeed())
+
+			return upgradeCfg
+		}
+
+		writeTemplate := func(name, content string) {
+			Expect(os.WriteFile(filepath.Join(sourceDir, name+goboottypes.TemplateSuffix),
+				[]byte(content), 0o644)).To(Succeed())
+		}
+
+		editFile := func(name, content string) {
+			Expect(os.WriteFile(filepath.Join(projectRoot, name), []byte(content), 0o644)).To(Succeed())
+		}
+
+		readProjectFile := func(name string) string {
+			content, err := os.ReadFile(filepath.Join(projectRoot, name))
+			Expect(err).NotTo(HaveOccurred())
+
+			return string(content)
+		}
+
+		BeforeEach(func() {
+			sourceDir = GinkgoT().TempDir()
+			projectRoot = filepath.Join(tempDir, "upgradeproj")
+
+			writeTemplate("go.mod", "module {{.RepoPath}}\n\ngo {{.UsedGoVersion}}\n")
+			writeTemplate("README.md", "# {{.ProjectName}}\n\nGenerated by goboot.\n")
+
+			app := goboot.NewGoBoot(newConfig("1.25.0"))
+			Expect(app.RegisterServices()).To(Succeed())
+			Expect(app.RunServices()).To(Succeed())
+		})
+
+		It("merges config changes with local edits", func() {
+			editFile("go.mod", "// Maintained by the platform team.\nmodule github.com/example/upgradeproj\n\ngo 1.25.0\n")
+
+			report, err := goboot.Upgrade(projectRoot, newConfig("1.26.0"), "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(report.Files).To(Equal([]goboot.UpgradeChange{
+				{Path: "README.md", Action: goboot.UpgradeUnchanged},
+				{Path: "go.mod", Action: goboot.UpgradeMerged},
+			}))
+
+			Expect(readProjectFile("go.mod")).To(Equal(
+				"// Maintained by the platform team.\nmodule github.com/example/upgradeproj\n\ngo 1.26.0\n"))
+
+			lock, err := goboot.ParseLock([]byte(readProjectFile(goboottypes.LockFileName)))
+			Expect(err).NotTo(HaveOccurred())
+
+			var recorded config.BaseProjectConfig
+			Expect(lock.Services[0].Config.Decode(&recorded)).To(Succeed())
+			Expect(recorded.UsedGoVersion).To(Equal("1.26.0"))
+		})
+
+		It("replaces unedited files after templates changed in place", func() {
+			writeTemplate("README.md", "# {{.ProjectName}}\n\nGenerated by goboot (improved).\n")
+
+			report, err := goboot.Upgrade(projectRoot, newConfig("1.25.0"), "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(report.Count(goboot.UpgradeUpdated)).To(Equal(1))
+			Expect(readProjectFile("README.md")).To(ContainSubstring("(improved)"))
+
+			verify, err := goboot.Verify(projectRoot, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(verify.HasDrift()).To(BeFalse())
+		})
+
+		It("writes conflict markers for conflicting edits", func() {
+			editFile("go.mod", "module github.com/example/upgradeproj\n\ngo 1.25.4\n")
+
+			report, err := goboot.Upgrade(projectRoot, newConfig("1.26.0"), goboottypes.MergeStyleMarkers)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(report.Count(goboot.UpgradeConflict)).To(Equal(1))
+			Expect(readProjectFile("go.mod")).To(Equal("module github.com/example/upgradeproj\n\n" +
+				"<<<<<<< current\ngo 1.25.4\n=======\ngo 1.26.0\n>>>>>>> goboot " + goboottypes.Version + "\n"))
+		})
+
+		It("merges local edits with templates changed in place", func() {
+			writeTemplate("README.md", "# {{.ProjectName}}\n\nGenerated by goboot.\n\n## Usage\n\nRun it.\n")
+			regenerate := newConfig("1.25.0")
+			regenerate.ConflictPolicy = goboottypes.ConflictPolicyOverwrite
+			app := goboot.NewGoBoot(regenerate)
+			Expect(app.RegisterServices()).To(Succeed())
+			Expect(app.RunServices()).To(Succeed())
+
+			editFile("README.md", "# upgradeproj\n\nGenerated by goboot.\n\n## Usage\n\nRun it with care.\n")
+			writeTemplate("README.md", "# {{.ProjectName}}\n\nGenerated by goboot (improved).\n\n## Usage\n\nRun it.\n")
+
+			report, err := goboot.Upgrade(projectRoot, newConfig("1.25.0"), "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(report.Files).To(ContainElement(goboot.UpgradeChange{Path: "README.md", Action: goboot.UpgradeMerged}))
+			Expect(readProjectFile("README.md")).To(Equal(
+				"# upgradeproj\n\nGenerated by goboot (improved).\n\n## Usage\n\nRun it with care.\n"))
+
+			// The base file now holds the new render.
+			base, err := goboot.ParseBase([]byte(readProjectFile(goboottypes.BaseFileName)))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(base.Files).To(ContainElement(goboot.BaseFile{
+				Path:    "README.md",
+				Content: "# upgradeproj\n\nGenerated by goboot (improved).\n\n## Usage\n\nRun it.\n",
+			}))
+		})
+
+		It("treats edited files as whole-file conflicts after templates changed in place without a base file", func() {
+			Expect(os.Remove(filepath.Join(projectRoot, goboottypes.BaseFileName))).To(Succeed())
+			editFile("README.md", "# upgradeproj\n\nEdited by hand.\n")
+			writeTemplate("README.md", "# {{.ProjectName}}\n\nGenerated by goboot (improved).\n")
+
+			report, err := goboot.Upgrade(projectRoot, newConfig("1.25.0"), "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(report.Count(goboot.UpgradeConflict)).To(Equal(1))
+			Expect(readProjectFile("README.md")).To(Equal("<<<<<<< current\n# upgradeproj\n\nEdited by hand.\n" +
+				"=======\n# upgradeproj\n\nGenerated by goboot (improved).\n>>>>>>> goboot " + goboottypes.Version + "\n"))
+			Expect(filepath.Join(projectRoot, goboottypes.BaseFileName)).To(BeAnExistingFile())
+		})
+
+		It("writes rejected sections next to the file with the rej style", func() {
+			editFile("go.mod", "module github.com/example/upgradeproj\n\ngo 1.25.4\n")
+
+			report, err := goboot.Upgrade(projectRoot, newConfig("1.26.0"), goboottypes.MergeStyleReject)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(report.Files).To(ContainElement(goboot.UpgradeChange{
+				Path: "go.mod", Action: goboot.UpgradeConflict, Reject: "go.mod.rej",
+			}))
+
+			Expect(readProjectFile("go.mod")).To(Equal("module github.com/example/upgradeproj\n\ngo 1.25.4\n"))
+			Expect(readProjectFile("go.mod.rej")).To(Equal("@@ line 3 @@\n-go 1.25.4\n+go 1.26.0\n"))
+
+			var buf strings.Builder
+			Expect(report.Print(&buf)).To(Succeed())
+			Expect(buf.String()).To(ContainSubstring("conflict   go.mod (rejected sections in go.mod.rej)"))
+		})
+
+		It("keeps locally deleted files deleted", func() {
+			Expect(os.Remove(filepath.Join(projectRoot, "README.md"))).To(Succeed())
+
+			report, err := goboot.Upgrade(projectRoot, newConfig("1.26.0"), "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(report.Files).To(ContainElement(goboot.UpgradeChange{Path: "README.md", Action: goboot.UpgradeSkipped}))
+			Expect(filepath.Join(projectRoot, "README.md")).NotTo(BeAnExistingFile())
+		})
+
+		It("rejects configs of other projects and unknown conflict styles", func() {
+			other := newConfig("1.25.0")
+			other.ProjectName = "otherproj"
+
+			_, err := goboot.Upgrade(projectRoot, other, "")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(`config is for project "otherproj"`))
+
+			_, err = goboot.Upgrade(projectRoot, newConfig("1.25.0"), "theirs")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(`invalid conflict style "theirs"`))
+		})
+	})
+
+	Describe("Add", func() {
+		var projectRoot string
+
+		BeforeEach(func() {
+			sourceDir := GinkgoT().TempDir()
+			projectRoot = filepath.Join(tempDir, "addproj")
+
+			localSource, err := filepath.Abs("../../templates/local_base")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(os.WriteFile(filepath.Join(sourceDir, "README.md"+goboottypes.TemplateSuffix),
+				[]byte("# {{.ProjectName}}\n"), 0o644)).To(Succeed())
+
+			addCfg := config.NewGoBoot(goboot.ServiceConfigFactories(), "")
+			addCfg.ProjectName = "addproj"
+			addCfg.RepoURL = "https://github.com/example/addproj"
+			addCfg.TargetPath = tempDir
+
+			Expect(addCfg.LoadServiceConfig(goboottypes.ServiceNameBaseProject, []byte(
+				"sourcePath: "+sourceDir+"\nusedGoVersion: 1.25.0\nusedNodeVersion: 20.0.0\n"+
+					"releaseCurrentWindow: Q1 2026\nreleaseUpcomingWindow: Q3 2026\nreleaseLongTerm: \"2029\"\n"+
+					"author: Add Author\n"))).To(Succeed())
+			Expect(addCfg.LoadServiceConfig(goboottypes.ServiceNameBaseLocal, []byte(
+				"sourcePath: "+localSource+"\nfileList: [make]\n"))).To(Succeed())
+
+			app := goboot.NewGoBoot(addCfg)
+			Expect(app.RegisterServices()).To(Succeed())
+			Expect(app.RunServices()).To(Succeed())
+		})
+
+		// lintService returns a base_lint declaration with its config in a file.
+		lintService := func() config.ServiceConfigMeta {
+			lintSource, err := filepath.Abs("../../templates/lint_base")
+			Expect(err).NotTo(HaveOccurred())
+
+			confPath := filepath.Join(GinkgoT().TempDir(), "base_lint.yml")
+			Expect(os.WriteFile(confPath, []byte("sourcePath: "+lintSource+"\nlinters:\n"+
+				"  golang:\n    cmd: golangci-lint run ./...\n    enabled: true\n"), 0o644)).To(Succeed())
+
+			return config.ServiceConfigMeta{ID: goboottypes.ServiceNameBaseLint, ConfPath: confPath}
+		}
+
+		It("adds the service and merges its scripts into the existing Makefile", func() {
+			makefile := filepath.Join(projectRoot, "Makefile")
+			content, err := os.ReadFile(makefile)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(os.WriteFile(makefile, append(content, []byte("\ndeploy:\n\t./deploy.sh\n")...), 0o644)).To(Succeed())
+
+			report, err := goboot.Add(projectRoot, lintService(), "", false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(report.Warnings).To(BeEmpty())
+			Expect(report.Files).To(ContainElements(
+				goboot.UpgradeChange{Path: ".golangci.yml", Action: goboot.UpgradeCreated},
+				goboot.UpgradeChange{Path: "Makefile", Action: goboot.UpgradeMerged},
+			))
+			Expect(report.Files).NotTo(ContainElement(HaveField("Path", "README.md")))
+
+			content, err = os.ReadFile(makefile)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(ContainSubstring("\tgolangci-lint run ./..."))
+			Expect(string(content)).To(HaveSuffix("deploy:\n\t./deploy.sh\n"))
+
+			lockData, err := os.ReadFile(filepath.Join(projectRoot, goboottypes.LockFileName))
+			Expect(err).NotTo(HaveOccurred())
+
+			lock, err := goboot.ParseLock(lockData)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lock.Services).To(ContainElement(HaveField("ID", goboottypes.ServiceNameBaseLint)))
+		})
+
+		It("rejects services that are already part of the project", func() {
+			_, err := goboot.Add(projectRoot, config.ServiceConfigMeta{ID: goboottypes.ServiceNameBaseLocal}, "", false)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(`service "base_local" is already part of the project`))
+		})
+	})
+
 	Describe("Service name validation", func() {
 		Context("with valid service names", func() {
 			DescribeTable("accepts known service IDs",
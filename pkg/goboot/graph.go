@@ -0,0 +1,211 @@
+package goboot
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/it-timo/goboot/pkg/config"
+	"github.com/it-timo/goboot/pkg/goboottypes"
+)
+
+// resolvePlan sorts the registered services topologically by their dependencies.
+//
+// Dependencies come from three sources:
+//   - Explicit: services implementing DependentService declare the IDs they depend on.
+//   - Roles: every service runs after the services whose configs have an earlier config.Role
+//     (bootstrap before main before finalizer).
+//   - Implicit: every goboottypes.ScriptReceiver runs before the registered goboottypes.Registrar,
+//     because the registrar renders the script lines collected from the receivers.
+//
+// Whenever several services are ready to run, the one registered first wins,
+// so the plan is stable and follows the order of `services:` in goboot.yml.
+//
+// Returns an error if a declared dependency is not registered or if the dependencies form a cycle.
+func (sm *serviceManager) resolvePlan() ([]string, error) {
+	deps, err := sm.dependencies()
+	if err != nil {
+		return nil, err
+	}
+
+	plan := make([]string, 0, len(sm.order))
+	done := make(map[string]bool, len(sm.order))
+
+	for len(plan) < len(sm.order) {
+		next, ok := sm.nextReady(deps, done)
+		if !ok {
+			return nil, fmt.Errorf("dependency cycle detected: %s", findCycle(sm.order, deps, done))
+		}
+
+		done[next] = true
+		plan = append(plan, next)
+	}
+
+	return plan, nil
+}
+
+// dependencies builds the dependency list for every registered service.
+//
+// Returns an error listing all declared dependencies that are not registered.
+func (sm *serviceManager) dependencies() (map[string][]string, error) {
+	deps := make(map[string][]string, len(sm.order))
+
+	var missing []string
+
+	for _, id := range sm.order {
+		dependent, ok := sm.services[id].(DependentService)
+		if !ok {
+			continue
+		}
+
+		for _, dep := range dependent.DependsOn() {
+			_, registered := sm.services[dep]
+			if !registered {
+				missing = append(missing, fmt.Sprintf("%s requires %s", id, dep))
+
+				continue
+			}
+
+			deps[id] = append(deps[id], dep)
+		}
+	}
+
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("missing service dependencies (enable them in goboot.yml): %s",
+			strings.Join(missing, ", "))
+	}
+
+	sm.addRoleDependencies(deps)
+
+	registrarID, ok := sm.registrarID()
+	if !ok {
+		return deps, nil
+	}
+
+	for _, id := range sm.order {
+		_, isReceiver := sm.services[id].(goboottypes.ScriptReceiver)
+		if !isReceiver || id == registrarID || slices.Contains(deps[registrarID], id) {
+			continue
+		}
+
+		deps[registrarID] = append(deps[registrarID], id)
+	}
+
+	return deps, nil
+}
+
+// addRoleDependencies makes every service depend on the services whose configs have an earlier role,
+// walking the configs phase by phase (see config.Manager.ConfigsByRole).
+//
+// Services without a loaded config have no role and are left to their explicit dependencies.
+func (sm *serviceManager) addRoleDependencies(deps map[string][]string) {
+	var earlier []string
+
+	for _, role := range config.Roles() {
+		var phase []string
+
+		for _, cfg := range sm.cfgMgr.ConfigsByRole(role) {
+			id := cfg.ID()
+
+			_, registered := sm.services[id]
+			if !registered {
+				continue
+			}
+
+			for _, dep := range earlier {
+				if !slices.Contains(deps[id], dep) {
+					deps[id] = append(deps[id], dep)
+				}
+			}
+
+			phase = append(phase, id)
+		}
+
+		earlier = append(earlier, phase...)
+	}
+}
+
+// nextReady returns the first service in registration order that is not done yet
+// and whose dependencies are all done.
+func (sm *serviceManager) nextReady(deps map[string][]string, done map[string]bool) (string, bool) {
+	for _, id := range sm.order {
+		if done[id] {
+			continue
+		}
+
+		ready := true
+
+		for _, dep := range deps[id] {
+			if !done[dep] {
+				ready = false
+
+				break
+			}
+		}
+
+		if ready {
+			return id, true
+		}
+	}
+
+	return "", false
+}
+
+// findCycle returns a readable dependency cycle (e.g., "a -> b -> a") among the services that are not done.
+//
+// It is only called when no remaining service is ready, which guarantees that such a cycle exists.
+func findCycle(order []string, deps map[string][]string, done map[string]bool) string {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	state := make(map[string]int, len(order))
+
+	var (
+		stack []string
+		visit func(id string) []string
+	)
+
+	visit = func(id string) []string {
+		state[id] = visiting
+		stack = append(stack, id)
+
+		for _, dep := range deps[id] {
+			if done[dep] {
+				continue
+			}
+
+			switch state[dep] {
+			case visiting:
+				start := slices.Index(stack, dep)
+
+				return append(slices.Clone(stack[start:]), dep)
+			case unvisited:
+				cycle := visit(dep)
+				if cycle != nil {
+					return cycle
+				}
+			}
+		}
+
+		stack = stack[:len(stack)-1]
+		state[id] = visited
+
+		return nil
+	}
+
+	for _, id := range order {
+		if done[id] || state[id] != unvisited {
+			continue
+		}
+
+		cycle := visit(id)
+		if cycle != nil {
+			return strings.Join(cycle, " -> ")
+		}
+	}
+
+	return "unknown"
+}
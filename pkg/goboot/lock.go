@@ -0,0 +1,334 @@
+package goboot
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/it-timo/goboot/pkg/config"
+	"github.com/it-timo/goboot/pkg/gobootfs"
+	"github.com/it-timo/goboot/pkg/goboottypes"
+	"github.com/it-timo/goboot/pkg/gobootutils"
+)
+
+// ErrPluginsNotAllowed is returned if a lock records plugin services, but running them was not allowed.
+//
+// Plugin paths are read from the lock file, so anyone who can edit it chooses the executables;
+// verify, upgrade, and add only run them if the caller explicitly allows it.
+var ErrPluginsNotAllowed = errors.New("running the plugins recorded in the lock file is not allowed")
+
+// lockHeader is written on top of every lock file.
+const lockHeader = "# Generated by goboot. Do not edit by hand.\n" +
+	"# It records how this project was generated and is used to detect drift.\n"
+
+// Lock is the generation manifest written into the project root as goboottypes.LockFileName.
+//
+// It records which goboot version generated the project, with which service configs,
+// and the checksum and template of every generated file.
+//
+// It intentionally contains no timestamps or target paths, so identical runs produce identical locks.
+// Template directories, templates, and plugins are recorded as given in the config: absolute paths stay absolute,
+// and relative paths resolve against the working directory, as they did during the generation.
+type Lock struct {
+	// GobootVersion is the goboot version that generated the project (see goboottypes.Version).
+	GobootVersion string `yaml:"gobootVersion"`
+
+	// ProjectName is the project name from goboot.yml.
+	ProjectName string `yaml:"projectName"`
+
+	// RepoURL is the repository URL from goboot.yml.
+	RepoURL string `yaml:"repoUrl"`
+
+	// Services lists the services that ran, in the order they are declared in goboot.yml.
+	Services []LockService `yaml:"services"`
+
+	// Files lists every generated file, sorted by path.
+	Files []LockFile `yaml:"files"`
+}
+
+// LockService records the resolved config of a single service.
+type LockService struct {
+	// ID is the service identifier (e.g., "base_project").
+	ID string `yaml:"id"`
+
+	// Plugin is the path to the plugin executable, if the service is a plugin (see config.ServiceConfigMeta.Plugin).
+	Plugin string `yaml:"plugin,omitempty"`
+
+	// ConfigHash is the hex-encoded SHA-256 checksum of the resolved config as stored in Config.
+	ConfigHash string `yaml:"configHash"`
+
+	// Config is the resolved service config after validation (including filled defaults).
+	Config yaml.Node `yaml:"config"`
+}
+
+// LockFile records a single generated file.
+type LockFile struct {
+	// Path is the slash-separated path relative to the project root.
+	Path string `yaml:"path"`
+
+	// SHA256 is the hex-encoded SHA-256 checksum of the content goboot left behind.
+	SHA256 string `yaml:"sha256"`
+
+	// Template is the path of the template the file was rendered from.
+	Template string `yaml:"template"`
+
+	// RenderedSHA256 is the checksum of the rendered content, if a command rewrote the file afterward
+	// (e.g., go.mod after go mod tidy). Empty if SHA256 is the rendered content.
+	RenderedSHA256 string `yaml:"renderedSha256,omitempty"`
+}
+
+// Rendered returns the checksum of the rendered content.
+func (f LockFile) Rendered() string {
+	if f.RenderedSHA256 != "" {
+		return f.RenderedSHA256
+	}
+
+	return f.SHA256
+}
+
+// ParseLock decodes a lock file.
+func ParseLock(data []byte) (*Lock, error) {
+	lock := &Lock{}
+
+	err := yaml.Unmarshal(data, lock)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse lock file: %w", err)
+	}
+
+	if lock.GobootVersion == "" {
+		return nil, errors.New("invalid lock file: missing gobootVersion")
+	}
+
+	return lock, nil
+}
+
+// Marshal encodes the lock file including its header.
+func (l *Lock) Marshal() ([]byte, error) {
+	return marshalWithHeader("lock file", lockHeader, l)
+}
+
+// marshalWithHeader encodes the named file v as YAML below the given header comment.
+func marshalWithHeader(name, header string, v any) ([]byte, error) {
+	var buf bytes.Buffer
+
+	buf.WriteString(header)
+
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+
+	err := enc.Encode(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s: %w", name, err)
+	}
+
+	err = enc.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to close %s encoder: %w", name, err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Config rebuilds the goboot configuration recorded in the lock.
+//
+// The recorded service configs are decoded and validated again, and their checksums must match configHash.
+// The returned config targets targetPath and overwrites existing files.
+//
+// Unless allowPlugins is set, it fails with ErrPluginsNotAllowed if the lock records plugin services.
+func (l *Lock) Config(targetPath string, allowPlugins bool) (*config.GoBoot, error) {
+	cfg := config.NewGoBoot(ServiceConfigFactories(), "")
+	cfg.ProjectName = l.ProjectName
+	cfg.RepoURL = l.RepoURL
+	cfg.TargetPath = targetPath
+	cfg.ConflictPolicy = goboottypes.ConflictPolicyOverwrite
+	cfg.Services = []config.ServiceConfigMeta{}
+
+	for _, service := range l.Services {
+		data, err := yaml.Marshal(&service.Config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode recorded config for %q: %w", service.ID, err)
+		}
+
+		if gobootutils.HashContent(data) != service.ConfigHash {
+			return nil, fmt.Errorf("recorded config for %q does not match its configHash", service.ID)
+		}
+
+		if service.Plugin != "" && !allowPlugins {
+			return nil, fmt.Errorf("%w: service %q runs %s", ErrPluginsNotAllowed, service.ID, service.Plugin)
+		}
+
+		if service.Plugin != "" {
+			err = cfg.LoadPluginConfig(service.ID, service.Plugin, data)
+		} else {
+			err = cfg.LoadServiceConfig(service.ID, data)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to load recorded config: %w", err)
+		}
+	}
+
+	return cfg, nil
+}
+
+// buildLock assembles the lock for the services that ran and the files they generated.
+func (gb *GoBoot) buildLock(generated []gobootfs.GeneratedFile) (*Lock, error) {
+	lock := &Lock{
+		GobootVersion: goboottypes.Version,
+		ProjectName:   gb.cfg.ProjectName,
+		RepoURL:       gb.cfg.RepoURL,
+		Files:         make([]LockFile, 0, len(generated)),
+	}
+
+	for _, meta := range gb.cfg.Services {
+		id := meta.ID
+
+		cfg, ok := gb.ServiceMgr.config(id)
+		if !meta.IsEnabled() || !ok {
+			continue
+		}
+
+		service, err := lockService(meta, cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		lock.Services = append(lock.Services, service)
+	}
+
+	for _, file := range generated {
+		lock.Files = append(lock.Files, LockFile{
+			Path:     file.Path,
+			SHA256:   file.SHA256,
+			Template: file.Template,
+		})
+	}
+
+	return lock, nil
+}
+
+// lockService records the resolved config of a single service.
+func lockService(meta config.ServiceConfigMeta, cfg any) (LockService, error) {
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return LockService{}, fmt.Errorf("failed to encode config for %q: %w", meta.ID, err)
+	}
+
+	var node yaml.Node
+
+	err = yaml.Unmarshal(data, &node)
+	if err != nil {
+		return LockService{}, fmt.Errorf("failed to decode config for %q: %w", meta.ID, err)
+	}
+
+	service := LockService{
+		ID:         meta.ID,
+		Plugin:     meta.Plugin,
+		ConfigHash: gobootutils.HashContent(data),
+	}
+
+	if len(node.Content) > 0 {
+		service.Config = *node.Content[0]
+	}
+
+	return service, nil
+}
+
+// writeLock writes the lock into the project root.
+//
+// The lock is owned by goboot, so it is written directly into the output and bypasses the conflict policy.
+func (gb *GoBoot) writeLock(out goboottypes.OutputFS, generated []gobootfs.GeneratedFile) error {
+	lock, err := gb.buildLock(generated)
+	if err != nil {
+		return err
+	}
+
+	data, err := lock.Marshal()
+	if err != nil {
+		return err
+	}
+
+	err = out.WriteFile(goboottypes.OutputFile{
+		Path:    goboottypes.LockFileName,
+		Content: data,
+		Perm:    goboottypes.FilePerm,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", goboottypes.LockFileName, err)
+	}
+
+	return nil
+}
+
+// refreshLock updates the checksums of the given files in the lock on disk to their current content,
+// e.g., after go mod tidy rewrote go.mod. The checksum of the rendered content is kept as renderedSha256.
+//
+// It is a no-op if the project has no lock file. Files not recorded in the lock are ignored.
+func refreshLock(projectRoot string, paths ...string) error {
+	root, err := os.OpenRoot(projectRoot)
+	if err != nil {
+		return fmt.Errorf("failed to open project directory: %w", err)
+	}
+
+	defer func() {
+		_ = root.Close()
+	}()
+
+	data, err := root.ReadFile(goboottypes.LockFileName)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to read %s: %w", goboottypes.LockFileName, err)
+	}
+
+	lock, err := ParseLock(data)
+	if err != nil {
+		return err
+	}
+
+	for i, file := range lock.Files {
+		if !slices.Contains(paths, file.Path) {
+			continue
+		}
+
+		content, err := root.ReadFile(filepath.FromSlash(file.Path))
+		if err != nil {
+			return fmt.Errorf("failed to read %q: %w", file.Path, err)
+		}
+
+		sum := gobootutils.HashContent(content)
+		if sum == file.SHA256 {
+			continue
+		}
+
+		if file.RenderedSHA256 == "" {
+			lock.Files[i].RenderedSHA256 = file.SHA256
+		}
+
+		if sum == file.RenderedSHA256 {
+			lock.Files[i].RenderedSHA256 = ""
+		}
+
+		lock.Files[i].SHA256 = sum
+	}
+
+	data, err = lock.Marshal()
+	if err != nil {
+		return err
+	}
+
+	err = root.WriteFile(goboottypes.LockFileName, data, goboottypes.FilePerm)
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", goboottypes.LockFileName, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,210 @@
+package goboot
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/it-timo/goboot/pkg/gobootfs"
+	"github.com/it-timo/goboot/pkg/goboottypes"
+)
+
+// ScriptKind distinguishes the two kinds of goboottypes.Registrar registrations.
+type ScriptKind string
+
+const (
+	// ScriptKindLines marks lines registered via RegisterLines (Makefile, Taskfile, pre-commit).
+	ScriptKindLines ScriptKind = "lines"
+
+	// ScriptKindFile marks a script file registered via RegisterFile (scripts/ directory).
+	ScriptKindFile ScriptKind = "file"
+)
+
+// ScriptRegistration describes a single registration a service made with the Registrar.
+type ScriptRegistration struct {
+	Service string     // ID of the registering service.
+	Kind    ScriptKind // Lines or file registration.
+	Name    string     // Registered name (service name for lines, file name for files).
+	Lines   []string   // Registered script lines.
+}
+
+// Command describes an external command executed by a plugin service, or executed (or, in a dry run, skipped)
+// after the services ran.
+type Command struct {
+	Dir     string   // Working directory of the command; empty for the working directory of goboot.
+	Args    []string // Command name and arguments (e.g., "go", "mod", "tidy").
+	Service string   // ID of the plugin service running the command; empty for commands run after the services.
+}
+
+// String returns the command line and where it runs (e.g., "go mod tidy (in ./mytool)").
+func (c Command) String() string {
+	if c.isPlugin() {
+		return fmt.Sprintf("%s (plugin %s)", strings.Join(c.Args, " "), c.Service)
+	}
+
+	return fmt.Sprintf("%s (in %s)", strings.Join(c.Args, " "), c.Dir)
+}
+
+// isPlugin reports whether the command is run by a plugin service.
+func (c Command) isPlugin() bool {
+	return c.Service != ""
+}
+
+// RunReport summarizes the effects of a goboot run.
+type RunReport struct {
+	DryRun   bool                 // Whether the run was a dry run.
+	Files    []gobootfs.Change    // Files created or overwritten, sorted by path.
+	Scripts  []ScriptRegistration // Script registrations in the order they were made.
+	Commands []Command            // Plugins run (also in a dry run), then commands run after the services.
+	Warnings []string             // Warnings of loading the config (see config.GoBoot.Warnings).
+}
+
+// Print writes a human-readable summary of the report to w.
+func (r RunReport) Print(w io.Writer) error {
+	var buf strings.Builder
+
+	switch {
+	case r.DryRun && slices.ContainsFunc(r.Commands, Command.isPlugin):
+		buf.WriteString("Dry run: no files were written and no commands were executed, except the plugins.\n")
+	case r.DryRun:
+		buf.WriteString("Dry run: no files were written and no commands were executed.\n")
+	}
+
+	fmt.Fprintf(&buf, "\nFiles (%d):\n", len(r.Files))
+
+	for _, change := range r.Files {
+		writeChange(&buf, change)
+	}
+
+	fmt.Fprintf(&buf, "\nRegistered scripts (%d):\n", len(r.Scripts))
+
+	for _, script := range r.Scripts {
+		fmt.Fprintf(&buf, "  %s: %s %q\n", script.Service, script.Kind, script.Name)
+
+		for _, line := range script.Lines {
+			fmt.Fprintf(&buf, "      %s\n", line)
+		}
+	}
+
+	fmt.Fprintf(&buf, "\nCommands (%d):\n", len(r.Commands))
+
+	for _, cmd := range r.Commands {
+		fmt.Fprintf(&buf, "  %s\n", cmd)
+	}
+
+	if len(r.Warnings) > 0 {
+		fmt.Fprintf(&buf, "\nWarnings (%d):\n", len(r.Warnings))
+
+		for _, warning := range r.Warnings {
+			fmt.Fprintf(&buf, "  %s\n", warning)
+		}
+	}
+
+	buf.WriteString("\n")
+	writeConflicts(&buf, r.Conflicts())
+
+	_, err := io.WriteString(w, buf.String())
+	if err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+
+	return nil
+}
+
+// Conflicts returns the changes to files that existed before the run, sorted by path.
+func (r RunReport) Conflicts() []gobootfs.Change {
+	var conflicts []gobootfs.Change
+
+	for _, change := range r.Files {
+		if change.IsConflict() {
+			conflicts = append(conflicts, change)
+		}
+	}
+
+	return conflicts
+}
+
+// PrintConflicts writes a summary of the resolved conflicts to w.
+func (r RunReport) PrintConflicts(w io.Writer) error {
+	var buf strings.Builder
+
+	writeConflicts(&buf, r.Conflicts())
+
+	_, err := io.WriteString(w, buf.String())
+	if err != nil {
+		return fmt.Errorf("failed to write conflict summary: %w", err)
+	}
+
+	return nil
+}
+
+// writeConflicts writes the conflict summary section.
+func writeConflicts(buf *strings.Builder, conflicts []gobootfs.Change) {
+	if len(conflicts) == 0 {
+		buf.WriteString("Conflicts: none\n")
+
+		return
+	}
+
+	fmt.Fprintf(buf, "Conflicts resolved (%d):\n", len(conflicts))
+
+	for _, change := range conflicts {
+		writeChange(buf, change)
+	}
+}
+
+// writeChange writes a single file change line.
+func writeChange(buf *strings.Builder, change gobootfs.Change) {
+	if change.Action == gobootfs.ActionBackup {
+		fmt.Fprintf(buf, "  %-10s %s (saved as %s)\n", change.Action, change.Path, change.Backup)
+
+		return
+	}
+
+	fmt.Fprintf(buf, "  %-10s %s\n", change.Action, change.Path)
+}
+
+// recordingRegistrar wraps a goboottypes.Registrar and records every successful registration.
+//
+// It is injected into script receivers instead of the registrar itself, so the report
+// can list the scripts each service registered.
+type recordingRegistrar struct {
+	next    goboottypes.Registrar
+	service string
+	scripts *[]ScriptRegistration
+}
+
+// RegisterLines forwards the lines to the wrapped registrar and records them on success.
+func (r *recordingRegistrar) RegisterLines(name string, lines []string) error {
+	err := r.next.RegisterLines(name, lines)
+	if err != nil {
+		return fmt.Errorf("failed to register lines: %w", err)
+	}
+
+	r.record(ScriptKindLines, name, lines)
+
+	return nil
+}
+
+// RegisterFile forwards the file to the wrapped registrar and records it on success.
+func (r *recordingRegistrar) RegisterFile(name string, lines []string) error {
+	err := r.next.RegisterFile(name, lines)
+	if err != nil {
+		return fmt.Errorf("failed to register file: %w", err)
+	}
+
+	r.record(ScriptKindFile, name, lines)
+
+	return nil
+}
+
+// record appends a copy of the registration to the shared list.
+func (r *recordingRegistrar) record(kind ScriptKind, name string, lines []string) {
+	*r.scripts = append(*r.scripts, ScriptRegistration{
+		Service: r.service,
+		Kind:    kind,
+		Name:    name,
+		Lines:   append([]string(nil), lines...),
+	})
+}
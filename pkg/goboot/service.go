@@ -2,6 +2,7 @@ package goboot
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/it-timo/goboot/pkg/config"
 	"github.com/it-timo/goboot/pkg/goboottypes"
@@ -27,6 +28,24 @@ type Service interface {
 	Run() error
 }
 
+// DependentService is an optional extension of Service for services that must run after other services.
+//
+// Services that do not implement it have no explicit dependencies and run in declaration order.
+type DependentService interface {
+	// DependsOn returns the IDs of the services that must run before this service.
+	//
+	// Every returned ID must belong to a registered service; otherwise, the run fails.
+	DependsOn() []string
+}
+
+// CommandService is an optional extension of Service for services running an external executable (e.g., plugins).
+//
+// The command is recorded in the RunReport, as it runs in a dry run as well.
+type CommandService interface {
+	// Command returns the executable and arguments the service runs.
+	Command() []string
+}
+
 // serviceManager coordinates service registration and execution.
 //
 // It holds a registry of enabled service implementations and links them with their corresponding configurations
@@ -35,16 +54,25 @@ type serviceManager struct {
 	// services maps service IDs to their implementation.
 	services map[string]Service
 
+	// order keeps the service IDs in registration order (the order of `services:` in goboot.yml).
+	//
+	// It is used instead of ranging over the services map to keep execution deterministic.
+	order []string
+
 	// cfgMgr resolves and holds validated configuration instances by service ID.
 	cfgMgr *config.Manager
 
-	// priorServiceIDs defines the core services that must always run first,
-	// such as directory structure or root project setup.
-	priorServiceIDs []string
+	// output is injected into every goboottypes.OutputReceiver before it runs; may be nil.
+	output goboottypes.OutputFS
+
+	// log receives the progress messages of the run and is injected into every goboottypes.LogReceiver.
+	log goboottypes.Logger
 
-	// subsequentServiceIDs defines the care services that must always run last,
-	// such as script injections for multiple services.
-	subsequentServiceIDs []string
+	// scripts records the registrations made by script receivers during runAll.
+	scripts []ScriptRegistration
+
+	// commands records the executables run by command services during runAll.
+	commands []Command
 }
 
 // newServiceManager creates a new ServiceManager bound to the given config manager.
@@ -54,14 +82,7 @@ func newServiceManager(cfgMgr *config.Manager) *serviceManager {
 	return &serviceManager{
 		services: make(map[string]Service),
 		cfgMgr:   cfgMgr,
-		priorServiceIDs: []string{
-			goboottypes.ServiceNameBaseProject, // required to initialize the base project directory structure.
-			// future pre-services can be added here.
-		},
-		subsequentServiceIDs: []string{
-			goboottypes.ServiceNameBaseLocal, // required to handle the script files in fully.
-			// future sub-services can be added here.
-		},
+		log:      goboottypes.NewStdoutLogger(),
 	}
 }
 
@@ -77,178 +98,149 @@ func (sm *serviceManager) register(service Service) error {
 	}
 
 	sm.services[service.ID()] = service
+	sm.order = append(sm.order, service.ID())
 
 	return nil
 }
 
 // runAll executes all registered services that have a matching configuration.
 //
+// The execution order is resolved from the declared service dependencies and config roles (see resolvePlan)
+// and printed before any service runs.
+//
 // For each service:
 //   - If a config is available via cfgMgr, the service is executed with it
 //   - If no config is found, the service is skipped with a warning
 //
 // It returns the first encountered execution error, if any. Skipped services do not fail the run.
-//
-//nolint:cyclop // branching required for service dispatch logic; each path reflects a distinct lifecycle phase.
 func (sm *serviceManager) runAll() error {
-	err := sm.assignConfigs()
+	plan, err := sm.resolvePlan()
 	if err != nil {
-		return fmt.Errorf("failed to assign configs: %w", err)
+		return fmt.Errorf("failed to resolve execution plan: %w", err)
 	}
 
-	// Run priority bootstrap services first (e.g., base_project).
-	err = sm.runPriorServices()
+	if len(plan) > 0 {
+		sm.log.Printf("Resolved execution plan: %s\n", strings.Join(plan, " -> "))
+	}
+
+	err = sm.assignConfigs()
 	if err != nil {
-		return fmt.Errorf("failed to run prior services: %w", err)
+		return fmt.Errorf("failed to assign configs: %w", err)
 	}
 
-	for curID, svc := range sm.services {
-		// Skip services already handled in runPriorServices or will be handled by runSubsequentServices.
-		if sm.isPriorService(curID) || sm.isSubsequentService(curID) {
-			continue
+	for _, curID := range plan {
+		err = sm.runService(curID)
+		if err != nil {
+			return err
 		}
+	}
 
-		_, ok := sm.cfgMgr.GetRegistrar(curID)
-		if !ok {
-			_, ok = sm.cfgMgr.GetService(curID)
-			if !ok {
-				fmt.Printf("Service %q skipped (no configuration loaded)\n", curID)
-
-				continue
-			}
-		}
+	return nil
+}
 
-		receiver, isScriptRec := svc.(goboottypes.ScriptReceiver)
-		if isScriptRec {
-			registrar, isRegistrar := sm.services[goboottypes.ServiceNameBaseLocal].(goboottypes.Registrar)
-			if isRegistrar {
-				fmt.Printf("Injecting script registrar into %q\n", curID)
+// runService executes a single registered service.
+//
+// Output receivers get the shared output, and log receivers the logger injected before they run.
+// Script receivers get the registered Registrar injected before they run,
+// wrapped so that their registrations are recorded. The commands of command services are recorded as well.
+//
+// Services without a loaded configuration are skipped.
+func (sm *serviceManager) runService(curID string) error {
+	svc := sm.services[curID]
 
-				receiver.SetScriptReceiver(registrar)
-			}
-		}
+	if !sm.hasConfig(curID) {
+		sm.log.Printf("Service %q skipped (no configuration loaded)\n", curID)
 
-		err = svc.Run()
-		if err != nil {
-			return fmt.Errorf("failed to run service %q: %w", curID, err)
-		}
+		return nil
 	}
 
-	// Run later bootstrap services first (e.g., base_local).
-	err = sm.runSubsequentServices()
-	if err != nil {
-		return fmt.Errorf("failed to run subsequent services: %w", err)
+	outReceiver, isOutputRec := svc.(goboottypes.OutputReceiver)
+	if isOutputRec && sm.output != nil {
+		outReceiver.SetOutput(sm.output)
 	}
 
-	return nil
-}
-
-// assignConfigs calls on all registered services with a valid config the SetConfig.
-//
-// returns an error if any set fails.
-func (sm *serviceManager) assignConfigs() error {
-	for curID, svc := range sm.services {
-		cfg, ok := sm.cfgMgr.GetRegistrar(curID)
-		if !ok {
-			cfg, ok = sm.cfgMgr.GetService(curID)
-			if !ok {
-				continue
-			}
-		}
+	logReceiver, isLogRec := svc.(goboottypes.LogReceiver)
+	if isLogRec {
+		logReceiver.SetLogger(sm.log)
+	}
 
-		err := svc.SetConfig(cfg)
-		if err != nil {
-			return fmt.Errorf("failed to set config for %q: %w", curID, err)
+	receiver, isScriptRec := svc.(goboottypes.ScriptReceiver)
+	if isScriptRec {
+		registrar, isRegistrar := sm.registrar()
+		if isRegistrar {
+			sm.log.Printf("Injecting script registrar into %q\n", curID)
+			receiver.SetScriptReceiver(&recordingRegistrar{
+				next:    registrar,
+				service: curID,
+				scripts: &sm.scripts,
+			})
 		}
 	}
 
-	return nil
-}
+	cmdService, isCmdService := svc.(CommandService)
+	if isCmdService {
+		sm.commands = append(sm.commands, Command{Args: cmdService.Command(), Service: curID})
+	}
 
-// isPriorService checks if the given service ID is in the list of priority services.
-func (sm *serviceManager) isPriorService(id string) bool {
-	for _, ps := range sm.priorServiceIDs {
-		if ps == id {
-			return true
-		}
+	err := svc.Run()
+	if err != nil {
+		return fmt.Errorf("failed to run service %q: %w", curID, err)
 	}
 
-	return false
+	return nil
 }
 
-// isSubsequentService checks if the given service ID is in the list of later services.
-func (sm *serviceManager) isSubsequentService(id string) bool {
-	for _, ps := range sm.subsequentServiceIDs {
-		if ps == id {
-			return true
-		}
-	}
+// hasConfig reports whether a validated configuration is loaded for the given service ID.
+func (sm *serviceManager) hasConfig(id string) bool {
+	_, ok := sm.config(id)
 
-	return false
+	return ok
 }
 
-// runPriorServices executes predefined core services that must run before any other services.
-//
-// This is typically used to guarantee foundational setup (e.g., base_project) is completed
-// before rendering additional modules.
-//
-// The list of service IDs is hardcoded in a dedicated slice to allow future extension.
-func (sm *serviceManager) runPriorServices() error {
-	for _, serviceID := range sm.priorServiceIDs {
-		svc, okay := sm.services[serviceID]
-		if !okay {
-			fmt.Printf("Service %q skipped (not registered)\n", serviceID)
+// config returns the validated configuration loaded for the given service ID.
+func (sm *serviceManager) config(id string) (config.ServiceConfig, bool) {
+	return sm.cfgMgr.Get(id)
+}
 
-			continue
-		}
+// registrar returns the first registered service (in registration order) that implements goboottypes.Registrar.
+func (sm *serviceManager) registrar() (goboottypes.Registrar, bool) {
+	id, ok := sm.registrarID()
+	if !ok {
+		return nil, false
+	}
 
-		_, ok := sm.cfgMgr.GetRegistrar(serviceID)
-		if !ok {
-			_, ok = sm.cfgMgr.GetService(serviceID)
-			if !ok {
-				fmt.Printf("Prior Service %q skipped (no configuration loaded)\n", serviceID)
+	registrar, ok := sm.services[id].(goboottypes.Registrar)
 
-				continue
-			}
-		}
+	return registrar, ok
+}
 
-		err := svc.Run()
-		if err != nil {
-			return fmt.Errorf("failed to run service %q: %w", serviceID, err)
+// registrarID returns the ID of the first registered service that implements goboottypes.Registrar.
+func (sm *serviceManager) registrarID() (string, bool) {
+	for _, id := range sm.order {
+		_, ok := sm.services[id].(goboottypes.Registrar)
+		if ok {
+			return id, true
 		}
 	}
 
-	return nil
+	return "", false
 }
 
-// runSubsequentServices executes predefined care services that must run after any other services.
-//
-// This is typically used to guarantee foundational setup (e.g., base_local) is completed
-// before rendering this module.
+// assignConfigs calls on all registered services with a valid config the SetConfig.
 //
-// The list of service IDs is hardcoded in a dedicated slice to allow future extension.
-func (sm *serviceManager) runSubsequentServices() error {
-	for _, serviceID := range sm.subsequentServiceIDs {
-		svc, okay := sm.services[serviceID]
-		if !okay {
-			fmt.Printf("Service %q skipped (not registered)\n", serviceID)
-
-			continue
-		}
+// returns an error if any set fails.
+func (sm *serviceManager) assignConfigs() error {
+	for _, curID := range sm.order {
+		svc := sm.services[curID]
 
-		_, ok := sm.cfgMgr.GetRegistrar(serviceID)
+		cfg, ok := sm.config(curID)
 		if !ok {
-			_, ok = sm.cfgMgr.GetService(serviceID)
-			if !ok {
-				fmt.Printf("Subsequent Service %q skipped (no configuration loaded)\n", serviceID)
-
-				continue
-			}
+			continue
 		}
 
-		err := svc.Run()
+		err := svc.SetConfig(cfg)
 		if err != nil {
-			return fmt.Errorf("failed to run service %q: %w", serviceID, err)
+			return fmt.Errorf("failed to set config for %q: %w", curID, err)
 		}
 	}
 
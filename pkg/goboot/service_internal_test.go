@@ -6,6 +6,9 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/it-timo/goboot/pkg/baselint"
+	"github.com/it-timo/goboot/pkg/baselocal"
+	"github.com/it-timo/goboot/pkg/basetest"
 	"github.com/it-timo/goboot/pkg/config"
 	"github.com/it-timo/goboot/pkg/goboottypes"
 )
@@ -30,6 +33,29 @@ func (m *recordingScriptService) SetScriptReceiver(_ goboottypes.Registrar) {
 	m.registrarSet = true
 }
 
+// dependentService extends recordingService with declared dependencies.
+type dependentService struct {
+	recordingService
+	deps []string
+}
+
+func (m *dependentService) DependsOn() []string {
+	return m.deps
+}
+
+// registrarService extends recordingService to act as a script registrar.
+type registrarService struct {
+	recordingService
+}
+
+func (m *registrarService) RegisterLines(_ string, _ []string) error {
+	return nil
+}
+
+func (m *registrarService) RegisterFile(_ string, _ []string) error {
+	return nil
+}
+
 func (m *recordingService) ID() string {
 	return m.id
 }
@@ -61,7 +87,8 @@ func (m *recordingService) Run() error {
 }
 
 type mockServiceConfig struct {
-	id string
+	id   string
+	role config.Role
 }
 
 func (m *mockServiceConfig) ID() string {
@@ -72,10 +99,18 @@ func (m *mockServiceConfig) ReadConfig(_, _ string) error {
 	return nil
 }
 
+func (m *mockServiceConfig) DecodeConfig(_ []byte, _ string) error {
+	return nil
+}
+
 func (m *mockServiceConfig) Validate() error {
 	return nil
 }
 
+func (m *mockServiceConfig) Role() config.Role {
+	return m.role
+}
+
 var _ = Describe("serviceManager internals", func() {
 	var (
 		cfgMgr      *config.Manager
@@ -99,19 +134,15 @@ var _ = Describe("serviceManager internals", func() {
 		Expect(svc.runCalled).To(BeTrue())
 	})
 
-	It("runs prior, main, and subsequent services in order", func() {
-		Expect(cfgMgr.Register(&mockServiceConfig{id: goboottypes.ServiceNameBaseProject})).To(Succeed())
-		Expect(cfgMgr.Register(&mockServiceConfig{id: "custom"})).To(Succeed())
-		Expect(cfgMgr.Register(&mockServiceConfig{id: goboottypes.ServiceNameBaseLocal})).To(Succeed())
+	It("runs bootstrap, main, and finalizer services in order", func() {
+		Expect(cfgMgr.Register(&mockServiceConfig{id: "finalizer", role: config.RoleFinalizer})).To(Succeed())
+		Expect(cfgMgr.Register(&mockServiceConfig{id: "custom", role: config.RoleMain})).To(Succeed())
+		Expect(cfgMgr.Register(&mockServiceConfig{id: "bootstrap", role: config.RoleBootstrap})).To(Succeed())
 
 		var order []string
-		services := []*recordingService{
-			{id: goboottypes.ServiceNameBaseProject},
-			{id: "custom"},
-			{id: goboottypes.ServiceNameBaseLocal},
-		}
 
-		for _, svc := range services {
+		for _, id := range []string{"finalizer", "custom", "bootstrap"} {
+			svc := &recordingService{id: id}
 			svc.runHook = func() {
 				order = append(order, svc.id)
 			}
@@ -120,11 +151,43 @@ var _ = Describe("serviceManager internals", func() {
 
 		err := testManager.runAll()
 		Expect(err).NotTo(HaveOccurred())
-		Expect(order).To(Equal([]string{
-			goboottypes.ServiceNameBaseProject,
-			"custom",
-			goboottypes.ServiceNameBaseLocal,
-		}))
+		Expect(order).To(Equal([]string{"bootstrap", "custom", "finalizer"}))
+	})
+
+	It("reports dependencies against the order of config roles as cycles", func() {
+		Expect(cfgMgr.Register(&mockServiceConfig{id: "setup", role: config.RoleBootstrap})).To(Succeed())
+		Expect(cfgMgr.Register(&mockServiceConfig{id: "feature", role: config.RoleMain})).To(Succeed())
+
+		Expect(testManager.register(&dependentService{
+			recordingService: recordingService{id: "setup"}, deps: []string{"feature"},
+		})).To(Succeed())
+		Expect(testManager.register(&recordingService{id: "feature"})).To(Succeed())
+
+		_, err := testManager.resolvePlan()
+		Expect(err).To(MatchError(ContainSubstring("dependency cycle detected: setup -> feature -> setup")))
+	})
+
+	It("runs middle services in registration order", func() {
+		ids := []string{"zeta", "alpha", "mid", "beta", "omega"}
+
+		var order []string
+
+		for _, id := range ids {
+			Expect(cfgMgr.Register(&mockServiceConfig{id: id})).To(Succeed())
+
+			svc := &recordingService{id: id}
+			svc.runHook = func() {
+				order = append(order, svc.id)
+			}
+			Expect(testManager.register(svc)).To(Succeed())
+		}
+
+		for range 5 {
+			order = nil
+
+			Expect(testManager.runAll()).To(Succeed())
+			Expect(order).To(Equal(ids))
+		}
 	})
 
 	It("returns an error when assigning config fails", func() {
@@ -151,9 +214,98 @@ var _ = Describe("serviceManager internals", func() {
 		Expect(svc.runCalled).To(BeFalse())
 	})
 
-	It("skips prior services that are not registered", func() {
-		err := testManager.runPriorServices()
+	It("runs services after their declared dependencies", func() {
+		var order []string
+
+		track := func(id string) recordingService {
+			return recordingService{id: id, runHook: func() { order = append(order, id) }}
+		}
+
+		project := track("project")
+		services := []Service{
+			&dependentService{recordingService: track("local"), deps: []string{"project"}},
+			&dependentService{recordingService: track("lint"), deps: []string{"project"}},
+			&project,
+		}
+
+		for _, svc := range services {
+			Expect(cfgMgr.Register(&mockServiceConfig{id: svc.ID()})).To(Succeed())
+			Expect(testManager.register(svc)).To(Succeed())
+		}
+
+		Expect(testManager.runAll()).To(Succeed())
+		Expect(order).To(Equal([]string{"project", "local", "lint"}))
+	})
+
+	It("runs script receivers before the registrar", func() {
+		registrar := &registrarService{recordingService: recordingService{id: "scripts"}}
+		receiver := &recordingScriptService{recordingService: recordingService{id: "scripted"}}
+
+		Expect(testManager.register(registrar)).To(Succeed())
+		Expect(testManager.register(receiver)).To(Succeed())
+
+		plan, err := testManager.resolvePlan()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plan).To(Equal([]string{"scripted", "scripts"}))
+	})
+
+	It("resolves the built-in services into the expected plan", func() {
+		for _, factory := range ServiceFactories() {
+			Expect(cfgMgr.Register(&mockServiceConfig{id: factory.ID, role: factory.Phase()})).To(Succeed())
+		}
+
+		Expect(testManager.register(baselocal.NewBaseLocal("out"))).To(Succeed())
+		Expect(testManager.register(basetest.NewBaseTest("out"))).To(Succeed())
+		Expect(testManager.register(&recordingService{id: goboottypes.ServiceNameBaseProject})).To(Succeed())
+		Expect(testManager.register(baselint.NewBaseLint("out"))).To(Succeed())
+
+		plan, err := testManager.resolvePlan()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plan).To(Equal([]string{
+			goboottypes.ServiceNameBaseProject,
+			goboottypes.ServiceNameBaseTest,
+			goboottypes.ServiceNameBaseLint,
+			goboottypes.ServiceNameBaseLocal,
+		}))
+	})
+
+	It("runs the built-in services without base_project", func() {
+		for _, id := range []string{goboottypes.ServiceNameBaseLint, goboottypes.ServiceNameBaseLocal} {
+			factory, _ := serviceFactory(id)
+			Expect(cfgMgr.Register(&mockServiceConfig{id: id, role: factory.Phase()})).To(Succeed())
+		}
+
+		Expect(testManager.register(baselocal.NewBaseLocal("out"))).To(Succeed())
+		Expect(testManager.register(baselint.NewBaseLint("out"))).To(Succeed())
+
+		plan, err := testManager.resolvePlan()
 		Expect(err).NotTo(HaveOccurred())
+		Expect(plan).To(Equal([]string{goboottypes.ServiceNameBaseLint, goboottypes.ServiceNameBaseLocal}))
+	})
+
+	It("reports missing dependencies", func() {
+		svc := &dependentService{recordingService: recordingService{id: "lint"}, deps: []string{"project"}}
+		Expect(testManager.register(svc)).To(Succeed())
+
+		err := testManager.runAll()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("missing service dependencies"))
+		Expect(err.Error()).To(ContainSubstring("lint requires project"))
+		Expect(svc.runCalled).To(BeFalse())
+	})
+
+	It("reports dependency cycles", func() {
+		Expect(testManager.register(&recordingService{id: "free"})).To(Succeed())
+		Expect(testManager.register(&dependentService{
+			recordingService: recordingService{id: "first"}, deps: []string{"second"},
+		})).To(Succeed())
+		Expect(testManager.register(&dependentService{
+			recordingService: recordingService{id: "second"}, deps: []string{"first"},
+		})).To(Succeed())
+
+		err := testManager.runAll()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("dependency cycle detected: first -> second -> first"))
 	})
 
 	It("skips prior services without configuration", func() {
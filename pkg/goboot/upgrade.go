@@ -0,0 +1,514 @@
+package goboot
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/it-timo/goboot/pkg/config"
+	"github.com/it-timo/goboot/pkg/gobootfs"
+	"github.com/it-timo/goboot/pkg/goboottypes"
+	"github.com/it-timo/goboot/pkg/gobootutils"
+)
+
+// UpgradeAction describes what Upgrade did with a single file.
+type UpgradeAction string
+
+const (
+	// UpgradeUnchanged marks files that already had the upgraded content; they are not written.
+	UpgradeUnchanged UpgradeAction = "unchanged"
+
+	// UpgradeCreated marks files generated for the first time.
+	UpgradeCreated UpgradeAction = "created"
+
+	// UpgradeUpdated marks files that were not edited since generation and are replaced with the new render.
+	UpgradeUpdated UpgradeAction = "updated"
+
+	// UpgradeMerged marks edited files whose edits were merged cleanly with the new render.
+	UpgradeMerged UpgradeAction = "merged"
+
+	// UpgradeConflict marks edited files whose edits conflict with the new render.
+	UpgradeConflict UpgradeAction = "conflict"
+
+	// UpgradeSkipped marks generated files that were deleted locally; they stay deleted.
+	UpgradeSkipped UpgradeAction = "skipped"
+
+	// UpgradeObsolete marks files that are no longer generated; they are left in place.
+	UpgradeObsolete UpgradeAction = "obsolete"
+)
+
+// UpgradeChange is the upgrade result of a single file.
+type UpgradeChange struct {
+	Path   string        // Slash-separated path relative to the project root.
+	Action UpgradeAction // What happened to the file.
+	Reject string        // Path of the file holding rejected sections (goboottypes.MergeStyleReject only).
+}
+
+// UpgradeReport is the result of Upgrade.
+type UpgradeReport struct {
+	Files    []UpgradeChange // Upgrade results, sorted by path.
+	Warnings []string        // Problems that degraded the upgrade without stopping it.
+}
+
+// Count returns the number of files with the given action.
+func (r UpgradeReport) Count(action UpgradeAction) int {
+	count := 0
+
+	for _, file := range r.Files {
+		if file.Action == action {
+			count++
+		}
+	}
+
+	return count
+}
+
+// Print writes the warnings, the changed files, and a summary line to w.
+func (r UpgradeReport) Print(w io.Writer) error {
+	var buf strings.Builder
+
+	for _, warning := range r.Warnings {
+		fmt.Fprintf(&buf, "Warning: %s\n", warning)
+	}
+
+	for _, file := range r.Files {
+		if file.Action == UpgradeUnchanged {
+			continue
+		}
+
+		fmt.Fprintf(&buf, "  %-10s %s", file.Action, file.Path)
+
+		if file.Reject != "" {
+			fmt.Fprintf(&buf, " (rejected sections in %s)", file.Reject)
+		}
+
+		buf.WriteString("\n")
+	}
+
+	fmt.Fprintf(&buf, "Upgraded %d files: %d created, %d updated, %d merged, %d conflicts, "+
+		"%d skipped, %d obsolete, %d unchanged\n",
+		len(r.Files), r.Count(UpgradeCreated), r.Count(UpgradeUpdated), r.Count(UpgradeMerged),
+		r.Count(UpgradeConflict), r.Count(UpgradeSkipped), r.Count(UpgradeObsolete), r.Count(UpgradeUnchanged))
+
+	_, err := io.WriteString(w, buf.String())
+	if err != nil {
+		return fmt.Errorf("failed to write upgrade report: %w", err)
+	}
+
+	return nil
+}
+
+// upgrade holds the state of a single Upgrade or Add run.
+type upgrade struct {
+	projectDir string               // Project directory on disk.
+	out        goboottypes.OutputFS // Output writing into the project directory.
+	style      string               // Merge conflict style (see goboottypes.MergeStyles).
+	lock       *Lock                // Lock file of the project before the run.
+	recorded   map[string]LockFile  // Files recorded in the lock by path.
+	base       map[string][]byte    // Rendered content at generation by path (see Base); nil if unavailable.
+	untouched  map[string]bool      // Paths of the new render that were left alone.
+	report     *UpgradeReport
+}
+
+// Upgrade brings the templates and configs of cfg into the generated project in projectDir.
+//
+// Every file is three-way merged from:
+//   - the base: the content rendered at generation (goboottypes.BaseFileName)
+//   - the next version: the render of cfg
+//   - the current file in projectDir
+//
+// Files not edited since generation are replaced, edits are merged line by line,
+// and conflicts are written according to style (see goboottypes.MergeStyles).
+// Finally, the lock and base files are replaced with the ones of the new render.
+//
+// Projects without a base file (generated by older goboot versions) use the render of the configs
+// recorded in the lock file (goboottypes.LockFileName) instead; files whose templates changed since
+// then have no base, so their edits become whole-file conflicts.
+func Upgrade(projectDir string, cfg *config.GoBoot, style string) (*UpgradeReport, error) {
+	upg, release, err := openUpgrade(projectDir, style)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	if upg.lock.ProjectName != cfg.ProjectName {
+		return nil, fmt.Errorf("config is for project %q, but %s belongs to %q",
+			cfg.ProjectName, goboottypes.LockFileName, upg.lock.ProjectName)
+	}
+
+	next, _, err := render(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render the upgraded project: %w", err)
+	}
+
+	if upg.base == nil {
+		// The plugins of cfg are declared by the caller; those recorded in the lock are not trusted.
+		upg.renderBase(false)
+	}
+
+	err = upg.apply(next, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	upg.findObsolete(next)
+
+	return upg.finish(next)
+}
+
+// openUpgrade opens the project directory and reads its lock file.
+//
+// The returned release function must always be called.
+func openUpgrade(projectDir, style string) (*upgrade, func(), error) {
+	if style == "" {
+		style = goboottypes.DefaultMergeStyle
+	}
+
+	if !slices.Contains(goboottypes.MergeStyles(), style) {
+		return nil, nil, fmt.Errorf("invalid conflict style %q (must be one of: %s)",
+			style, strings.Join(goboottypes.MergeStyles(), ", "))
+	}
+
+	projectDir = filepath.Clean(projectDir)
+
+	out, release, err := gobootfs.Open(nil, filepath.Dir(projectDir), filepath.Base(projectDir))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open project directory: %w", err)
+	}
+
+	data, err := out.ReadFile(goboottypes.LockFileName)
+	if err != nil {
+		release()
+
+		return nil, nil, fmt.Errorf("failed to read %s: %w", goboottypes.LockFileName, err)
+	}
+
+	lock, err := ParseLock(data)
+	if err != nil {
+		release()
+
+		return nil, nil, err
+	}
+
+	base, err := readBase(out)
+	if err != nil {
+		release()
+
+		return nil, nil, err
+	}
+
+	upg := &upgrade{
+		projectDir: projectDir,
+		out:        out,
+		style:      style,
+		lock:       lock,
+		recorded:   make(map[string]LockFile, len(lock.Files)),
+		untouched:  make(map[string]bool),
+		report:     &UpgradeReport{},
+	}
+
+	for _, file := range lock.Files {
+		upg.recorded[file.Path] = file
+	}
+
+	if base != nil {
+		upg.base = make(map[string][]byte, len(base.Files))
+
+		for _, file := range base.Files {
+			upg.base[file.Path] = []byte(file.Content)
+		}
+	}
+
+	return upg, release, nil
+}
+
+// renderBase renders the configs recorded in the lock as the merge base of projects without a base file.
+//
+// Plugins recorded in the lock are only run if allowPlugins is set (see ErrPluginsNotAllowed).
+// If that fails, a warning is recorded and the run continues without a base.
+func (u *upgrade) renderBase(allowPlugins bool) {
+	cfg, err := u.lock.Config(filepath.Dir(u.projectDir), allowPlugins)
+	if err == nil {
+		var rendered *gobootfs.MemoryFS
+
+		rendered, _, err = render(cfg)
+		if err == nil {
+			u.base = make(map[string][]byte)
+
+			for _, file := range rendered.Files() {
+				u.base[file.Path] = file.Content
+			}
+
+			return
+		}
+	}
+
+	u.report.Warnings = append(u.report.Warnings,
+		fmt.Sprintf("cannot render the recorded configs, edited files become conflicts: %v", err))
+}
+
+// trustedBase returns the base of the given path if it matches the render recorded in the lock.
+//
+// The base file always does, unless it was edited. A base rendered from the recorded configs does not
+// for templates changed in place since the generation, so it is not trusted for those.
+func (u *upgrade) trustedBase(path string) ([]byte, bool) {
+	recorded, inLock := u.recorded[path]
+	base, ok := u.base[path]
+
+	if !ok || !inLock || gobootutils.HashContent(base) != recorded.Rendered() {
+		return nil, false
+	}
+
+	return base, true
+}
+
+// apply upgrades the files of the new render accepted by include (all files if include is nil).
+func (u *upgrade) apply(next *gobootfs.MemoryFS, include func(file goboottypes.OutputFile) bool) error {
+	for _, file := range next.Files() {
+		if file.Path == goboottypes.LockFileName || file.Path == goboottypes.BaseFileName {
+			continue
+		}
+
+		if include != nil && !include(file) {
+			u.untouched[file.Path] = true
+
+			continue
+		}
+
+		change, err := u.upgradeFile(file)
+		if err != nil {
+			return fmt.Errorf("failed to upgrade %q: %w", file.Path, err)
+		}
+
+		u.report.Files = append(u.report.Files, change)
+	}
+
+	return nil
+}
+
+// finish writes the new lock and base files and returns the sorted report.
+func (u *upgrade) finish(next *gobootfs.MemoryFS) (*UpgradeReport, error) {
+	err := u.writeLock(next)
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.writeBase(next)
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(u.report.Files, func(a, b UpgradeChange) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+
+	return u.report, nil
+}
+
+// upgradeFile merges a single file of the new render into the project.
+//
+// Without a trusted base (see trustedBase), files not edited since generation are replaced
+// and edited files become whole-file conflicts.
+func (u *upgrade) upgradeFile(file goboottypes.OutputFile) (UpgradeChange, error) {
+	change := UpgradeChange{Path: file.Path}
+	recorded, inLock := u.recorded[file.Path]
+	base, hasBase := u.trustedBase(file.Path)
+
+	exists, err := u.out.Exists(file.Path)
+	if err != nil {
+		return change, fmt.Errorf("failed to check file: %w", err)
+	}
+
+	if !exists {
+		if inLock {
+			change.Action = UpgradeSkipped
+
+			return change, nil
+		}
+
+		change.Action = UpgradeCreated
+
+		return change, u.write(file, file.Content)
+	}
+
+	current, err := u.out.ReadFile(file.Path)
+	if err != nil {
+		return change, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	switch {
+	case bytes.Equal(current, file.Content):
+		change.Action = UpgradeUnchanged
+
+		return change, nil
+	case hasBase && bytes.Equal(current, base),
+		!hasBase && inLock && gobootutils.HashContent(current) == recorded.SHA256:
+		change.Action = UpgradeUpdated
+
+		return change, u.write(file, file.Content)
+	}
+
+	// Merging over the markers of an earlier conflict would hide it; the file and its lock entry stay as they are.
+	if gobootutils.HasConflictMarkers(current) {
+		u.untouched[file.Path] = true
+		u.report.Warnings = append(u.report.Warnings,
+			fmt.Sprintf("%s still has unresolved conflict markers; resolve them and run again", file.Path))
+		change.Action = UpgradeConflict
+
+		return change, nil
+	}
+
+	return u.writeMerge(file, current, gobootutils.Merge3(base, current, file.Content))
+}
+
+// writeMerge writes the merge result of an edited file according to the conflict style.
+func (u *upgrade) writeMerge(file goboottypes.OutputFile, current []byte, merge *gobootutils.Merge) (UpgradeChange, error) {
+	change := UpgradeChange{Path: file.Path, Action: UpgradeMerged}
+
+	if merge.Conflicts() > 0 {
+		change.Action = UpgradeConflict
+	}
+
+	content := merge.Markers("current", "goboot "+goboottypes.Version)
+
+	if merge.Conflicts() > 0 && u.style == goboottypes.MergeStyleReject {
+		var rejects []byte
+
+		content, rejects = merge.Rejects()
+		change.Reject = file.Path + goboottypes.RejectSuffix
+
+		err := u.out.WriteFile(goboottypes.OutputFile{
+			Path:    filepath.FromSlash(change.Reject),
+			Content: rejects,
+			Perm:    goboottypes.FilePerm,
+		})
+		if err != nil {
+			return change, fmt.Errorf("failed to write rejected sections: %w", err)
+		}
+	}
+
+	if bytes.Equal(content, current) {
+		if change.Action == UpgradeMerged {
+			change.Action = UpgradeUnchanged
+		}
+
+		return change, nil
+	}
+
+	return change, u.write(file, content)
+}
+
+// write writes the given content to the path of the rendered file.
+func (u *upgrade) write(file goboottypes.OutputFile, content []byte) error {
+	file.Path = filepath.FromSlash(file.Path)
+	file.Content = content
+
+	err := u.out.WriteFile(file)
+	if err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return nil
+}
+
+// findObsolete reports files recorded in the lock that the new render no longer generates.
+func (u *upgrade) findObsolete(next *gobootfs.MemoryFS) {
+	for _, file := range u.lock.Files {
+		generated, err := next.Exists(file.Path)
+		if err != nil || generated {
+			continue
+		}
+
+		exists, err := u.out.Exists(filepath.FromSlash(file.Path))
+		if err != nil || !exists {
+			continue
+		}
+
+		u.report.Files = append(u.report.Files, UpgradeChange{Path: file.Path, Action: UpgradeObsolete})
+	}
+}
+
+// writeBase replaces the base file with the one of the new render.
+//
+// Files left untouched keep their trusted base (see trustedBase); files without one get no base.
+func (u *upgrade) writeBase(next *gobootfs.MemoryFS) error {
+	data, err := next.ReadFile(goboottypes.BaseFileName)
+	if err != nil {
+		return fmt.Errorf("failed to read upgraded base file: %w", err)
+	}
+
+	base, err := ParseBase(data)
+	if err != nil {
+		return err
+	}
+
+	files := make([]BaseFile, 0, len(base.Files))
+
+	for _, file := range base.Files {
+		if u.untouched[file.Path] {
+			content, ok := u.trustedBase(file.Path)
+			if !ok {
+				continue
+			}
+
+			file.Content = string(content)
+		}
+
+		files = append(files, file)
+	}
+
+	base.Files = files
+
+	return writeBase(u.out, base)
+}
+
+// writeLock replaces the lock file with the one of the new render.
+//
+// Files left untouched keep their recorded entry. The go.mod checksum is refreshed afterward,
+// as go.mod is maintained by the go tool after generation, unless go.mod has unresolved conflicts.
+func (u *upgrade) writeLock(next *gobootfs.MemoryFS) error {
+	data, err := next.ReadFile(goboottypes.LockFileName)
+	if err != nil {
+		return fmt.Errorf("failed to read upgraded lock file: %w", err)
+	}
+
+	lock, err := ParseLock(data)
+	if err != nil {
+		return err
+	}
+
+	for i, file := range lock.Files {
+		recorded, ok := u.recorded[file.Path]
+		if ok && u.untouched[file.Path] {
+			lock.Files[i] = recorded
+		}
+	}
+
+	data, err = lock.Marshal()
+	if err != nil {
+		return err
+	}
+
+	err = u.out.WriteFile(goboottypes.OutputFile{
+		Path:    goboottypes.LockFileName,
+		Content: data,
+		Perm:    goboottypes.FilePerm,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", goboottypes.LockFileName, err)
+	}
+
+	if slices.Contains(u.report.Files, UpgradeChange{Path: "go.mod", Action: UpgradeConflict}) {
+		return nil
+	}
+
+	err = refreshLock(u.projectDir, "go.mod")
+	if err != nil {
+		return fmt.Errorf("failed to update lock file: %w", err)
+	}
+
+	return nil
+}
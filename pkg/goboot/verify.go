@@ -0,0 +1,279 @@
+package goboot
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/it-timo/goboot/pkg/config"
+	"github.com/it-timo/goboot/pkg/gobootfs"
+	"github.com/it-timo/goboot/pkg/goboottypes"
+	"github.com/it-timo/goboot/pkg/gobootutils"
+)
+
+// FileStatus describes how a generated file compares to its re-rendered content.
+type FileStatus string
+
+const (
+	// FileUnchanged marks files whose content matches what goboot generated.
+	FileUnchanged FileStatus = "unchanged"
+
+	// FileModified marks files that were edited after generation.
+	FileModified FileStatus = "modified"
+
+	// FileDeleted marks generated files that no longer exist.
+	FileDeleted FileStatus = "deleted"
+
+	// FileExtra marks files in the generated directories that goboot does not generate,
+	// including files recorded in the lock that the current templates no longer generate.
+	FileExtra FileStatus = "extra"
+)
+
+// verifyIgnored lists the files in the generated directories that are never extra:
+// the lock and base files of goboot itself, and go.sum, which go mod tidy writes after generation.
+var verifyIgnored = []string{goboottypes.LockFileName, goboottypes.BaseFileName, "go.sum"}
+
+// FileDrift is the verification result of a single file.
+type FileDrift struct {
+	Path   string     // Slash-separated path relative to the project root.
+	Status FileStatus // Comparison result.
+}
+
+// VerifyReport is the result of Verify.
+type VerifyReport struct {
+	Files    []FileDrift // Verification results, sorted by path.
+	Commands []Command   // Plugins run to re-render the project.
+}
+
+// HasDrift reports whether any file is not unchanged.
+func (r VerifyReport) HasDrift() bool {
+	return slices.ContainsFunc(r.Files, func(file FileDrift) bool {
+		return file.Status != FileUnchanged
+	})
+}
+
+// Count returns the number of files with the given status.
+func (r VerifyReport) Count(status FileStatus) int {
+	count := 0
+
+	for _, file := range r.Files {
+		if file.Status == status {
+			count++
+		}
+	}
+
+	return count
+}
+
+// Print writes the plugins run, the drifted files, and a summary line to w.
+func (r VerifyReport) Print(w io.Writer) error {
+	var buf strings.Builder
+
+	for _, cmd := range r.Commands {
+		fmt.Fprintf(&buf, "Ran %s\n", cmd)
+	}
+
+	for _, file := range r.Files {
+		if file.Status != FileUnchanged {
+			fmt.Fprintf(&buf, "  %-10s %s\n", file.Status, file.Path)
+		}
+	}
+
+	fmt.Fprintf(&buf, "Verified %d files: %d unchanged, %d modified, %d deleted, %d extra\n",
+		len(r.Files), r.Count(FileUnchanged), r.Count(FileModified), r.Count(FileDeleted), r.Count(FileExtra))
+
+	_, err := io.WriteString(w, buf.String())
+	if err != nil {
+		return fmt.Errorf("failed to write verify report: %w", err)
+	}
+
+	return nil
+}
+
+// Verify detects drift between a generated project and its generation manifest (goboottypes.LockFileName).
+//
+// It re-renders the project in memory with the service configs recorded in the lock
+// and compares the result with the files in projectDir:
+//   - unchanged: the file matches the re-rendered content, or the checksum recorded in the lock
+//     (e.g., go.mod after go mod tidy)
+//   - modified: the file differs from both
+//   - deleted: the file is missing
+//   - extra: the file is in a directory goboot generates files into (not in its subdirectories), but is not generated,
+//     or it is recorded in the lock and still exists, but is no longer generated
+//
+// Template paths recorded in the lock are resolved relative to the current working directory,
+// just like during generation. Nothing is written to disk.
+//
+// Plugin services recorded in the lock are only run if allowPlugins is set (see ErrPluginsNotAllowed).
+func Verify(projectDir string, allowPlugins bool) (*VerifyReport, error) {
+	root, err := os.OpenRoot(projectDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open project directory: %w", err)
+	}
+
+	defer func() {
+		_ = root.Close()
+	}()
+
+	data, err := root.ReadFile(goboottypes.LockFileName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", goboottypes.LockFileName, err)
+	}
+
+	lock, err := ParseLock(data)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := lock.Config(filepath.Dir(filepath.Clean(projectDir)), allowPlugins)
+	if err != nil {
+		return nil, err
+	}
+
+	rendered, commands, err := render(cfg)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to re-render project (relative paths in %s resolve against "+
+			"the working directory, run goboot from where the project was generated): %w", goboottypes.LockFileName, err)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to re-render project: %w", err)
+	}
+
+	report, err := compareRendered(root, lock, rendered)
+	if err != nil {
+		return nil, err
+	}
+
+	report.Commands = commands
+
+	return report, nil
+}
+
+// render runs all services of the given config into memory and returns the output and the plugins run.
+func render(cfg *config.GoBoot) (*gobootfs.MemoryFS, []Command, error) {
+	app := NewGoBoot(cfg)
+	app.SetDryRun(true)
+	app.memory = gobootfs.NewMemoryFS(nil)
+
+	err := app.RegisterServices()
+	if err != nil {
+		return nil, nil, fmt.Errorf("service registration failed: %w", err)
+	}
+
+	err = app.RunServices()
+	if err != nil {
+		return nil, nil, fmt.Errorf("service execution failed: %w", err)
+	}
+
+	return app.memory, app.report.Commands, nil
+}
+
+// compareRendered compares the re-rendered files with the files in the project root.
+func compareRendered(root *os.Root, lock *Lock, rendered *gobootfs.MemoryFS) (*VerifyReport, error) {
+	recorded := make(map[string]string, len(lock.Files))
+	for _, file := range lock.Files {
+		recorded[file.Path] = file.SHA256
+	}
+
+	report := &VerifyReport{}
+	generated := make(map[string]bool)
+
+	for _, file := range rendered.Files() {
+		if slices.Contains(verifyIgnored, file.Path) {
+			continue
+		}
+
+		generated[file.Path] = true
+
+		actual, err := root.ReadFile(filepath.FromSlash(file.Path))
+		if err != nil {
+			if !errors.Is(err, fs.ErrNotExist) {
+				return nil, fmt.Errorf("failed to read %q: %w", file.Path, err)
+			}
+
+			report.Files = append(report.Files, FileDrift{Path: file.Path, Status: FileDeleted})
+
+			continue
+		}
+
+		status := FileModified
+		if bytes.Equal(actual, file.Content) || gobootutils.HashContent(actual) == recorded[file.Path] {
+			status = FileUnchanged
+		}
+
+		report.Files = append(report.Files, FileDrift{Path: file.Path, Status: status})
+	}
+
+	extra, err := findExtra(root, lock, generated)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range extra {
+		report.Files = append(report.Files, FileDrift{Path: name, Status: FileExtra})
+	}
+
+	slices.SortFunc(report.Files, func(a, b FileDrift) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+
+	return report, nil
+}
+
+// findExtra returns the sorted paths of the existing files that are not generated:
+// the files in every directory the render generates files into (without descending into subdirectories),
+// and the files recorded in the lock.
+func findExtra(root *os.Root, lock *Lock, generated map[string]bool) ([]string, error) {
+	extra := make(map[string]bool)
+
+	dirs := make(map[string]bool)
+	for name := range generated {
+		dirs[path.Dir(name)] = true
+	}
+
+	for dir := range dirs {
+		entries, err := fs.ReadDir(root.FS(), dir)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+
+			return nil, fmt.Errorf("failed to read directory %q: %w", dir, err)
+		}
+
+		for _, entry := range entries {
+			name := path.Join(dir, entry.Name())
+			if !entry.IsDir() && !generated[name] && !slices.Contains(verifyIgnored, name) {
+				extra[name] = true
+			}
+		}
+	}
+
+	for _, file := range lock.Files {
+		if generated[file.Path] || extra[file.Path] {
+			continue
+		}
+
+		_, err := root.Stat(filepath.FromSlash(file.Path))
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+
+			return nil, fmt.Errorf("failed to stat %q: %w", file.Path, err)
+		}
+
+		extra[file.Path] = true
+	}
+
+	return slices.Sorted(maps.Keys(extra)), nil
+}
@@ -0,0 +1,50 @@
+/*
+Package gobootfs provides the output filesystems that goboot services write generated files into.
+
+It implements goboottypes.OutputFS for:
+  - RootFS: the project directory on disk, confined by a secure os.Root.
+  - MemoryFS: an in-memory filesystem used for dry runs; nothing is written to disk.
+
+The Recorder wraps any OutputFS, stages the writes of a run until all services succeeded,
+and keeps track of which files the run creates or overwrites.
+
+On the input side, Templates resolves the template source of a service, either a directory on disk
+or a template set embedded into the binary.
+
+Unlike gobootutils, the types in this package hold state and are owned by the orchestrator,
+which injects them into the services via goboottypes.OutputReceiver.
+*/
+package gobootfs
+
+import (
+	"fmt"
+
+	"github.com/it-timo/goboot/pkg/goboottypes"
+)
+
+// Open returns the output a service should write into.
+//
+// If an output was injected by the orchestrator, it is returned as-is.
+// Otherwise, a RootFS for the project directory (targetDir/projectName) is opened,
+// which keeps services usable on their own.
+//
+// The returned release function must always be called once the service is done writing.
+func Open(injected goboottypes.OutputFS, targetDir, projectName string) (goboottypes.OutputFS, func(), error) {
+	if injected != nil {
+		return injected, func() {}, nil
+	}
+
+	rootFS, err := OpenRootFS(targetDir, projectName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	release := func() {
+		err := rootFS.Close()
+		if err != nil {
+			fmt.Println("Failed to close root dir:", err)
+		}
+	}
+
+	return rootFS, release, nil
+}
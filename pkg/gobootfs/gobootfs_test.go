@@ -0,0 +1,403 @@
+package gobootfs_test
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing/fstest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/it-timo/goboot/pkg/gobootfs"
+	"github.com/it-timo/goboot/pkg/goboottypes"
+	"github.com/it-timo/goboot/pkg/gobootutils"
+)
+
+var _ = Describe("Output filesystems", func() {
+	var tempDir string
+
+	BeforeEach(func() {
+		tempDir = GinkgoT().TempDir()
+	})
+
+	Describe("RootFS", func() {
+		var rootFS *gobootfs.RootFS
+
+		BeforeEach(func() {
+			var err error
+			rootFS, err = gobootfs.OpenRootFS(tempDir, "project")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			Expect(rootFS.Close()).To(Succeed())
+		})
+
+		It("creates the project directory", func() {
+			Expect(filepath.Join(tempDir, "project")).To(BeADirectory())
+		})
+
+		It("writes files with parent directories and permissions", func() {
+			Expect(rootFS.WriteFile(goboottypes.OutputFile{
+				Path:    filepath.Join("scripts", "lint.sh"),
+				Content: []byte("echo lint"),
+				Perm:    goboottypes.ScriptPerm,
+			})).To(Succeed())
+
+			target := filepath.Join(tempDir, "project", "scripts", "lint.sh")
+			info, err := os.Stat(target)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.Mode().Perm()).To(Equal(os.FileMode(goboottypes.ScriptPerm)))
+
+			content, err := rootFS.ReadFile(filepath.Join("scripts", "lint.sh"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(Equal("echo lint"))
+		})
+
+		It("truncates existing files", func() {
+			Expect(rootFS.WriteFile(goboottypes.OutputFile{Path: "a.txt", Content: []byte("long content"), Perm: goboottypes.FilePerm})).To(Succeed())
+			Expect(rootFS.WriteFile(goboottypes.OutputFile{Path: "a.txt", Content: []byte("short"), Perm: goboottypes.FilePerm})).To(Succeed())
+
+			content, err := rootFS.ReadFile("a.txt")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(Equal("short"))
+		})
+
+		It("reports existing paths", func() {
+			Expect(rootFS.MkdirAll(filepath.Join("a", "b"))).To(Succeed())
+
+			exists, err := rootFS.Exists(filepath.Join("a", "b"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(exists).To(BeTrue())
+
+			exists, err = rootFS.Exists("missing")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(exists).To(BeFalse())
+		})
+
+		It("rejects paths escaping the root", func() {
+			err := rootFS.WriteFile(goboottypes.OutputFile{Path: filepath.Join("..", "escape.txt")})
+			Expect(err).To(HaveOccurred())
+			Expect(filepath.Join(tempDir, "escape.txt")).NotTo(BeAnExistingFile())
+		})
+	})
+
+	Describe("MemoryFS", func() {
+		It("keeps writes in memory", func() {
+			memory := gobootfs.NewMemoryFS(nil)
+
+			Expect(memory.WriteFile(goboottypes.OutputFile{
+				Path:    filepath.Join("cmd", "app", "main.go"),
+				Content: []byte("package main"),
+				Perm:    goboottypes.FilePerm,
+			})).To(Succeed())
+
+			content, err := memory.ReadFile("cmd/app/main.go")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(Equal("package main"))
+
+			exists, err := memory.Exists("cmd")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(exists).To(BeTrue())
+
+			entries, err := os.ReadDir(tempDir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(entries).To(BeEmpty())
+		})
+
+		It("reads through to the base filesystem", func() {
+			memory := gobootfs.NewMemoryFS(fstest.MapFS{
+				"README.md": {Data: []byte("existing")},
+			})
+
+			exists, err := memory.Exists("README.md")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(exists).To(BeTrue())
+
+			content, err := memory.ReadFile("README.md")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(Equal("existing"))
+
+			Expect(memory.WriteFile(goboottypes.OutputFile{Path: "README.md", Content: []byte("new")})).To(Succeed())
+
+			content, err = memory.ReadFile("README.md")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(Equal("new"))
+		})
+
+		It("returns written files sorted by path", func() {
+			memory := gobootfs.NewMemoryFS(nil)
+
+			Expect(memory.WriteFile(goboottypes.OutputFile{Path: "b.txt"})).To(Succeed())
+			Expect(memory.WriteFile(goboottypes.OutputFile{Path: "a.txt"})).To(Succeed())
+			Expect(memory.MkdirAll("empty")).To(Succeed())
+
+			files := memory.Files()
+			Expect(files).To(HaveLen(2))
+			Expect(files[0].Path).To(Equal("a.txt"))
+			Expect(files[1].Path).To(Equal("b.txt"))
+		})
+
+		It("rejects paths escaping the root", func() {
+			memory := gobootfs.NewMemoryFS(nil)
+
+			err := memory.WriteFile(goboottypes.OutputFile{Path: "../escape.txt"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("path escapes root"))
+
+			Expect(memory.MkdirAll("/abs")).To(HaveOccurred())
+		})
+	})
+
+	Describe("Recorder", func() {
+		var memory *gobootfs.MemoryFS
+
+		BeforeEach(func() {
+			memory = gobootfs.NewMemoryFS(fstest.MapFS{
+				"Makefile": {Data: []byte("existing")},
+			})
+		})
+
+		It("records created and overwritten files once per path", func() {
+			recorder := gobootfs.NewRecorder(memory, goboottypes.ConflictPolicyOverwrite)
+
+			Expect(recorder.WriteFile(goboottypes.OutputFile{Path: "Makefile"})).To(Succeed())
+			Expect(recorder.WriteFile(goboottypes.OutputFile{Path: filepath.Join("scripts", "lint.sh")})).To(Succeed())
+			Expect(recorder.WriteFile(goboottypes.OutputFile{Path: filepath.Join("scripts", "lint.sh")})).To(Succeed())
+			Expect(recorder.Commit()).To(Succeed())
+
+			Expect(recorder.Changes()).To(Equal([]gobootfs.Change{
+				{Path: "Makefile", Action: gobootfs.ActionOverwrite},
+				{Path: "scripts/lint.sh", Action: gobootfs.ActionCreate},
+			}))
+		})
+
+		It("does not record failed writes", func() {
+			recorder := gobootfs.NewRecorder(memory, goboottypes.ConflictPolicyOverwrite)
+
+			Expect(recorder.WriteFile(goboottypes.OutputFile{Path: "../escape.txt"})).NotTo(Succeed())
+			Expect(recorder.Changes()).To(BeEmpty())
+		})
+
+		It("stages writes until commit", func() {
+			recorder := gobootfs.NewRecorder(memory, goboottypes.ConflictPolicyOverwrite)
+
+			Expect(recorder.MkdirAll("docs")).To(Succeed())
+			Expect(recorder.WriteFile(goboottypes.OutputFile{Path: "README.md", Content: []byte("new")})).To(Succeed())
+
+			Expect(memory.Files()).To(BeEmpty())
+			Expect(recorder.Exists("README.md")).To(BeTrue())
+			Expect(recorder.ReadFile("README.md")).To(Equal([]byte("new")))
+
+			Expect(recorder.Commit()).To(Succeed())
+			Expect(memory.ReadFile("README.md")).To(Equal([]byte("new")))
+			Expect(memory.Exists("docs")).To(BeTrue())
+		})
+
+		It("fails on existing files by default and writes nothing", func() {
+			memory = gobootfs.NewMemoryFS(fstest.MapFS{
+				"Makefile":  {Data: []byte("existing")},
+				"README.md": {Data: []byte("existing")},
+			})
+			recorder := gobootfs.NewRecorder(memory, "")
+
+			Expect(recorder.WriteFile(goboottypes.OutputFile{Path: "go.mod", Content: []byte("new")})).To(Succeed())
+			Expect(recorder.WriteFile(goboottypes.OutputFile{Path: "README.md", Content: []byte("new")})).To(Succeed())
+			Expect(recorder.WriteFile(goboottypes.OutputFile{Path: "Makefile", Content: []byte("new")})).To(Succeed())
+
+			err := recorder.Commit()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(`2 files already exist (conflict policy "fail"): Makefile, README.md`))
+
+			Expect(memory.Files()).To(BeEmpty())
+			Expect(memory.ReadFile("Makefile")).To(Equal([]byte("existing")))
+		})
+
+		It("keeps existing files with skip-existing", func() {
+			recorder := gobootfs.NewRecorder(memory, goboottypes.ConflictPolicySkipExisting)
+
+			Expect(recorder.WriteFile(goboottypes.OutputFile{Path: "Makefile", Content: []byte("new")})).To(Succeed())
+			Expect(recorder.WriteFile(goboottypes.OutputFile{Path: "Makefile", Content: []byte("again")})).To(Succeed())
+			Expect(recorder.Commit()).To(Succeed())
+
+			content, err := memory.ReadFile("Makefile")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(Equal("existing"))
+			Expect(recorder.Changes()).To(Equal([]gobootfs.Change{{Path: "Makefile", Action: gobootfs.ActionSkip}}))
+		})
+
+		It("saves existing files before replacing them with backup", func() {
+			recorder := gobootfs.NewRecorder(memory, goboottypes.ConflictPolicyBackup)
+
+			Expect(recorder.WriteFile(goboottypes.OutputFile{Path: "Makefile", Content: []byte("new")})).To(Succeed())
+			Expect(recorder.Commit()).To(Succeed())
+
+			content, err := memory.ReadFile("Makefile")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(Equal("new"))
+
+			backup, err := memory.ReadFile("Makefile.orig")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(backup)).To(Equal("existing"))
+
+			changes := recorder.Changes()
+			Expect(changes).To(Equal([]gobootfs.Change{
+				{Path: "Makefile", Action: gobootfs.ActionBackup, Backup: "Makefile.orig"},
+				{Path: "Makefile.orig", Action: gobootfs.ActionCreate},
+			}))
+			Expect(changes[0].IsConflict()).To(BeTrue())
+		})
+
+		It("keeps earlier backups", func() {
+			memory = gobootfs.NewMemoryFS(fstest.MapFS{
+				"Makefile":      {Data: []byte("existing")},
+				"Makefile.orig": {Data: []byte("first backup")},
+			})
+			recorder := gobootfs.NewRecorder(memory, goboottypes.ConflictPolicyBackup)
+
+			Expect(recorder.WriteFile(goboottypes.OutputFile{Path: "Makefile", Content: []byte("new")})).To(Succeed())
+			Expect(recorder.Commit()).To(Succeed())
+
+			Expect(memory.ReadFile("Makefile.orig")).To(Equal([]byte("first backup")))
+			Expect(memory.ReadFile("Makefile.orig.1")).To(Equal([]byte("existing")))
+			Expect(recorder.Changes()).To(ContainElements(
+				gobootfs.Change{Path: "Makefile", Action: gobootfs.ActionBackup, Backup: "Makefile.orig.1"},
+				gobootfs.Change{Path: "Makefile.orig.1", Action: gobootfs.ActionCreate},
+			))
+		})
+
+		It("does not treat files created during the run as conflicts", func() {
+			recorder := gobootfs.NewRecorder(memory, goboottypes.ConflictPolicyFail)
+
+			Expect(recorder.WriteFile(goboottypes.OutputFile{Path: "README.md"})).To(Succeed())
+			Expect(recorder.WriteFile(goboottypes.OutputFile{Path: "README.md"})).To(Succeed())
+			Expect(recorder.Commit()).To(Succeed())
+
+			changes := recorder.Changes()
+			Expect(changes).To(Equal([]gobootfs.Change{{Path: "README.md", Action: gobootfs.ActionCreate}}))
+			Expect(changes[0].IsConflict()).To(BeFalse())
+		})
+
+		It("records the final content of every generated file", func() {
+			recorder := gobootfs.NewRecorder(memory, goboottypes.ConflictPolicySkipExisting)
+
+			Expect(recorder.WriteFile(goboottypes.OutputFile{
+				Path: "README.md", Content: []byte("first"), Template: "README.md.tmpl",
+			})).To(Succeed())
+			Expect(recorder.WriteFile(goboottypes.OutputFile{
+				Path: "README.md", Content: []byte("goboot"), Template: "README.md.tmpl",
+			})).To(Succeed())
+			Expect(recorder.WriteFile(goboottypes.OutputFile{
+				Path: "Makefile", Content: []byte("new"), Template: "Makefile.tmpl",
+			})).To(Succeed())
+
+			Expect(recorder.Generated()).To(Equal([]gobootfs.GeneratedFile{
+				{
+					Path: "Makefile", SHA256: gobootutils.HashContent([]byte("new")), Template: "Makefile.tmpl",
+					Content: []byte("new"),
+				},
+				{
+					Path: "README.md", SHA256: gobootutils.HashContent([]byte("goboot")), Template: "README.md.tmpl",
+					Content: []byte("goboot"),
+				},
+			}))
+		})
+	})
+
+	Describe("Open", func() {
+		It("returns the injected output", func() {
+			memory := gobootfs.NewMemoryFS(nil)
+
+			out, release, err := gobootfs.Open(memory, tempDir, "project")
+			Expect(err).NotTo(HaveOccurred())
+			defer release()
+
+			Expect(out).To(BeIdenticalTo(memory))
+			Expect(filepath.Join(tempDir, "project")).NotTo(BeADirectory())
+		})
+
+		It("falls back to the project directory on disk", func() {
+			out, release, err := gobootfs.Open(nil, tempDir, "project")
+			Expect(err).NotTo(HaveOccurred())
+			defer release()
+
+			Expect(out.WriteFile(goboottypes.OutputFile{Path: "a.txt", Perm: goboottypes.FilePerm})).To(Succeed())
+			Expect(filepath.Join(tempDir, "project", "a.txt")).To(BeAnExistingFile())
+		})
+	})
+
+	Describe("Templates", func() {
+		It("reads embedded template sets", func() {
+			templates, err := gobootfs.Templates(goboottypes.BuiltinPrefix + goboottypes.TemplateSetLintBase)
+			Expect(err).NotTo(HaveOccurred())
+
+			content, err := fs.ReadFile(templates, ".golangci.yml"+goboottypes.TemplateSuffix)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(content).NotTo(BeEmpty())
+		})
+
+		It("reads template directories on disk", func() {
+			Expect(os.WriteFile(filepath.Join(tempDir, "README.md.tmpl"), []byte("# {{.ProjectName}}\n"), 0o644)).
+				To(Succeed())
+
+			templates, err := gobootfs.Templates(tempDir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fs.ReadFile(templates, "README.md.tmpl")).To(Equal([]byte("# {{.ProjectName}}\n")))
+			Expect(gobootfs.IsBuiltin(tempDir)).To(BeFalse())
+		})
+
+		It("layers overlay sets on top of the set they extend", func() {
+			overlay, err := gobootfs.Templates(goboottypes.BuiltinPrefix + goboottypes.TemplateSetProjectOSS)
+			Expect(err).NotTo(HaveOccurred())
+
+			base, err := gobootfs.Templates(goboottypes.BuiltinPrefix + goboottypes.TemplateSetProjectBase)
+			Expect(err).NotTo(HaveOccurred())
+
+			var overlayFiles, baseFiles []string
+
+			collect := func(fsys fs.FS, files *[]string) {
+				Expect(fs.WalkDir(fsys, ".", func(path string, d fs.DirEntry, err error) error {
+					if err == nil && !d.IsDir() {
+						*files = append(*files, path)
+					}
+
+					return err
+				})).To(Succeed())
+			}
+
+			collect(overlay, &overlayFiles)
+			collect(base, &baseFiles)
+
+			Expect(overlayFiles).To(ContainElements(baseFiles))
+			Expect(overlayFiles).To(ContainElements("CONTRIBUTING.md.tmpl", "CODE_OF_CONDUCT.md.tmpl"))
+			Expect(overlayFiles).To(HaveLen(len(baseFiles) + 2))
+			readme, err := fs.ReadFile(base, "README.md.tmpl")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fs.ReadFile(overlay, "README.md.tmpl")).To(Equal(readme))
+
+			_, err = fs.ReadDir(overlay, "missing")
+			Expect(err).To(MatchError(fs.ErrNotExist))
+		})
+
+		It("names the absolute path of missing template directories", func() {
+			missing, err := filepath.Abs("missing_templates")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = gobootfs.Templates("missing_templates")
+			Expect(err).To(MatchError(fs.ErrNotExist))
+			Expect(err.Error()).To(ContainSubstring(missing))
+
+			file := filepath.Join(tempDir, "file.tmpl")
+			Expect(os.WriteFile(file, nil, 0o644)).To(Succeed())
+
+			_, err = gobootfs.Templates(file)
+			Expect(err).To(MatchError(ContainSubstring(file + " is not a directory")))
+		})
+
+		It("rejects unknown template sets", func() {
+			_, err := gobootfs.Templates("builtin:docs_base")
+			Expect(err).To(MatchError(ContainSubstring(`unknown builtin template set "docs_base"`)))
+		})
+	})
+})
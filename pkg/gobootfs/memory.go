@@ -0,0 +1,142 @@
+package gobootfs
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"path"
+	"path/filepath"
+	"slices"
+
+	"github.com/it-timo/goboot/pkg/goboottypes"
+)
+
+// MemoryFS implements goboottypes.OutputFS entirely in memory.
+//
+// Writes never reach the disk. Reads and existence checks fall through to an optional base filesystem
+// (typically the existing project directory), so a dry run sees the same state a real run would.
+type MemoryFS struct {
+	base  fs.FS                             // Optional read-only view of the existing project; may be nil.
+	files map[string]goboottypes.OutputFile // Written files by clean slash-separated path.
+	dirs  map[string]bool                   // Created directories by clean slash-separated path.
+}
+
+// NewMemoryFS creates an empty MemoryFS on top of the given base filesystem.
+//
+// Pass nil if there is no existing project to read from.
+func NewMemoryFS(base fs.FS) *MemoryFS {
+	return &MemoryFS{
+		base:  base,
+		files: make(map[string]goboottypes.OutputFile),
+		dirs:  make(map[string]bool),
+	}
+}
+
+// MkdirAll records the given directory and all its parents.
+func (m *MemoryFS) MkdirAll(relPath string) error {
+	clean, err := cleanPath(relPath)
+	if err != nil {
+		return err
+	}
+
+	for dir := clean; dir != "."; dir = path.Dir(dir) {
+		m.dirs[dir] = true
+	}
+
+	return nil
+}
+
+// WriteFile stores the file in memory, replacing any earlier write to the same path.
+func (m *MemoryFS) WriteFile(file goboottypes.OutputFile) error {
+	clean, err := cleanPath(file.Path)
+	if err != nil {
+		return err
+	}
+
+	err = m.MkdirAll(path.Dir(clean))
+	if err != nil {
+		return err
+	}
+
+	file.Path = clean
+	file.Content = slices.Clone(file.Content)
+	m.files[clean] = file
+
+	return nil
+}
+
+// ReadFile returns the content written in memory or, if not written, the content of the base filesystem.
+func (m *MemoryFS) ReadFile(relPath string) ([]byte, error) {
+	clean, err := cleanPath(relPath)
+	if err != nil {
+		return nil, err
+	}
+
+	file, ok := m.files[clean]
+	if ok {
+		return slices.Clone(file.Content), nil
+	}
+
+	if m.base == nil {
+		return nil, fmt.Errorf("failed to read file %q: %w", relPath, fs.ErrNotExist)
+	}
+
+	content, err := fs.ReadFile(m.base, clean)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %q: %w", relPath, err)
+	}
+
+	return content, nil
+}
+
+// Exists reports whether the path was written in memory or exists in the base filesystem.
+func (m *MemoryFS) Exists(relPath string) (bool, error) {
+	clean, err := cleanPath(relPath)
+	if err != nil {
+		return false, err
+	}
+
+	_, isFile := m.files[clean]
+	if isFile || m.dirs[clean] || clean == "." {
+		return true, nil
+	}
+
+	if m.base == nil {
+		return false, nil
+	}
+
+	_, err = fs.Stat(m.base, clean)
+	if err == nil {
+		return true, nil
+	}
+
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+
+	return false, fmt.Errorf("failed to stat %q: %w", relPath, err)
+}
+
+// Files returns all files written to memory, sorted by path.
+func (m *MemoryFS) Files() []goboottypes.OutputFile {
+	files := make([]goboottypes.OutputFile, 0, len(m.files))
+
+	for _, name := range slices.Sorted(maps.Keys(m.files)) {
+		files = append(files, m.files[name])
+	}
+
+	return files
+}
+
+// cleanPath converts a relative OS path into a clean slash-separated path as used by io/fs.
+//
+// Returns an error for absolute paths and paths escaping the root.
+func cleanPath(relPath string) (string, error) {
+	clean := path.Clean(filepath.ToSlash(relPath))
+	if !fs.ValidPath(clean) {
+		return "", fmt.Errorf("invalid path %q: path escapes root", relPath)
+	}
+
+	return clean, nil
+}
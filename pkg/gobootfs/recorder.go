@@ -0,0 +1,315 @@
+package gobootfs
+
+import (
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/it-timo/goboot/pkg/goboottypes"
+	"github.com/it-timo/goboot/pkg/gobootutils"
+)
+
+// Action describes what writing a file did to the project.
+type Action string
+
+const (
+	// ActionCreate marks a file that did not exist before the run.
+	ActionCreate Action = "create"
+
+	// ActionOverwrite marks an existing file that was replaced by the run.
+	ActionOverwrite Action = "overwrite"
+
+	// ActionSkip marks an existing file that was kept untouched (conflict policy "skip-existing").
+	ActionSkip Action = "skip"
+
+	// ActionBackup marks an existing file that was saved with goboottypes.BackupSuffix
+	// and then replaced (conflict policy "backup").
+	ActionBackup Action = "backup"
+)
+
+// Change describes a single file written during a run.
+type Change struct {
+	Path   string // Slash-separated path relative to the project root.
+	Action Action // What the write did to the file.
+	Backup string // Path of the backup copy; only set for ActionBackup.
+}
+
+// IsConflict reports whether the change touched a file that existed before the run.
+func (c Change) IsConflict() bool {
+	return c.Action != ActionCreate
+}
+
+// GeneratedFile describes the final render of a single file during a run.
+type GeneratedFile struct {
+	Path     string // Slash-separated path relative to the project root.
+	SHA256   string // Hex-encoded SHA-256 checksum of the rendered content.
+	Template string // Path of the template the file was rendered from.
+	Content  []byte // Rendered content.
+}
+
+// Recorder wraps a goboottypes.OutputFS, applies the conflict policy,
+// and records every file written through it.
+//
+// Writes are staged in memory until Commit, so the conflicts of the whole run are known before anything
+// is written: a failing service or the "fail" policy leave the output untouched.
+//
+// A conflict is a file that existed before the run. Each path is resolved once:
+// a file created by one service and rewritten by another is still a create,
+// and a skipped file stays skipped for the whole run.
+type Recorder struct {
+	out       goboottypes.OutputFS
+	policy    string
+	dirs      []string                          // Staged directories in creation order.
+	staged    map[string]goboottypes.OutputFile // Staged files by clean slash-separated path.
+	conflicts []string                          // Existing files the "fail" policy refuses to replace.
+	changes   map[string]Change
+	generated map[string]GeneratedFile
+}
+
+// NewRecorder returns a Recorder writing into the given output using the given conflict policy
+// (see goboottypes.ConflictPolicies). An empty policy falls back to goboottypes.DefaultConflictPolicy.
+func NewRecorder(out goboottypes.OutputFS, policy string) *Recorder {
+	if policy == "" {
+		policy = goboottypes.DefaultConflictPolicy
+	}
+
+	return &Recorder{
+		out:       out,
+		policy:    policy,
+		staged:    make(map[string]goboottypes.OutputFile),
+		changes:   make(map[string]Change),
+		generated: make(map[string]GeneratedFile),
+	}
+}
+
+// MkdirAll stages the directory; it is created by Commit.
+func (r *Recorder) MkdirAll(relPath string) error {
+	r.dirs = append(r.dirs, relPath)
+
+	return nil
+}
+
+// WriteFile resolves conflicts with existing files according to the policy,
+// stages the write until Commit, and records the change.
+func (r *Recorder) WriteFile(file goboottypes.OutputFile) error {
+	key := filepath.ToSlash(filepath.Clean(file.Path))
+
+	change, seen := r.changes[key]
+	if !seen {
+		var err error
+
+		change, err = r.resolveConflict(file, key)
+		if err != nil {
+			return err
+		}
+
+		r.changes[key] = change
+	}
+
+	if change.Action != ActionSkip {
+		file.Content = slices.Clone(file.Content)
+		r.staged[key] = file
+	}
+
+	r.recordGenerated(key, file)
+
+	return nil
+}
+
+// Commit applies the staged writes to the wrapped output.
+//
+// With the "fail" policy, it returns an error listing every existing file and writes nothing.
+// With the backup policy, each existing file is copied to a free backup path first (see backupPath),
+// which is recorded as a created file.
+func (r *Recorder) Commit() error {
+	if len(r.conflicts) > 0 {
+		slices.Sort(r.conflicts)
+
+		return fmt.Errorf("%d files already exist (conflict policy %q): %s; use %q, %q, or %q to continue",
+			len(r.conflicts), r.policy, strings.Join(r.conflicts, ", "),
+			goboottypes.ConflictPolicySkipExisting, goboottypes.ConflictPolicyOverwrite, goboottypes.ConflictPolicyBackup)
+	}
+
+	for _, dir := range r.dirs {
+		err := r.out.MkdirAll(dir)
+		if err != nil {
+			return fmt.Errorf("failed to create directory: %w", err)
+		}
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(r.staged)) {
+		file := r.staged[key]
+
+		change := r.changes[key]
+		if change.Action == ActionBackup && change.Backup == "" {
+			err := r.backup(file, key)
+			if err != nil {
+				return err
+			}
+		}
+
+		err := r.write(file)
+		if err != nil {
+			return err
+		}
+	}
+
+	r.dirs = nil
+	clear(r.staged)
+
+	return nil
+}
+
+// recordGenerated stores the checksum and template of the latest render of a file.
+//
+// Skipped files are recorded as well: the record describes what goboot rendered, not what is on disk.
+func (r *Recorder) recordGenerated(key string, file goboottypes.OutputFile) {
+	r.generated[key] = GeneratedFile{
+		Path:     key,
+		SHA256:   gobootutils.HashContent(file.Content),
+		Template: file.Template,
+		Content:  slices.Clone(file.Content),
+	}
+}
+
+// resolveConflict applies the conflict policy to the file and returns the resulting change.
+//
+// Existing files are collected for the "fail" policy, which refuses them all at once in Commit.
+func (r *Recorder) resolveConflict(file goboottypes.OutputFile, key string) (Change, error) {
+	exists, err := r.out.Exists(file.Path)
+	if err != nil {
+		return Change{}, fmt.Errorf("failed to check existing file: %w", err)
+	}
+
+	if !exists {
+		return Change{Path: key, Action: ActionCreate}, nil
+	}
+
+	switch r.policy {
+	case goboottypes.ConflictPolicySkipExisting:
+		return Change{Path: key, Action: ActionSkip}, nil
+	case goboottypes.ConflictPolicyOverwrite:
+		return Change{Path: key, Action: ActionOverwrite}, nil
+	case goboottypes.ConflictPolicyBackup:
+		return Change{Path: key, Action: ActionBackup}, nil
+	default:
+		r.conflicts = append(r.conflicts, key)
+
+		return Change{Path: key, Action: ActionOverwrite}, nil
+	}
+}
+
+// backup copies the existing content of the file to a free backup path and records both changes.
+func (r *Recorder) backup(file goboottypes.OutputFile, key string) error {
+	existing, err := r.out.ReadFile(file.Path)
+	if err != nil {
+		return fmt.Errorf("failed to read %q for backup: %w", key, err)
+	}
+
+	backup, err := r.backupPath(key)
+	if err != nil {
+		return err
+	}
+
+	err = r.write(goboottypes.OutputFile{Path: filepath.FromSlash(backup), Content: existing, Perm: goboottypes.FilePerm})
+	if err != nil {
+		return fmt.Errorf("failed to back up %q: %w", key, err)
+	}
+
+	r.changes[backup] = Change{Path: backup, Action: ActionCreate}
+	r.changes[key] = Change{Path: key, Action: ActionBackup, Backup: backup}
+
+	return nil
+}
+
+// backupPath returns the first backup path of the file that neither exists nor is written by the run:
+// "<file>.orig", then "<file>.orig.1", "<file>.orig.2", and so on. Earlier backups are never replaced.
+func (r *Recorder) backupPath(key string) (string, error) {
+	for i := 0; ; i++ {
+		candidate := key + goboottypes.BackupSuffix
+		if i > 0 {
+			candidate += "." + strconv.Itoa(i)
+		}
+
+		if _, planned := r.changes[candidate]; planned {
+			continue
+		}
+
+		exists, err := r.out.Exists(filepath.FromSlash(candidate))
+		if err != nil {
+			return "", fmt.Errorf("failed to check backup file: %w", err)
+		}
+
+		if !exists {
+			return candidate, nil
+		}
+	}
+}
+
+// ReadFile returns the staged content of the file or, if not staged, the content of the wrapped output.
+func (r *Recorder) ReadFile(relPath string) ([]byte, error) {
+	file, ok := r.staged[filepath.ToSlash(filepath.Clean(relPath))]
+	if ok {
+		return slices.Clone(file.Content), nil
+	}
+
+	content, err := r.out.ReadFile(relPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read output: %w", err)
+	}
+
+	return content, nil
+}
+
+// Exists reports whether the file is staged or exists in the wrapped output.
+func (r *Recorder) Exists(relPath string) (bool, error) {
+	_, ok := r.staged[filepath.ToSlash(filepath.Clean(relPath))]
+	if ok {
+		return true, nil
+	}
+
+	exists, err := r.out.Exists(relPath)
+	if err != nil {
+		return false, fmt.Errorf("failed to check output: %w", err)
+	}
+
+	return exists, nil
+}
+
+// Changes returns the recorded changes sorted by path; before Commit, these are the planned changes.
+func (r *Recorder) Changes() []Change {
+	changes := make([]Change, 0, len(r.changes))
+	for _, change := range r.changes {
+		changes = append(changes, change)
+	}
+
+	slices.SortFunc(changes, func(a, b Change) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+
+	return changes
+}
+
+// Generated returns the final render of every file written during the run, sorted by path.
+func (r *Recorder) Generated() []GeneratedFile {
+	files := make([]GeneratedFile, 0, len(r.generated))
+
+	for _, name := range slices.Sorted(maps.Keys(r.generated)) {
+		files = append(files, r.generated[name])
+	}
+
+	return files
+}
+
+// write delegates the write to the wrapped output.
+func (r *Recorder) write(file goboottypes.OutputFile) error {
+	err := r.out.WriteFile(file)
+	if err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+
+	return nil
+}
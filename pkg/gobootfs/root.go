@@ -0,0 +1,98 @@
+package gobootfs
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/it-timo/goboot/pkg/goboottypes"
+	"github.com/it-timo/goboot/pkg/gobootutils"
+)
+
+// RootFS implements goboottypes.OutputFS on disk.
+//
+// All operations are confined to the project directory via *os.Root.
+type RootFS struct {
+	root *os.Root
+}
+
+// OpenRootFS creates the project directory (targetDir/name) if needed and opens it as a RootFS.
+//
+// The caller must Close the returned RootFS.
+func OpenRootFS(targetDir, name string) (*RootFS, error) {
+	curRoot, err := gobootutils.CreateRootDir(targetDir, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create root dir: %w", err)
+	}
+
+	return &RootFS{root: curRoot}, nil
+}
+
+// Close releases the underlying root handle.
+func (r *RootFS) Close() error {
+	err := r.root.Close()
+	if err != nil {
+		return fmt.Errorf("failed to close root: %w", err)
+	}
+
+	return nil
+}
+
+// MkdirAll ensures the given relative directory path exists inside the root.
+func (r *RootFS) MkdirAll(relPath string) error {
+	err := gobootutils.EnsureDir(relPath, r.root, goboottypes.DirPerm)
+	if err != nil {
+		return fmt.Errorf("failed to ensure directory %q: %w", relPath, err)
+	}
+
+	return nil
+}
+
+// WriteFile creates or truncates the file inside the root, writes the content and applies the file permission.
+//
+// Missing parent directories are created.
+func (r *RootFS) WriteFile(file goboottypes.OutputFile) error {
+	err := r.MkdirAll(filepath.Dir(file.Path))
+	if err != nil {
+		return fmt.Errorf("failed to ensure destination directory: %w", err)
+	}
+
+	err = r.root.WriteFile(file.Path, file.Content, file.Perm)
+	if err != nil {
+		return fmt.Errorf("failed to write file %q: %w", file.Path, err)
+	}
+
+	// WriteFile keeps the permission of existing files and applies the umask, so set it explicitly.
+	err = r.root.Chmod(file.Path, file.Perm)
+	if err != nil {
+		return fmt.Errorf("failed to set permissions on %q: %w", file.Path, err)
+	}
+
+	return nil
+}
+
+// ReadFile returns the content of the given file inside the root.
+func (r *RootFS) ReadFile(relPath string) ([]byte, error) {
+	content, err := r.root.ReadFile(relPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %q: %w", relPath, err)
+	}
+
+	return content, nil
+}
+
+// Exists reports whether a file or directory exists at the given path inside the root.
+func (r *RootFS) Exists(relPath string) (bool, error) {
+	_, err := r.root.Stat(relPath)
+	if err == nil {
+		return true, nil
+	}
+
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+
+	return false, fmt.Errorf("failed to stat %q: %w", relPath, err)
+}
@@ -0,0 +1,121 @@
+package gobootfs
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/it-timo/goboot/pkg/goboottypes"
+	"github.com/it-timo/goboot/templates"
+)
+
+// Templates returns the filesystem the templates of a service are read from.
+//
+// A sourcePath starting with goboottypes.BuiltinPrefix selects a template set embedded into the binary
+// (e.g., "builtin:project_base"); any other sourcePath is a directory on disk, which must exist.
+// Errors about a missing directory name its absolute path, as relative ones resolve against the working directory.
+// Overlay sets are layered on top of the set they extend (see goboottypes.TemplateSetExtends).
+func Templates(sourcePath string) (fs.FS, error) {
+	name, builtin := strings.CutPrefix(sourcePath, goboottypes.BuiltinPrefix)
+	if !builtin {
+		return templateDir(sourcePath)
+	}
+
+	if !slices.Contains(goboottypes.TemplateSets(), name) {
+		return nil, fmt.Errorf("unknown builtin template set %q (must be one of: %s)",
+			name, strings.Join(goboottypes.TemplateSets(), ", "))
+	}
+
+	sub, err := fs.Sub(templates.FS, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open builtin template set %q: %w", name, err)
+	}
+
+	extends := goboottypes.TemplateSetExtends(name)
+	if extends == "" {
+		return sub, nil
+	}
+
+	base, err := Templates(goboottypes.BuiltinPrefix + extends)
+	if err != nil {
+		return nil, err
+	}
+
+	return overlayFS{top: sub, base: base}, nil
+}
+
+// templateDir returns the filesystem of the template directory at sourcePath.
+func templateDir(sourcePath string) (fs.FS, error) {
+	dir, err := filepath.Abs(sourcePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve template directory %q: %w", sourcePath, err)
+	}
+
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open template directory: %w", err)
+	}
+
+	if !info.IsDir() {
+		return nil, fmt.Errorf("failed to open template directory: %s is not a directory", dir)
+	}
+
+	return os.DirFS(sourcePath), nil
+}
+
+// overlayFS is a read-only union of two filesystems, where the files of top replace those of base.
+type overlayFS struct {
+	top  fs.FS
+	base fs.FS
+}
+
+// Open opens the named file of top, or of base if top does not have it.
+func (o overlayFS) Open(name string) (fs.File, error) {
+	file, err := o.top.Open(name)
+	if err == nil || !errors.Is(err, fs.ErrNotExist) {
+		return file, err //nolint:wrapcheck // fs.FS errors are passed through unchanged.
+	}
+
+	return o.base.Open(name) //nolint:wrapcheck // fs.FS errors are passed through unchanged.
+}
+
+// ReadDir returns the entries of the named directory in both filesystems, sorted by name.
+func (o overlayFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	entries := make(map[string]fs.DirEntry)
+	found := false
+
+	for _, fsys := range []fs.FS{o.base, o.top} {
+		dirEntries, err := fs.ReadDir(fsys, name)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+
+		if err != nil {
+			return nil, err //nolint:wrapcheck // fs.FS errors are passed through unchanged.
+		}
+
+		found = true
+
+		for _, entry := range dirEntries {
+			entries[entry.Name()] = entry
+		}
+	}
+
+	if !found {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrNotExist}
+	}
+
+	return slices.SortedFunc(maps.Values(entries), func(a, b fs.DirEntry) int {
+		return strings.Compare(a.Name(), b.Name())
+	}), nil
+}
+
+// IsBuiltin reports whether sourcePath selects an embedded template set.
+func IsBuiltin(sourcePath string) bool {
+	return strings.HasPrefix(sourcePath, goboottypes.BuiltinPrefix)
+}
@@ -0,0 +1,214 @@
+/*
+Package gobootplugin runs external executables as goboot services ("plugins").
+
+A plugin is declared in goboot.yml with a service ID and the path to its executable:
+
+	services:
+	  - id: acme_license
+	    plugin: ./bin/goboot-acme
+	    enabled: true
+	    config:
+	      holder: ACME Corp.
+
+goboot runs the executable once per generation, writes a Request as JSON to its stdin,
+and reads a Response as JSON from its stdout (see doc/plugins.md):
+  - The files of the response are written through the same output as the built-in services,
+    so they are confined to the project by os.Root and follow the conflict policy.
+  - The scripts of the response are registered with the script registrar (typically base_local).
+
+Plugins never write into the project themselves; a non-zero exit status fails the run with the stderr of the plugin.
+*/
+package gobootplugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/it-timo/goboot/pkg/config"
+	"github.com/it-timo/goboot/pkg/gobootfs"
+	"github.com/it-timo/goboot/pkg/goboottypes"
+)
+
+// Plugin implements the Service interface for a service implemented by an external executable.
+//
+// It holds a reference to the resolved config.PluginConfig and tracks the
+// target directory, output, and script registrar for applying the response of the plugin.
+type Plugin struct {
+	id        string
+	cfg       *config.PluginConfig
+	targetDir string
+	output    goboottypes.OutputFS  // Output injected by the orchestrator; may be nil.
+	script    goboottypes.Registrar // Script registrar injected by the orchestrator; may be nil.
+	log       goboottypes.Logger    // Logger injected by the orchestrator; logs to stdout by default.
+}
+
+// NewPlugin returns a new Plugin for the given service ID and target directory.
+func NewPlugin(id, targetDir string) *Plugin {
+	return &Plugin{
+		id:        id,
+		targetDir: targetDir,
+		log:       goboottypes.NewStdoutLogger(),
+	}
+}
+
+// ID returns the service ID the plugin is declared for in goboot.yml.
+func (p *Plugin) ID() string {
+	return p.id
+}
+
+// SetOutput sets the output the files of the plugin are written into.
+//
+// Without an output, Run writes into targetDir/ProjectName on disk.
+func (p *Plugin) SetOutput(out goboottypes.OutputFS) {
+	p.output = out
+}
+
+// SetScriptReceiver sets the registrar the scripts of the plugin are registered with.
+//
+// Without a registrar, the scripts of the plugin are dropped.
+func (p *Plugin) SetScriptReceiver(registrar goboottypes.Registrar) {
+	p.script = registrar
+}
+
+// SetLogger sets the logger the stderr of a successful plugin run is logged to.
+func (p *Plugin) SetLogger(logger goboottypes.Logger) {
+	p.log = logger
+}
+
+// SetConfig assigns the plugin configuration.
+//
+// It performs a type assertion to ensure the correct config type was passed.
+//
+// This assumes config has been validated during initialization.
+func (p *Plugin) SetConfig(cfg config.ServiceConfig) error {
+	pluginCfg, ok := cfg.(*config.PluginConfig)
+	if !ok {
+		return fmt.Errorf("invalid config type for plugin %s", p.id)
+	}
+
+	p.cfg = pluginCfg
+
+	return nil
+}
+
+// Command returns the plugin executable, which is run without arguments.
+func (p *Plugin) Command() []string {
+	return []string{p.cfg.Plugin}
+}
+
+// Run executes the plugin and applies its response: the files are written into the output,
+// and the scripts are registered with the script registrar.
+//
+// This assumes config has been validated during initialization.
+func (p *Plugin) Run() error {
+	resp, err := p.execute()
+	if err != nil {
+		return err
+	}
+
+	out, release, err := gobootfs.Open(p.output, p.targetDir, p.cfg.ProjectName)
+	if err != nil {
+		return fmt.Errorf("failed to create root dir: %w", err)
+	}
+	defer release()
+
+	err = p.writeFiles(out, resp.Files)
+	if err != nil {
+		return fmt.Errorf("failed to write files: %w", err)
+	}
+
+	if p.script != nil {
+		err = p.registerScripts(resp)
+		if err != nil {
+			return fmt.Errorf("failed to register scripts: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// execute runs the plugin executable with the request on stdin and decodes the response from its stdout.
+//
+// The stderr of the plugin is logged after a successful run, and added to the error otherwise.
+func (p *Plugin) execute() (*Response, error) {
+	req, err := json.Marshal(Request{
+		ProtocolVersion: ProtocolVersion,
+		Service:         p.id,
+		ProjectName:     p.cfg.ProjectName,
+		RepoURL:         p.cfg.RepoURL,
+		Config:          p.cfg.Values,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode plugin request: %w", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+
+	// #nosec G204 -- the plugin is declared in goboot.yml and meant to be executed.
+	cmd := exec.CommandContext(context.Background(), p.cfg.Plugin)
+	cmd.Stdin = bytes.NewReader(req)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err = cmd.Run()
+	msg := strings.TrimSpace(stderr.String())
+
+	switch {
+	case err != nil && msg != "":
+		return nil, fmt.Errorf("plugin %s failed: %w: %s", p.cfg.Plugin, err, msg)
+	case err != nil:
+		return nil, fmt.Errorf("plugin %s failed: %w", p.cfg.Plugin, err)
+	case msg != "":
+		p.log.Printf("plugin %s: %s\n", p.id, msg)
+	}
+
+	resp, err := decodeResponse(stdout.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("plugin %s: %w", p.cfg.Plugin, err)
+	}
+
+	return resp, nil
+}
+
+// writeFiles writes the files of the plugin response into the output.
+func (p *Plugin) writeFiles(out goboottypes.OutputFS, files []File) error {
+	for _, file := range files {
+		perm := os.FileMode(goboottypes.FilePerm)
+		if file.Executable {
+			perm = goboottypes.ScriptPerm
+		}
+
+		err := out.WriteFile(goboottypes.OutputFile{
+			Path:     filepath.FromSlash(file.Path),
+			Content:  []byte(file.Content),
+			Perm:     perm,
+			Template: p.cfg.Plugin,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to write %q: %w", file.Path, err)
+		}
+	}
+
+	return nil
+}
+
+// registerScripts registers the scripts of the plugin response with the script registrar.
+func (p *Plugin) registerScripts(resp *Response) error {
+	var errs []error
+
+	for _, script := range resp.Scripts {
+		err := p.script.RegisterLines(script.Name, script.Lines)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to register script commands %q: %w", script.Name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
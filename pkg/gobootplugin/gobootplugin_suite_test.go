@@ -0,0 +1,29 @@
+package gobootplugin_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+)
+
+// fakePlugin is the path of the fake plugin binary built from testdata/fakeplugin.
+var fakePlugin string
+
+func TestGobootPlugin(t *testing.T) {
+	t.Parallel()
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "GobootPlugin Suite")
+}
+
+var _ = BeforeSuite(func() {
+	var err error
+
+	fakePlugin, err = gexec.Build("github.com/it-timo/goboot/pkg/gobootplugin/testdata/fakeplugin")
+	Expect(err).NotTo(HaveOccurred())
+})
+
+var _ = AfterSuite(func() {
+	gexec.CleanupBuildArtifacts()
+})
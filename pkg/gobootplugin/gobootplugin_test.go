@@ -0,0 +1,159 @@
+package gobootplugin_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/it-timo/goboot/pkg/config"
+	"github.com/it-timo/goboot/pkg/gobootfs"
+	"github.com/it-timo/goboot/pkg/gobootplugin"
+	"github.com/it-timo/goboot/pkg/goboottypes"
+)
+
+// registration is a script registration received by fakeRegistrar.
+type registration struct {
+	name  string
+	lines []string
+}
+
+// fakeRegistrar records the script registrations of a plugin.
+type fakeRegistrar struct {
+	registrations []registration
+}
+
+func (r *fakeRegistrar) RegisterLines(name string, lines []string) error {
+	r.registrations = append(r.registrations, registration{name: name, lines: lines})
+
+	return nil
+}
+
+func (r *fakeRegistrar) RegisterFile(_ string, _ []string) error {
+	Fail("plugins must not register script files")
+
+	return nil
+}
+
+var _ = Describe("Plugin", func() {
+	var (
+		tempDir   string
+		registrar *fakeRegistrar
+		memory    *gobootfs.MemoryFS
+	)
+
+	BeforeEach(func() {
+		tempDir = GinkgoT().TempDir()
+		registrar = &fakeRegistrar{}
+		memory = gobootfs.NewMemoryFS(nil)
+	})
+
+	// newPlugin loads the plugin config from a goboot.yml declaring the fake plugin with the given inline config,
+	// and returns the plugin service with the config set.
+	newPlugin := func(inline string) *gobootplugin.Plugin {
+		path := filepath.Join(tempDir, "goboot.yml")
+		Expect(os.WriteFile(path, []byte(`projectName: mytool
+repoUrl: github.com/acme/mytool
+targetPath: `+tempDir+`
+services:
+  - id: acme_license
+    plugin: `+fakePlugin+`
+    enabled: true
+    config:
+`+inline), 0o644)).To(Succeed())
+
+		gb := config.NewGoBoot(nil, path)
+		Expect(gb.Init()).To(Succeed())
+
+		cfg, ok := gb.ConfManager.Get("acme_license")
+		Expect(ok).To(BeTrue())
+
+		plugin := gobootplugin.NewPlugin("acme_license", tempDir)
+		Expect(plugin.SetConfig(cfg)).To(Succeed())
+
+		return plugin
+	}
+
+	It("sends the request and applies the files and scripts of the response", func() {
+		GinkgoT().Setenv("ACME_HOLDER", "ACME Corp.")
+
+		plugin := newPlugin("      interpolate: true\n      holder: ${ACME_HOLDER}\n")
+		plugin.SetOutput(memory)
+		plugin.SetScriptReceiver(registrar)
+
+		Expect(plugin.ID()).To(Equal("acme_license"))
+		Expect(plugin.Run()).To(Succeed())
+
+		header, err := memory.ReadFile("LICENSE_HEADER.txt")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(header)).To(Equal("Copyright ACME Corp.. Part of mytool.\n"))
+
+		Expect(memory.Files()).To(ContainElement(And(
+			HaveField("Path", filepath.Join("tools", "acme", "check.sh")),
+			HaveField("Perm", os.FileMode(goboottypes.ScriptPerm)),
+			HaveField("Template", fakePlugin),
+		)))
+
+		data, err := memory.ReadFile(filepath.Join("acme", "request.json"))
+		Expect(err).NotTo(HaveOccurred())
+
+		var req gobootplugin.Request
+		Expect(json.Unmarshal(data, &req)).To(Succeed())
+		Expect(req).To(Equal(gobootplugin.Request{
+			ProtocolVersion: gobootplugin.ProtocolVersion,
+			Service:         "acme_license",
+			ProjectName:     "mytool",
+			RepoURL:         "github.com/acme/mytool",
+			Config:          map[string]any{"holder": "ACME Corp."},
+		}))
+
+		Expect(registrar.registrations).To(Equal([]registration{
+			{name: "acme_license", lines: []string{"acme-check ./..."}},
+		}))
+	})
+
+	It("writes into the project directory on disk through the conflict policy", func() {
+		plugin := newPlugin("      holder: ACME\n")
+
+		projectDir := filepath.Join(tempDir, "mytool")
+		Expect(os.MkdirAll(projectDir, 0o755)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(projectDir, "LICENSE_HEADER.txt"), []byte("custom\n"), 0o644)).To(Succeed())
+
+		root, err := gobootfs.OpenRootFS(tempDir, "mytool")
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(root.Close)
+
+		recorder := gobootfs.NewRecorder(root, goboottypes.ConflictPolicyFail)
+		plugin.SetOutput(recorder)
+		Expect(plugin.Run()).To(Succeed())
+		Expect(recorder.Commit()).To(MatchError(ContainSubstring("LICENSE_HEADER.txt")))
+
+		plugin.SetOutput(nil)
+		Expect(plugin.Run()).To(Succeed())
+		Expect(filepath.Join(projectDir, "acme", "request.json")).To(BeAnExistingFile())
+	})
+
+	It("fails with the stderr of the plugin", func() {
+		plugin := newPlugin("      mode: fail\n")
+		plugin.SetOutput(memory)
+
+		err := plugin.Run()
+		Expect(err).To(MatchError(ContainSubstring("exit status 3: fakeplugin: holder is required")))
+		Expect(memory.Files()).To(BeEmpty())
+	})
+
+	It("rejects invalid responses", func() {
+		plugin := newPlugin("      mode: garbage\n")
+		plugin.SetOutput(memory)
+		Expect(plugin.Run()).To(MatchError(ContainSubstring("invalid response")))
+
+		plugin = newPlugin("      mode: escape\n")
+		plugin.SetOutput(memory)
+		Expect(plugin.Run()).To(MatchError(ContainSubstring(
+			`file path "../outside.txt" must be a relative path inside the project`)))
+		Expect(memory.Files()).To(BeEmpty())
+		Expect(filepath.Join(tempDir, "outside.txt")).NotTo(BeAnExistingFile())
+	})
+})
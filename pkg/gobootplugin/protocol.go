@@ -0,0 +1,123 @@
+package gobootplugin
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/it-timo/goboot/pkg/goboottypes"
+)
+
+// ProtocolVersion is the version of the plugin protocol, sent in every Request.
+//
+// It is incremented on incompatible changes, so plugins can reject requests they do not understand.
+const ProtocolVersion = 1
+
+// Request is the JSON document goboot writes to the stdin of a plugin.
+type Request struct {
+	// ProtocolVersion is the version of the protocol (see ProtocolVersion).
+	ProtocolVersion int `json:"protocolVersion"`
+
+	// Service is the service ID the plugin is declared for in goboot.yml (e.g., "acme_license").
+	Service string `json:"service"`
+
+	// ProjectName is the project name from goboot.yml.
+	ProjectName string `json:"projectName"`
+
+	// RepoURL is the repository URL from goboot.yml; may be empty.
+	RepoURL string `json:"repoUrl"`
+
+	// Config is the config of the service from goboot.yml (inline or confPath), after interpolation.
+	Config map[string]any `json:"config"`
+}
+
+// Response is the JSON document a plugin writes to its stdout before exiting with status 0.
+//
+// Unknown fields are rejected, so typos in a plugin fail loudly instead of dropping output.
+type Response struct {
+	// Files are the files to write into the project, in order.
+	Files []File `json:"files"`
+
+	// Scripts are the script lines to render into the shared script targets (e.g., Makefile, Taskfile),
+	// see goboottypes.Registrar.RegisterLines. Standalone scripts are written as executable Files instead.
+	Scripts []Script `json:"scripts"`
+}
+
+// File is a file a plugin writes into the project.
+type File struct {
+	// Path is the slash-separated path relative to the project root (e.g., "internal/acme/sdk.go").
+	Path string `json:"path"`
+
+	// Content is the content of the file.
+	Content string `json:"content"`
+
+	// Executable makes the file executable (goboottypes.ScriptPerm instead of goboottypes.FilePerm).
+	Executable bool `json:"executable"`
+}
+
+// Script is a named group of script lines a plugin registers with the script registrar (typically base_local).
+type Script struct {
+	// Name is the name of the script group or file (e.g., "acme_license").
+	Name string `json:"name"`
+
+	// Lines are the script lines.
+	Lines []string `json:"lines"`
+}
+
+// decodeResponse decodes the stdout of a plugin, which must be exactly one Response document.
+func decodeResponse(data []byte) (*Response, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+
+	resp := &Response{}
+
+	err := dec.Decode(resp)
+	if err != nil {
+		return nil, fmt.Errorf("invalid response: %w", err)
+	}
+
+	if dec.More() {
+		return nil, errors.New("invalid response: more than one JSON document")
+	}
+
+	err = resp.validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid response: %w", err)
+	}
+
+	return resp, nil
+}
+
+// validate checks that all files stay inside the project and that files and scripts are named.
+//
+// The paths are checked here for clearer errors; the output rejects paths escaping the project anyway.
+func (r *Response) validate() error {
+	seen := make(map[string]bool, len(r.Files))
+
+	for _, file := range r.Files {
+		localPath := filepath.FromSlash(file.Path)
+
+		switch {
+		case !filepath.IsLocal(localPath) || strings.Contains(file.Path, `\`):
+			return fmt.Errorf("file path %q must be a relative path inside the project", file.Path)
+		case filepath.Clean(localPath) == goboottypes.LockFileName,
+			filepath.Clean(localPath) == goboottypes.BaseFileName:
+			return fmt.Errorf("file path %q is reserved for goboot", file.Path)
+		case seen[filepath.Clean(localPath)]:
+			return fmt.Errorf("file path %q is written twice", file.Path)
+		}
+
+		seen[filepath.Clean(localPath)] = true
+	}
+
+	for _, script := range r.Scripts {
+		if strings.TrimSpace(script.Name) == "" {
+			return errors.New("scripts must have a name")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+// Command fakeplugin is a goboot plugin used by the tests.
+//
+// It answers with files and scripts derived from its request, including the request itself (acme/request.json).
+// The config key "mode" selects a misbehavior: "fail", "garbage", or "escape".
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/it-timo/goboot/pkg/gobootplugin"
+)
+
+func main() {
+	var req gobootplugin.Request
+
+	err := json.NewDecoder(os.Stdin).Decode(&req)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "fakeplugin: invalid request:", err)
+		os.Exit(2)
+	}
+
+	mode, _ := req.Config["mode"].(string)
+
+	switch mode {
+	case "fail":
+		fmt.Fprintln(os.Stderr, "fakeplugin: holder is required")
+		os.Exit(3)
+	case "garbage":
+		fmt.Print("not json")
+	case "escape":
+		respond(gobootplugin.Response{Files: []gobootplugin.File{{Path: "../outside.txt", Content: "escaped\n"}}})
+	default:
+		respond(licenseResponse(req))
+	}
+}
+
+// licenseResponse returns the files and scripts of a license header plugin.
+func licenseResponse(req gobootplugin.Request) gobootplugin.Response {
+	holder, _ := req.Config["holder"].(string)
+
+	request, err := json.Marshal(req)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "fakeplugin:", err)
+		os.Exit(2)
+	}
+
+	fmt.Fprintln(os.Stderr, "fakeplugin: rendered license header")
+
+	return gobootplugin.Response{
+		Files: []gobootplugin.File{
+			{Path: "LICENSE_HEADER.txt", Content: fmt.Sprintf("Copyright %s. Part of %s.\n", holder, req.ProjectName)},
+			{Path: "tools/acme/check.sh", Content: "#!/bin/sh\nacme-check ./...\n", Executable: true},
+			{Path: "acme/request.json", Content: string(request)},
+		},
+		Scripts: []gobootplugin.Script{{Name: req.Service, Lines: []string{"acme-check ./..."}}},
+	}
+}
+
+// respond writes the response to stdout.
+func respond(resp gobootplugin.Response) {
+	err := json.NewEncoder(os.Stdout).Encode(resp)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "fakeplugin:", err)
+		os.Exit(2)
+	}
+}
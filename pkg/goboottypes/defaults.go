@@ -13,9 +13,9 @@ const (
 	// DefaultMDLintCmd is the default command for the "md" linter.
 	DefaultMDLintCmd = "{{DOCKER_RUN}} ghcr.io/igorshubovych/markdownlint-cli:v0.46.0 markdownlint \"**/*.md\""
 	// DefaultShellLintCmd is the default command for the "shell" linter.
-	DefaultShellLintCmd = "{{DOCKER_RUN}} cytopia/shellcheck:latest-0.8.0 shellcheck {{SH_FILES}}"
+	DefaultShellLintCmd = "{{DOCKER_RUN}} koalaman/shellcheck:v0.11.0 -x {{SH_FILES}}"
 	// DefaultSHFMTCmd is the default command for the "shfmt" linter.
-	DefaultSHFMTCmd = "{{DOCKER_RUN}} cytopia/shfmt:latest-1.10 shfmt -d {{SH_FILES}}"
+	DefaultSHFMTCmd = "{{DOCKER_RUN}} mvdan/shfmt:v3.12.0 -d -i 2 -ci {{SH_FILES}}"
 )
 
 // Default linter identifiers.
@@ -34,10 +34,13 @@ const (
 	LinterSHFMT = "shfmt"
 )
 
+// GoVersionAuto is the "usedGoVersion" value taking the version of the local Go toolchain (go env GOVERSION).
+const GoVersionAuto = "auto"
+
 // Default test commands.
 const (
 	// DefaultGoTestCMD is the default command for running tests.
-	DefaultGoTestCMD = "go test -race -timeout=5m -coverprofile=coverage.txt " +
+	DefaultGoTestCMD = "go test -race -timeout=5m -coverprofile=coverage.txt ./... " +
 		"&& go tool cover -func=coverage.txt; rm -f coverage.txt"
 )
 
@@ -49,6 +52,14 @@ const (
 	TestStyleGo = "go"
 )
 
+// TestStyles returns all supported test styles.
+func TestStyles() []string {
+	return []string{
+		TestStyleGinkgo,
+		TestStyleGo,
+	}
+}
+
 // Default local script names.
 const (
 	// ScriptNameMake is the default name for the "make" script.
@@ -61,6 +72,32 @@ const (
 	ScriptNameCommit = "commit"
 )
 
+// ScriptNames returns all supported local script names.
+func ScriptNames() []string {
+	return []string{
+		ScriptNameMake,
+		ScriptNameTask,
+		ScriptNameScript,
+		ScriptNameCommit,
+	}
+}
+
+// Git providers the project templates render badges and links for.
+const (
+	// GitProviderGitHub is the identifier for GitHub.
+	GitProviderGitHub = "github"
+	// GitProviderGitLab is the identifier for GitLab.
+	GitProviderGitLab = "gitlab"
+)
+
+// GitProviders returns all supported git providers.
+func GitProviders() []string {
+	return []string{
+		GitProviderGitHub,
+		GitProviderGitLab,
+	}
+}
+
 // Default local file names.
 const (
 	// ScriptDirNameScript is the default name for the "script" dir.
@@ -74,3 +111,82 @@ const (
 	// ScriptFileTest is the default name for the "test" script file in the "script" dir.
 	ScriptFileTest = "test.sh"
 )
+
+// Conflict policies for files that already exist in the target project.
+//
+// Can be set using the "conflictPolicy" field in goboot.yml or the "-conflict-policy" CLI flag.
+const (
+	// ConflictPolicyFail aborts the run before writing anything if any generated file already exists.
+	ConflictPolicyFail = "fail"
+	// ConflictPolicySkipExisting keeps existing files untouched.
+	ConflictPolicySkipExisting = "skip-existing"
+	// ConflictPolicyOverwrite replaces existing files.
+	ConflictPolicyOverwrite = "overwrite"
+	// ConflictPolicyBackup copies existing files to "<file>.orig" before replacing them;
+	// if that exists as well, to the first free "<file>.orig.<n>".
+	ConflictPolicyBackup = "backup"
+
+	// DefaultConflictPolicy is used if no conflict policy is configured.
+	DefaultConflictPolicy = ConflictPolicyFail
+
+	// BackupSuffix is appended to the path of existing files saved by ConflictPolicyBackup.
+	BackupSuffix = ".orig"
+)
+
+// ConflictPolicies returns all supported conflict policies.
+func ConflictPolicies() []string {
+	return []string{
+		ConflictPolicyFail,
+		ConflictPolicySkipExisting,
+		ConflictPolicyOverwrite,
+		ConflictPolicyBackup,
+	}
+}
+
+// Config profiles are built-in bundles of enabled services, service config defaults, and template overlays.
+//
+// Can be set using the "profile" field in goboot.yml.
+const (
+	// ProfileMinimal generates the project with golang linting, go-style tests, and a Makefile only.
+	ProfileMinimal = "minimal"
+	// ProfileStandard generates the project with the built-in defaults of every service.
+	ProfileStandard = "standard"
+	// ProfileEnterprise adds a security policy and every local tool to the standard project.
+	ProfileEnterprise = "enterprise"
+	// ProfileOSS adds contribution guidelines and a code of conduct, with go-style tests.
+	ProfileOSS = "oss"
+)
+
+// Profiles returns all built-in config profiles.
+func Profiles() []string {
+	return []string{
+		ProfileMinimal,
+		ProfileStandard,
+		ProfileEnterprise,
+		ProfileOSS,
+	}
+}
+
+// Merge conflict styles decide how "goboot upgrade" writes files that could not be merged cleanly.
+//
+// Can be set using the "-conflict-style" flag of the upgrade subcommand.
+const (
+	// MergeStyleMarkers writes conflicts into the file wrapped in conflict markers.
+	MergeStyleMarkers = "markers"
+	// MergeStyleReject keeps the current version of conflicting sections and writes the rejected ones to "<file>.rej".
+	MergeStyleReject = "rej"
+
+	// DefaultMergeStyle is used if no merge conflict style is given.
+	DefaultMergeStyle = MergeStyleMarkers
+
+	// RejectSuffix is appended to the path of files holding sections rejected by MergeStyleReject.
+	RejectSuffix = ".rej"
+)
+
+// MergeStyles returns all supported merge conflict styles.
+func MergeStyles() []string {
+	return []string{
+		MergeStyleMarkers,
+		MergeStyleReject,
+	}
+}
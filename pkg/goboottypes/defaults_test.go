@@ -2,6 +2,8 @@ package goboottypes_test
 
 import (
 	"os"
+	"path/filepath"
+	"strings"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -49,13 +51,13 @@ var _ = Describe("Types Package - Defaults and Constants", func() {
 			})
 
 			It("pins shellcheck docker image, mount, and pattern", func() {
-				Expect(goboottypes.DefaultShellLintCmd).To(ContainSubstring("cytopia/shellcheck:latest-0.8.0"))
-				Expect(goboottypes.DefaultShellLintCmd).To(ContainSubstring("shellcheck {{SH_FILES}}"))
+				Expect(goboottypes.DefaultShellLintCmd).To(ContainSubstring("koalaman/shellcheck:v0.11.0"))
+				Expect(goboottypes.DefaultShellLintCmd).To(ContainSubstring("-x {{SH_FILES}}"))
 			})
 
 			It("pins shfmt docker image, mount, and pattern", func() {
-				Expect(goboottypes.DefaultSHFMTCmd).To(ContainSubstring("cytopia/shfmt:latest-1.10"))
-				Expect(goboottypes.DefaultSHFMTCmd).To(ContainSubstring("shfmt -d {{SH_FILES}}"))
+				Expect(goboottypes.DefaultSHFMTCmd).To(ContainSubstring("mvdan/shfmt:v3.12.0"))
+				Expect(goboottypes.DefaultSHFMTCmd).To(ContainSubstring("-d -i 2 -ci {{SH_FILES}}"))
 			})
 		})
 	})
@@ -85,6 +87,7 @@ var _ = Describe("Types Package - Defaults and Constants", func() {
 		Context("when inspecting specific default commands", func() {
 			It("matches full golangci-lint command", func() {
 				Expect(goboottypes.DefaultGoTestCMD).To(ContainSubstring("go test -race -timeout=5m"))
+				Expect(goboottypes.DefaultGoTestCMD).To(ContainSubstring("coverage.txt ./..."))
 			})
 		})
 	})
@@ -93,6 +96,7 @@ var _ = Describe("Types Package - Defaults and Constants", func() {
 		It("matches exact identifiers", func() {
 			Expect(goboottypes.TestStyleGinkgo).To(Equal("ginkgo"))
 			Expect(goboottypes.TestStyleGo).To(Equal("go"))
+			Expect(goboottypes.TestStyles()).To(Equal([]string{"ginkgo", "go"}))
 		})
 	})
 
@@ -102,6 +106,13 @@ var _ = Describe("Types Package - Defaults and Constants", func() {
 			Expect(goboottypes.ScriptNameTask).To(Equal("task"))
 			Expect(goboottypes.ScriptNameScript).To(Equal("script"))
 			Expect(goboottypes.ScriptNameCommit).To(Equal("commit"))
+			Expect(goboottypes.ScriptNames()).To(Equal([]string{"make", "task", "script", "commit"}))
+		})
+	})
+
+	Describe("Git Providers", func() {
+		It("matches the providers the project templates know", func() {
+			Expect(goboottypes.GitProviders()).To(Equal([]string{"github", "gitlab"}))
 		})
 	})
 
@@ -118,6 +129,22 @@ var _ = Describe("Types Package - Defaults and Constants", func() {
 		})
 	})
 
+	Describe("Conflict Policies", func() {
+		It("matches exact policy identifiers", func() {
+			Expect(goboottypes.ConflictPolicies()).To(Equal([]string{"fail", "skip-existing", "overwrite", "backup"}))
+			Expect(goboottypes.DefaultConflictPolicy).To(Equal(goboottypes.ConflictPolicyFail))
+			Expect(goboottypes.BackupSuffix).To(Equal(".orig"))
+		})
+	})
+
+	Describe("Merge Styles", func() {
+		It("matches exact style identifiers", func() {
+			Expect(goboottypes.MergeStyles()).To(Equal([]string{"markers", "rej"}))
+			Expect(goboottypes.DefaultMergeStyle).To(Equal(goboottypes.MergeStyleMarkers))
+			Expect(goboottypes.RejectSuffix).To(Equal(".rej"))
+		})
+	})
+
 	Describe("Service Names", func() {
 		It("matches exact service names", func() {
 			Expect(goboottypes.ServiceNameBaseProject).To(Equal("base_project"))
@@ -127,6 +154,46 @@ var _ = Describe("Types Package - Defaults and Constants", func() {
 		})
 	})
 
+	Describe("Template Sets", func() {
+		It("matches the template directories of the repository", func() {
+			Expect(goboottypes.BuiltinPrefix).To(Equal("builtin:"))
+
+			for _, name := range goboottypes.TemplateSets() {
+				Expect(filepath.Join("..", "..", "templates", name)).To(BeADirectory())
+			}
+		})
+
+		It("extends project_base with the overlay sets only", func() {
+			for _, name := range goboottypes.TemplateSets() {
+				switch name {
+				case goboottypes.TemplateSetProjectOSS, goboottypes.TemplateSetProjectEnterprise:
+					Expect(goboottypes.TemplateSetExtends(name)).To(Equal(goboottypes.TemplateSetProjectBase))
+				default:
+					Expect(goboottypes.TemplateSetExtends(name)).To(BeEmpty())
+				}
+			}
+		})
+	})
+
+	Describe("Profiles", func() {
+		It("matches exact profile names", func() {
+			Expect(goboottypes.Profiles()).To(Equal([]string{"minimal", "standard", "enterprise", "oss"}))
+		})
+	})
+
+	Describe("Goboot Metadata", func() {
+		It("keeps the version in sync with the .version file", func() {
+			data, err := os.ReadFile(filepath.Join("..", "..", ".version"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(goboottypes.Version).To(Equal(strings.TrimSpace(string(data))))
+		})
+
+		It("matches the lock file name", func() {
+			Expect(goboottypes.LockFileName).To(Equal(".goboot.lock"))
+			Expect(goboottypes.BaseFileName).To(Equal(".goboot.base"))
+		})
+	})
+
 	Describe("Directory Permissions", func() {
 		Context("when checking default permissions", func() {
 			It("defines directory permissions as 0755", func() {
@@ -1,5 +1,10 @@
 package goboottypes
 
+import (
+	"log"
+	"os"
+)
+
 // Registrar defines a service interface capable of receiving script-related registrations.
 //
 // It is typically implemented by script-generating services like `base_local`
@@ -34,3 +39,68 @@ type ScriptReceiver interface {
 	// enabling it to submit script content dynamically during generation.
 	SetScriptReceiver(registrar Registrar)
 }
+
+// OutputFile describes a single generated file written into an OutputFS.
+type OutputFile struct {
+	// Path is the file path relative to the project root (e.g., "scripts/lint.sh").
+	Path string
+
+	// Content is the fully rendered file content.
+	Content []byte
+
+	// Perm is the permission applied to the written file (e.g., FilePerm or ScriptPerm).
+	Perm os.FileMode
+
+	// Template is the path of the template the file was rendered from (e.g., "templates/lint_base/.golangci.yml.tmpl").
+	Template string
+}
+
+// OutputFS defines the project output that services write their generated files into.
+//
+// All paths are relative to the project root. Implementations must reject paths escaping the root.
+//
+// It allows the orchestrator to decide where generated files end up (e.g., the secure os.Root on disk,
+// or an in-memory filesystem for dry runs) without the services knowing about it.
+type OutputFS interface {
+	// MkdirAll ensures the given relative directory path exists.
+	MkdirAll(relPath string) error
+
+	// WriteFile creates or replaces the given file.
+	WriteFile(file OutputFile) error
+
+	// ReadFile returns the current content of the given file.
+	ReadFile(relPath string) ([]byte, error)
+
+	// Exists reports whether a file or directory exists at the given relative path.
+	Exists(relPath string) (bool, error)
+}
+
+// OutputReceiver defines an interface for services that accept an OutputFS
+// to write their generated files into.
+//
+// Services fall back to their own target directory if no output is set.
+type OutputReceiver interface {
+	// SetOutput provides the implementing service with the OutputFS to write into.
+	SetOutput(out OutputFS)
+}
+
+// Logger receives the progress messages and warnings of a goboot run (e.g., "loaded main service base_lint").
+//
+// It is implemented by *log.Logger; the CLI logs to stdout (see NewStdoutLogger).
+type Logger interface {
+	// Printf logs a message in the manner of fmt.Printf.
+	Printf(format string, v ...any)
+}
+
+// LogReceiver defines an interface for services that accept a Logger for their messages.
+//
+// Services fall back to logging to stdout if no logger is set.
+type LogReceiver interface {
+	// SetLogger provides the implementing service with the Logger to log to.
+	SetLogger(logger Logger)
+}
+
+// NewStdoutLogger returns a Logger printing the messages to stdout as they are, as the CLI does.
+func NewStdoutLogger() Logger {
+	return log.New(os.Stdout, "", 0)
+}
@@ -1,8 +1,11 @@
 package goboottypes_test
 
 import (
+	"log"
+
 	"github.com/it-timo/goboot/pkg/baselint"
 	"github.com/it-timo/goboot/pkg/baselocal"
+	"github.com/it-timo/goboot/pkg/gobootplugin"
 	"github.com/it-timo/goboot/pkg/goboottypes"
 )
 
@@ -10,4 +13,6 @@ import (
 var (
 	_ goboottypes.Registrar      = (*baselocal.BaseLocal)(nil)
 	_ goboottypes.ScriptReceiver = (*baselint.BaseLint)(nil)
+	_ goboottypes.Logger         = (*log.Logger)(nil)
+	_ goboottypes.LogReceiver    = (*gobootplugin.Plugin)(nil)
 )
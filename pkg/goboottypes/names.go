@@ -19,3 +19,55 @@ const (
 	// ServiceNameBaseTest is the name for the base test generation.
 	ServiceNameBaseTest = "base_test"
 )
+
+// The declaration of the template sets embedded into the goboot binary.
+const (
+	// BuiltinPrefix marks a sourcePath that selects an embedded template set (e.g., "builtin:project_base").
+	BuiltinPrefix = "builtin:"
+	// TemplateSetProjectBase is the name of the embedded template set for the base project generation.
+	TemplateSetProjectBase = "project_base"
+	// TemplateSetLintBase is the name of the embedded template set for the base lint generation.
+	TemplateSetLintBase = "lint_base"
+	// TemplateSetLocalBase is the name of the embedded template set for the base local generation.
+	TemplateSetLocalBase = "local_base"
+	// TemplateSetTestBase is the name of the embedded template set for the base test generation.
+	TemplateSetTestBase = "test_base"
+	// TemplateSetProjectOSS is the name of the embedded overlay of project_base for open source projects.
+	TemplateSetProjectOSS = "project_oss"
+	// TemplateSetProjectEnterprise is the name of the embedded overlay of project_base for enterprise projects.
+	TemplateSetProjectEnterprise = "project_enterprise"
+)
+
+// TemplateSets returns the names of all embedded template sets.
+func TemplateSets() []string {
+	return []string{
+		TemplateSetProjectBase,
+		TemplateSetLintBase,
+		TemplateSetLocalBase,
+		TemplateSetTestBase,
+		TemplateSetProjectOSS,
+		TemplateSetProjectEnterprise,
+	}
+}
+
+// TemplateSetExtends returns the template set an embedded overlay set adds its files to,
+// or an empty string if the set is complete on its own.
+func TemplateSetExtends(name string) string {
+	switch name {
+	case TemplateSetProjectOSS, TemplateSetProjectEnterprise:
+		return TemplateSetProjectBase
+	default:
+		return ""
+	}
+}
+
+// The declaration of goboot metadata.
+const (
+	// Version is the goboot release version; it must match the ".version" file in the repository root.
+	Version = "v0.0.2"
+	// LockFileName is the name of the generation manifest written into the generated project root.
+	LockFileName = ".goboot.lock"
+	// BaseFileName is the name of the file holding the rendered content of every generated file,
+	// written next to the lock file as the merge base of upgrades.
+	BaseFileName = ".goboot.base"
+)
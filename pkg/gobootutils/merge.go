@@ -0,0 +1,271 @@
+package gobootutils
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Conflict markers written by Merge.Markers (as used by git).
+const (
+	conflictStart     = "<<<<<<< "
+	conflictSeparator = "======="
+	conflictEnd       = ">>>>>>> "
+)
+
+// Merge is the line-based three-way merge of two versions derived from a common base (see Merge3).
+type Merge struct {
+	chunks []mergeChunk
+}
+
+// mergeChunk is a section of the merge result.
+//
+// Stable and cleanly merged sections only use lines; conflicts keep both sides.
+type mergeChunk struct {
+	lines    []string // Resolved lines; unused for conflicts.
+	conflict bool     // Whether both sides changed the section differently.
+	current  []string // Lines of the current version (conflicts only).
+	next     []string // Lines of the next version (conflicts only).
+}
+
+// Merge3 merges the changes from base to current and from base to next line by line (diff3).
+//
+// Sections changed on only one side, or identically on both sides, are merged cleanly.
+// Sections changed differently on both sides are conflicts.
+//
+// A nil base merges two unrelated versions, so any difference is a conflict.
+func Merge3(base, current, next []byte) *Merge {
+	baseLines := splitLines(base)
+	currentLines := splitLines(current)
+	nextLines := splitLines(next)
+
+	matchCurrent := matchLines(baseLines, currentLines)
+	matchNext := matchLines(baseLines, nextLines)
+
+	merge := &Merge{}
+
+	var baseIdx, curIdx, nextIdx int
+
+	for {
+		// Emit the lines that are unchanged in all three versions.
+		stable := 0
+		for baseIdx+stable < len(baseLines) &&
+			matchCurrent[baseIdx+stable] == curIdx+stable &&
+			matchNext[baseIdx+stable] == nextIdx+stable {
+			stable++
+		}
+
+		if stable > 0 {
+			merge.add(mergeChunk{lines: baseLines[baseIdx : baseIdx+stable]})
+			baseIdx += stable
+			curIdx += stable
+			nextIdx += stable
+
+			continue
+		}
+
+		// Find the next base line kept by both versions; everything before it was changed by at least one side.
+		syncIdx := baseIdx
+		for syncIdx < len(baseLines) && (matchCurrent[syncIdx] < 0 || matchNext[syncIdx] < 0) {
+			syncIdx++
+		}
+
+		if syncIdx == len(baseLines) {
+			merge.resolve(baseLines[baseIdx:], currentLines[curIdx:], nextLines[nextIdx:])
+
+			return merge
+		}
+
+		merge.resolve(baseLines[baseIdx:syncIdx],
+			currentLines[curIdx:matchCurrent[syncIdx]], nextLines[nextIdx:matchNext[syncIdx]])
+
+		baseIdx = syncIdx
+		curIdx = matchCurrent[syncIdx]
+		nextIdx = matchNext[syncIdx]
+	}
+}
+
+// Conflicts returns the number of conflicting sections.
+func (m *Merge) Conflicts() int {
+	count := 0
+
+	for _, chunk := range m.chunks {
+		if chunk.conflict {
+			count++
+		}
+	}
+
+	return count
+}
+
+// Markers returns the merged content with conflicts wrapped in conflict markers (as used by git).
+func (m *Merge) Markers(currentLabel, nextLabel string) []byte {
+	var buf strings.Builder
+
+	for _, chunk := range m.chunks {
+		if !chunk.conflict {
+			writeLines(&buf, chunk.lines)
+
+			continue
+		}
+
+		terminateLine(&buf)
+		buf.WriteString(conflictStart + currentLabel + "\n")
+		writeLines(&buf, chunk.current)
+		terminateLine(&buf)
+		buf.WriteString(conflictSeparator + "\n")
+		writeLines(&buf, chunk.next)
+		terminateLine(&buf)
+		buf.WriteString(conflictEnd + nextLabel + "\n")
+	}
+
+	return []byte(buf.String())
+}
+
+// HasConflictMarkers reports whether content contains a conflict wrapped in conflict markers (see Markers),
+// i.e., a conflict that was written but not resolved yet.
+func HasConflictMarkers(content []byte) bool {
+	var started, separated bool
+
+	for _, line := range splitLines(content) {
+		switch {
+		case strings.HasPrefix(line, conflictStart):
+			started, separated = true, false
+		case started && strings.TrimRight(line, "\r\n") == conflictSeparator:
+			separated = true
+		case separated && strings.HasPrefix(line, conflictEnd):
+			return true
+		}
+	}
+
+	return false
+}
+
+// Rejects returns the merged content keeping the current version of every conflict,
+// and the rejected sections of the next version (empty without conflicts).
+//
+// Every rejected section starts with a header naming its line in the merged content.
+func (m *Merge) Rejects() ([]byte, []byte) {
+	var (
+		merged  strings.Builder
+		rejects strings.Builder
+	)
+
+	line := 1
+
+	for _, chunk := range m.chunks {
+		if !chunk.conflict {
+			writeLines(&merged, chunk.lines)
+			line += len(chunk.lines)
+
+			continue
+		}
+
+		fmt.Fprintf(&rejects, "@@ line %d @@\n", line)
+
+		for _, curLine := range chunk.current {
+			rejects.WriteString("-" + curLine)
+			terminateLine(&rejects)
+		}
+
+		for _, nextLine := range chunk.next {
+			rejects.WriteString("+" + nextLine)
+			terminateLine(&rejects)
+		}
+
+		writeLines(&merged, chunk.current)
+		line += len(chunk.current)
+	}
+
+	return []byte(merged.String()), []byte(rejects.String())
+}
+
+// add appends a chunk, joining consecutive resolved chunks.
+func (m *Merge) add(chunk mergeChunk) {
+	last := len(m.chunks) - 1
+	if !chunk.conflict && last >= 0 && !m.chunks[last].conflict {
+		m.chunks[last].lines = append(m.chunks[last].lines, chunk.lines...)
+
+		return
+	}
+
+	m.chunks = append(m.chunks, chunk)
+}
+
+// resolve merges a section that at least one side changed.
+func (m *Merge) resolve(base, current, next []string) {
+	switch {
+	case slices.Equal(current, next), slices.Equal(base, next):
+		m.add(mergeChunk{lines: current})
+	case slices.Equal(base, current):
+		m.add(mergeChunk{lines: next})
+	default:
+		m.add(mergeChunk{conflict: true, current: current, next: next})
+	}
+}
+
+// matchLines returns, for every line of base, the index of the matching line in other (or -1),
+// based on the longest common subsequence of both.
+func matchLines(base, other []string) []int {
+	// lcs[i][j] is the LCS length of base[i:] and other[j:].
+	lcs := make([][]int, len(base)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(other)+1)
+	}
+
+	for i := len(base) - 1; i >= 0; i-- {
+		for j := len(other) - 1; j >= 0; j-- {
+			if base[i] == other[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	match := make([]int, len(base))
+
+	var i, j int
+
+	for i < len(base) {
+		switch {
+		case j < len(other) && base[i] == other[j]:
+			match[i] = j
+			i++
+			j++
+		case j < len(other) && lcs[i][j+1] >= lcs[i+1][j]:
+			j++
+		default:
+			match[i] = -1
+			i++
+		}
+	}
+
+	return match
+}
+
+// splitLines splits content into lines including their line endings.
+//
+// Only the last line may lack a line ending.
+func splitLines(content []byte) []string {
+	lines := strings.SplitAfter(string(content), "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return lines
+}
+
+// writeLines writes the lines unchanged.
+func writeLines(buf *strings.Builder, lines []string) {
+	for _, line := range lines {
+		buf.WriteString(line)
+	}
+}
+
+// terminateLine ends the last written line with a newline if it has none.
+func terminateLine(buf *strings.Builder) {
+	if buf.Len() > 0 && !strings.HasSuffix(buf.String(), "\n") {
+		buf.WriteString("\n")
+	}
+}
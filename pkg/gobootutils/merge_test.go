@@ -0,0 +1,94 @@
+package gobootutils_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/it-timo/goboot/pkg/gobootutils"
+)
+
+var _ = Describe("Three-way merge", func() {
+	const base = "one\ntwo\nthree\nfour\nfive\n"
+
+	markers := func(merge *gobootutils.Merge) string {
+		return string(merge.Markers("current", "next"))
+	}
+
+	It("keeps content changed on neither side", func() {
+		merge := gobootutils.Merge3([]byte(base), []byte(base), []byte(base))
+
+		Expect(merge.Conflicts()).To(BeZero())
+		Expect(markers(merge)).To(Equal(base))
+	})
+
+	It("takes changes made on one side only", func() {
+		current := "one\ntwo (edited)\nthree\nfour\nfive\n"
+		next := "one\ntwo\nthree\nfour\nfive\nsix\n"
+
+		merge := gobootutils.Merge3([]byte(base), []byte(current), []byte(next))
+
+		Expect(merge.Conflicts()).To(BeZero())
+		Expect(markers(merge)).To(Equal("one\ntwo (edited)\nthree\nfour\nfive\nsix\n"))
+	})
+
+	It("merges deletions and identical changes", func() {
+		current := "one\nthree\nfour\nfive (new)\n"
+		next := "one\ntwo\nthree\nfour\nfive (new)\n"
+
+		merge := gobootutils.Merge3([]byte(base), []byte(current), []byte(next))
+
+		Expect(merge.Conflicts()).To(BeZero())
+		Expect(markers(merge)).To(Equal("one\nthree\nfour\nfive (new)\n"))
+	})
+
+	It("marks sections changed differently on both sides", func() {
+		current := "one\ntwo\nthree (mine)\nfour\nfive\n"
+		next := "one\ntwo\nthree (theirs)\nfour\nfive (theirs)\n"
+
+		merge := gobootutils.Merge3([]byte(base), []byte(current), []byte(next))
+
+		Expect(merge.Conflicts()).To(Equal(1))
+		Expect(markers(merge)).To(Equal("one\ntwo\n" +
+			"<<<<<<< current\nthree (mine)\n=======\nthree (theirs)\n>>>>>>> next\n" +
+			"four\nfive (theirs)\n"))
+	})
+
+	It("keeps the current side and collects rejected sections", func() {
+		current := "one\ntwo\nthree (mine)\nfour\nfive\n"
+		next := "one (theirs)\ntwo\nthree (theirs)\nfour\nfive\n"
+
+		merged, rejects := gobootutils.Merge3([]byte(base), []byte(current), []byte(next)).Rejects()
+
+		Expect(string(merged)).To(Equal("one (theirs)\ntwo\nthree (mine)\nfour\nfive\n"))
+		Expect(string(rejects)).To(Equal("@@ line 3 @@\n-three (mine)\n+three (theirs)\n"))
+	})
+
+	It("treats every difference as a conflict without a base", func() {
+		merge := gobootutils.Merge3(nil, []byte("mine\n"), []byte("theirs"))
+
+		Expect(merge.Conflicts()).To(Equal(1))
+		Expect(markers(merge)).To(Equal("<<<<<<< current\nmine\n=======\ntheirs\n>>>>>>> next\n"))
+
+		merge = gobootutils.Merge3(nil, []byte("same\n"), []byte("same\n"))
+		Expect(merge.Conflicts()).To(BeZero())
+		Expect(markers(merge)).To(Equal("same\n"))
+	})
+
+	It("detects unresolved conflict markers", func() {
+		current := "one\ntwo\nthree (mine)\nfour\nfive\n"
+		next := "one\ntwo\nthree (theirs)\nfour\nfive\n"
+
+		Expect(gobootutils.HasConflictMarkers(gobootutils.Merge3([]byte(base), []byte(current), []byte(next)).
+			Markers("current", "next"))).To(BeTrue())
+		Expect(gobootutils.HasConflictMarkers([]byte(current))).To(BeFalse())
+		Expect(gobootutils.HasConflictMarkers([]byte("<<<<<<< only the start\n=======\n"))).To(BeFalse())
+		Expect(gobootutils.HasConflictMarkers(nil)).To(BeFalse())
+	})
+
+	It("preserves a missing trailing newline", func() {
+		merge := gobootutils.Merge3([]byte("a\nb"), []byte("a (edited)\nb"), []byte("a\nb"))
+
+		Expect(merge.Conflicts()).To(BeZero())
+		Expect(markers(merge)).To(Equal("a (edited)\nb"))
+	})
+})
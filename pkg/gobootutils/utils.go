@@ -10,6 +10,8 @@ This package emphasizes reusability and minimal external assumptions.
 package gobootutils
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"os"
 	"path"
@@ -180,3 +182,12 @@ func CreateRootDir(targetDir, name string) (*os.Root, error) {
 
 	return curRoot, nil
 }
+
+// HashContent returns the hex-encoded SHA-256 checksum of the given content.
+//
+// It is used to fingerprint generated files and configs in the generation manifest.
+func HashContent(content []byte) string {
+	sum := sha256.Sum256(content)
+
+	return hex.EncodeToString(sum[:])
+}
@@ -105,6 +105,15 @@ var _ = Describe("Utils Package", func() {
 		})
 	})
 
+	Describe("HashContent", func() {
+		It("returns the hex-encoded SHA-256 checksum", func() {
+			Expect(gobootutils.HashContent([]byte("goboot"))).
+				To(Equal("ac46f0bf6a3897bdd86febcba4845d80cfda75361204cb18bda893d28e8c49a4"))
+			Expect(gobootutils.HashContent(nil)).
+				To(Equal("e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"))
+		})
+	})
+
 	Describe("ComparePaths", func() {
 		var (
 			testFile1 string
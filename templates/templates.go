@@ -0,0 +1,18 @@
+/*
+Package templates embeds the default template sets into the goboot binary.
+
+Every top-level directory is a template set used by one service (e.g., "project_base" for base_project).
+Configs select an embedded set with a sourcePath of goboottypes.BuiltinPrefix plus the set name
+(e.g., "builtin:project_base"), or by leaving sourcePath empty (see gobootfs.Templates).
+
+Overlay sets (e.g., "project_oss") only hold the files they add to or replace in the set they extend
+(see goboottypes.TemplateSetExtends).
+*/
+package templates
+
+import "embed"
+
+// FS holds the embedded template sets, including dotfiles (e.g., ".golangci.yml.tmpl").
+//
+//go:embed all:project_base all:lint_base all:local_base all:test_base all:project_oss all:project_enterprise
+var FS embed.FS